@@ -0,0 +1,363 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/kanji"
+	"github.com/mocho271828/rs_coding-compresser/internal/rs"
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// CharResult は1文字の圧縮の過程
+type CharResult struct {
+	Char       string `json:"Char"`
+	ShiftJIS   string `json:"ShiftJIS"`
+	Subtracted string `json:"Subtracted"` // Shift JIS のコードから 8140 または C140 を引いた値
+	Value      string `json:"Value"`      // 13ビットの値 (16進数)
+	Binary     string `json:"Binary"`     // 13ビットの値 (2進数)
+}
+
+// StreamResult はデータコード語を組み立てる各段階のビット列
+type StreamResult struct {
+	ModeIndicator      string `json:"ModeIndicator"`
+	CharCountIndicator string `json:"CharCountIndicator"`
+	Body               string `json:"Body"`
+	Terminated         string `json:"Terminated"`
+	Padded             string `json:"Padded"`
+}
+
+// EncodeResult は encode と explain の結果
+type EncodeResult struct {
+	Text              string        `json:"Text"`
+	Chars             []CharResult  `json:"Chars"`
+	Stream            *StreamResult `json:"Stream,omitempty"`    // explain の場合だけ
+	Generator         []int         `json:"Generator,omitempty"` // explain の場合だけ (係数を α の指数で表したもの)
+	DataCodewords     string        `json:"DataCodewords"`
+	ECCCodewords      string        `json:"ECCCodewords"`
+	Codeword          string        `json:"Codeword"`
+	MaskPattern       int           `json:"MaskPattern"`
+	MaskBytes         string        `json:"MaskBytes"`
+	MaskedCodeword    string        `json:"MaskedCodeword"`
+	FormatInformation string        `json:"FormatInformation"`
+}
+
+// ECCResult は ecc の結果
+type ECCResult struct {
+	Data         string `json:"Data"`
+	Parity       int    `json:"Parity"`
+	ECCCodewords string `json:"ECCCodewords"`
+	Codeword     string `json:"Codeword"`
+}
+
+// MaskResult は mask の結果
+type MaskResult struct {
+	Codeword       string `json:"Codeword"`
+	MaskPattern    int    `json:"MaskPattern"`
+	MaskBytes      string `json:"MaskBytes"`
+	MaskedCodeword string `json:"MaskedCodeword"`
+}
+
+// DecodeResult は decode の結果
+type DecodeResult struct {
+	MaskedCodeword    string       `json:"MaskedCodeword"`
+	MaskPattern       int          `json:"MaskPattern"`
+	Codeword          string       `json:"Codeword"`          // マスクを外した符号語
+	CorrectedCodeword string       `json:"CorrectedCodeword"` // 誤りを訂正した符号語
+	ErrorPositions    []int        `json:"ErrorPositions"`    // 訂正したバイトの位置 (0始まり)
+	Text              string       `json:"Text"`
+	Chars             []CharResult `json:"Chars"`
+}
+
+// commonFlags は全てのサブコマンドにあるフラグ
+type commonFlags struct {
+	json  bool // 結果を JSON で出力する
+	lines bool // 標準入力の1行ごとに処理し, 1行に1つの JSON を出力する
+}
+
+// newFlagSet はサブコマンドのフラグを作る. 全てのサブコマンドに -json と -lines がある.
+func newFlagSet(name, input string) (*flag.FlagSet, *commonFlags) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "使い方: rscoder %s [オプション] [%s]\n", name, input)
+		fs.PrintDefaults()
+	}
+	flags := &commonFlags{}
+	fs.BoolVar(&flags.json, "json", false, "結果を JSON で出力する")
+	fs.BoolVar(&flags.lines, "lines", false, "標準入力の1行ごとに処理し, 1行に1つの JSON を出力する")
+	return fs, flags
+}
+
+// processor はサブコマンドの処理 (入力から結果を求める) と, 結果の表での表示
+type processor struct {
+	process func(input string) (interface{}, error)
+	print   func(result interface{})
+}
+
+// run は引数か標準入力の入力を処理して結果を表示する. -lines では標準入力を1行ずつ処理する (runLines).
+func (p processor) run(args []string, flags *commonFlags) error {
+	if flags.lines {
+		// 1回目の Ctrl-C で行の区切りでやめる. 入力を待っている間は止まらないので, 2回目で終わらせられるよう元に戻す.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		context.AfterFunc(ctx, stop)
+		return runLines(ctx, os.Stdin, os.Stdout, p.process)
+	}
+	input, err := readInput(args)
+	if err != nil {
+		return err
+	}
+	result, err := p.process(input)
+	if err != nil {
+		return err
+	}
+	if flags.json {
+		return printJSON(result)
+	}
+	p.print(result)
+	return nil
+}
+
+// maskFlag はマスクパターン参照子のフラグを加える
+func maskFlag(fs *flag.FlagSet) *int {
+	return fs.Int("mask", 0, "マスクパターン参照子 (0〜7)")
+}
+
+func checkMask(pattern int) error {
+	if pattern < 0 || pattern >= len(qr.MaskConditions) {
+		return fmt.Errorf("マスクパターン番号は0から%dの範囲で指定してください.", len(qr.MaskConditions)-1)
+	}
+	return nil
+}
+
+func runEncode(args []string) error {
+	return encodeCommand("encode", args, false)
+}
+
+func runExplain(args []string) error {
+	return encodeCommand("explain", args, true)
+}
+
+// encodeCommand は encode と explain を行う. explain ではビット列と生成多項式も求め, 段階ごとの表で示す.
+func encodeCommand(name string, args []string, explain bool) error {
+	fs, flags := newFlagSet(name, "漢字")
+	pattern := maskFlag(fs)
+	fs.Parse(args)
+	if err := checkMask(*pattern); err != nil {
+		return err
+	}
+	return processor{
+		process: func(text string) (interface{}, error) { return encode(text, *pattern, explain) },
+		print:   func(result interface{}) { printEncode(result.(EncodeResult)) },
+	}.run(fs.Args(), flags)
+}
+
+// checkText は符号化する文字列が空でなく, 型番1に収まる文字数かを確かめる
+func checkText(text string) error {
+	if text == "" {
+		return fmt.Errorf("漢字が入力されていません.")
+	}
+	if n := len([]rune(text)); n > qr.MaxKanjiChars {
+		return fmt.Errorf("文字数が多すぎます. %d文字以下で入力してください: %d文字", qr.MaxKanjiChars, n)
+	}
+	return nil
+}
+
+// encode は漢字の文字列をマスク後の符号語まで符号化する
+func encode(text string, pattern int, explain bool) (EncodeResult, error) {
+	if err := checkText(text); err != nil {
+		return EncodeResult{}, err
+	}
+	r, err := qr.Encode(text, qr.WithMask(pattern))
+	if err != nil {
+		return EncodeResult{}, err
+	}
+
+	result := EncodeResult{
+		Text:              text,
+		Chars:             charResults(r.Chars),
+		DataCodewords:     formatHex(r.DataCodewords),
+		ECCCodewords:      formatHex(r.ECCCodewords),
+		Codeword:          formatHex(r.Codeword),
+		MaskPattern:       pattern,
+		MaskBytes:         formatHex(r.MaskBytes),
+		MaskedCodeword:    formatHex(r.MaskedCodeword),
+		FormatInformation: fmt.Sprintf("%015b", r.FormatInformation),
+	}
+	if explain {
+		result.Stream = &StreamResult{
+			ModeIndicator:      r.Stream.ModeIndicator,
+			CharCountIndicator: r.Stream.CharCountIndicator,
+			Body:               r.Stream.Body,
+			Terminated:         r.Stream.Terminated,
+			Padded:             r.Stream.Padded,
+		}
+		f := rs.QR.Field()
+		for _, c := range rs.QR.Generator(qr.ECCCodewords) {
+			result.Generator = append(result.Generator, f.Log(c))
+		}
+	}
+	return result, nil
+}
+
+func charResults(chars []kanji.Char) []CharResult {
+	results := make([]CharResult, len(chars))
+	for i, c := range chars {
+		results[i] = CharResult{
+			Char:       string(c.Rune),
+			ShiftJIS:   fmt.Sprintf("%04X", c.ShiftJIS),
+			Subtracted: fmt.Sprintf("%04X - %04X = %04X", c.ShiftJIS, c.Offset, c.Subtracted),
+			Value:      fmt.Sprintf("%04X", c.Value),
+			Binary:     fmt.Sprintf("%0*b", kanji.Bits, c.Value),
+		}
+	}
+	return results
+}
+
+func runECC(args []string) error {
+	fs, flags := newFlagSet("ecc", "バイト列")
+	parity := fs.Int("parity", qr.ECCCodewords, "誤り訂正コード語の数")
+	fs.Parse(args)
+	return processor{
+		process: func(input string) (interface{}, error) { return ecc(input, *parity) },
+		print: func(result interface{}) {
+			r := result.(ECCResult)
+			printTable([][2]string{
+				{"データ", r.Data},
+				{"誤り訂正コード語の数", fmt.Sprint(r.Parity)},
+				{"誤り訂正コード語", r.ECCCodewords},
+				{"符号語", r.Codeword},
+			})
+		},
+	}.run(fs.Args(), flags)
+}
+
+// ecc はバイト列に誤り訂正コード語を付ける
+func ecc(input string, parity int) (ECCResult, error) {
+	data, err := parseBytes(input)
+	if err != nil {
+		return ECCResult{}, err
+	}
+	codeword, err := rs.QR.Encode(data, parity)
+	if err != nil {
+		return ECCResult{}, err
+	}
+	return ECCResult{
+		Data:         formatHex(data),
+		Parity:       parity,
+		ECCCodewords: formatHex(codeword[len(data):]),
+		Codeword:     formatHex(codeword),
+	}, nil
+}
+
+// parseCodeword は26バイトの符号語を読む
+func parseCodeword(input string) ([]byte, error) {
+	codeword, err := parseBytes(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(codeword) != qr.TotalCodewords {
+		return nil, fmt.Errorf("符号語は%dバイトである必要がありますが, %dバイトでした.", qr.TotalCodewords, len(codeword))
+	}
+	return codeword, nil
+}
+
+func runMask(args []string) error {
+	fs, flags := newFlagSet("mask", "バイト列")
+	pattern := maskFlag(fs)
+	fs.Parse(args)
+	if err := checkMask(*pattern); err != nil {
+		return err
+	}
+	return processor{
+		process: func(input string) (interface{}, error) { return mask(input, *pattern) },
+		print: func(result interface{}) {
+			r := result.(MaskResult)
+			printTable([][2]string{
+				{"符号語", r.Codeword},
+				{"マスクパターン", fmt.Sprint(r.MaskPattern)},
+				{"マスク", r.MaskBytes},
+				{"マスク後", r.MaskedCodeword},
+			})
+		},
+	}.run(fs.Args(), flags)
+}
+
+// mask は26バイトの符号語にマスクを掛ける
+func mask(input string, pattern int) (MaskResult, error) {
+	codeword, err := parseCodeword(input)
+	if err != nil {
+		return MaskResult{}, err
+	}
+	maskBytes, err := qr.MaskBytes(pattern)
+	if err != nil {
+		return MaskResult{}, err
+	}
+	return MaskResult{
+		Codeword:       formatHex(codeword),
+		MaskPattern:    pattern,
+		MaskBytes:      formatHex(maskBytes),
+		MaskedCodeword: formatHex(qr.Mask(codeword, maskBytes)),
+	}, nil
+}
+
+func runDecode(args []string) error {
+	fs, flags := newFlagSet("decode", "バイト列")
+	pattern := maskFlag(fs)
+	fs.Parse(args)
+	if err := checkMask(*pattern); err != nil {
+		return err
+	}
+	return processor{
+		process: func(input string) (interface{}, error) { return decode(input, *pattern) },
+		print: func(result interface{}) {
+			r := result.(DecodeResult)
+			printTable([][2]string{
+				{"マスク後の符号語", r.MaskedCodeword},
+				{"マスクパターン", fmt.Sprint(r.MaskPattern)},
+				{"マスクを外した符号語", r.Codeword},
+				{"訂正した符号語", r.CorrectedCodeword},
+				{"誤りの位置", fmt.Sprint(r.ErrorPositions)},
+				{"文字列", r.Text},
+			})
+			fmt.Println()
+			printChars(r.Chars)
+		},
+	}.run(fs.Args(), flags)
+}
+
+// decode はマスク後の符号語のマスクを外し, 誤りを訂正してから漢字に戻す
+func decode(input string, pattern int) (DecodeResult, error) {
+	masked, err := parseCodeword(input)
+	if err != nil {
+		return DecodeResult{}, err
+	}
+	maskBytes, err := qr.MaskBytes(pattern)
+	if err != nil {
+		return DecodeResult{}, err
+	}
+	codeword := qr.Mask(masked, maskBytes)
+	corrected, positions, err := rs.QR.Decode(codeword, qr.ECCCodewords)
+	if err != nil {
+		return DecodeResult{}, err
+	}
+	text, chars, err := qr.ParseKanji(corrected[:qr.DataCodewords])
+	if err != nil {
+		return DecodeResult{}, err
+	}
+	if positions == nil {
+		positions = []int{}
+	}
+	return DecodeResult{
+		MaskedCodeword:    formatHex(masked),
+		MaskPattern:       pattern,
+		Codeword:          formatHex(codeword),
+		CorrectedCodeword: formatHex(corrected),
+		ErrorPositions:    positions,
+		Text:              text,
+		Chars:             charResults(chars),
+	}, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readInput は残りの引数を空白でつないだもの, 引数がなければ標準入力の内容を返す (前後の空白は除く)
+func readInput(args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.TrimSpace(strings.Join(args, " ")), nil
+	}
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("標準入力を読めませんでした: %v", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// parseBytes はバイト列を読み取る. 空白で区切った各語が0と1だけで桁数が8の倍数なら2進数,
+// それ以外は16進数として読む. 先頭に 0x を付けると常に16進数として読む.
+func parseBytes(s string) ([]byte, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("バイト列が入力されていません.")
+	}
+	if !strings.HasPrefix(fields[0], "0x") && isBinary(fields) {
+		return parseBinary(strings.Join(fields, "")), nil
+	}
+	joined := strings.TrimPrefix(strings.Join(fields, ""), "0x")
+	if len(joined)%2 != 0 {
+		return nil, fmt.Errorf("16進数の桁数が奇数です: %d桁", len(joined))
+	}
+	b, err := hex.DecodeString(joined)
+	if err != nil {
+		return nil, fmt.Errorf("16進数を読めませんでした: %v", err)
+	}
+	return b, nil
+}
+
+// isBinary は各語が0と1だけからなり, 桁数が8の倍数かどうかを返す
+func isBinary(fields []string) bool {
+	for _, f := range fields {
+		if len(f)%8 != 0 || strings.Trim(f, "01") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBinary は0と1だけからなる8の倍数の桁の文字列をバイト列にする
+func parseBinary(s string) []byte {
+	b := make([]byte, len(s)/8)
+	for i := range b {
+		for _, bit := range s[i*8 : i*8+8] {
+			b[i] = b[i]<<1 | byte(bit-'0')
+		}
+	}
+	return b
+}
+
+// formatHex はバイト列を空白区切りの16進数にする
+func formatHex(b []byte) string {
+	parts := make([]string, len(b))
+	for i, v := range b {
+		parts[i] = fmt.Sprintf("%02X", v)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LineError は -lines で処理できなかった行の結果
+type LineError struct {
+	Line  int    `json:"Line"` // 1始まりの行番号
+	Input string `json:"Input"`
+	Error string `json:"Error"`
+}
+
+// runLines は r の1行ごとに process を呼び, 結果を1行に1つの JSON で w に書く (空行は飛ばす).
+// 処理できなかった行も LineError を書いて続け, 最後にまとめてエラーを返す (終了コードが1になる).
+// ctx が取り消されると (Ctrl-C), 処理中の行を書き終えてからやめる.
+func runLines(ctx context.Context, r io.Reader, w io.Writer, process func(input string) (interface{}, error)) error {
+	scanner := bufio.NewScanner(r)
+	enc := json.NewEncoder(w)
+	failed := 0
+	for n := 1; scanner.Scan(); n++ {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%d行目の前で中断しました", n)
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		result, err := process(line)
+		if err != nil {
+			failed++
+			result = LineError{Line: n, Input: line, Error: err.Error()}
+		}
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("標準入力を読めませんでした: %v", err)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d行を処理できませんでした", failed)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// rscoder は QRコード (型番1-L, 漢字モード) の符号化の各段階をコマンドラインで行う.
+// ブラウザを使わずに, スクリプトや課題の採点から同じ実装を呼べるようにする.
+//
+//	rscoder encode [-mask n] [-json] 漢字       漢字 → データコード語 → 符号語 → マスク後の符号語
+//	rscoder ecc [-parity n] [-json] バイト列   データに誤り訂正コード語を付ける
+//	rscoder mask [-mask n] [-json] バイト列    26バイトの符号語にマスクを掛ける (外す場合も同じ)
+//	rscoder decode [-mask n] [-json] バイト列  マスク後の符号語から誤りを訂正して漢字に戻す
+//	rscoder explain [-mask n] [-json] 漢字      符号化の過程を段階ごとの表で示す
+//	rscoder tui [-mask n] 漢字                 符号化の過程をキー操作で1画面ずつ進めて示す
+//	rscoder serve [-addr a] [-token t]         学習用のページと API, 授業のライブ表示を公開する
+//
+// 入力を引数で渡さない場合は標準入力から読む. バイト列は16進数 ("40 11 EC") か,
+// 8桁ずつの2進数 ("01000000 00010001") で指定する.
+//
+// -lines を付けると標準入力の1行を1つの入力として順に処理し, 結果を1行に1つの JSON で書く.
+// シェルのパイプや Makefile で例をまとめて作る場合に使う:
+//
+//	printf '漢字\n符号\n' | rscoder encode -lines -mask 2 > examples.jsonl
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command はサブコマンド
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"encode", "漢字を符号語にする", runEncode},
+	{"ecc", "データに誤り訂正コード語を付ける", runECC},
+	{"mask", "26バイトの符号語にマスクを掛ける", runMask},
+	{"decode", "マスク後の符号語を漢字に戻す", runDecode},
+	{"explain", "符号化の過程を段階ごとに示す", runExplain},
+	{"tui", "符号化の過程をキー操作で1画面ずつ示す", runTUI},
+	{"serve", "学習用のページと API, ライブ表示を公開する", runServe},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	if name == "-h" || name == "-help" || name == "--help" || name == "help" {
+		usage()
+		return
+	}
+	for _, c := range commands {
+		if c.name == name {
+			if err := c.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "rscoder %s: %v\n", name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "rscoder: 不明なサブコマンドです: %s\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "使い方: rscoder <サブコマンド> [オプション] [入力]")
+	fmt.Fprintln(os.Stderr, "")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "入力を省略すると標準入力から読みます. -lines を付けると標準入力の1行ごとに処理して, 1行に1つの JSON を出力します.")
+	fmt.Fprintln(os.Stderr, "各サブコマンドのオプションは rscoder <サブコマンド> -h で表示します.")
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// printJSON は結果を字下げした JSON で標準出力に書く
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable は項目名と値の組を2列の表にする
+func printTable(rows [][2]string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s\t%s\n", row[0], row[1])
+	}
+	w.Flush()
+}
+
+// printChars は各文字の圧縮の過程を表にする
+func printChars(chars []CharResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "文字\tShift JIS\t引き算\t13ビット (16進)\t13ビット (2進)")
+	for _, c := range chars {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Char, c.ShiftJIS, c.Subtracted, c.Value, c.Binary)
+	}
+	w.Flush()
+}
+
+// printEncode は encode と explain の結果を表示する. explain の結果なら段階ごとに見出しを付ける.
+func printEncode(r EncodeResult) {
+	if r.Stream == nil {
+		printTable([][2]string{
+			{"文字列", r.Text},
+			{"データコード語", r.DataCodewords},
+			{"誤り訂正コード語", r.ECCCodewords},
+			{"マスクパターン", fmt.Sprint(r.MaskPattern)},
+			{"マスク後の符号語", r.MaskedCodeword},
+		})
+		return
+	}
+
+	fmt.Println("STEP 1: 漢字の圧縮")
+	printChars(r.Chars)
+
+	fmt.Println()
+	fmt.Println("STEP 2: ビット列の組み立て")
+	printTable([][2]string{
+		{"モード指示子", r.Stream.ModeIndicator},
+		{"文字数指示子", r.Stream.CharCountIndicator},
+		{"データ", r.Stream.Body},
+		{"終端パターンまで", r.Stream.Terminated},
+		{"バイト境界まで", groupBits(r.Stream.Padded)},
+		{"データコード語", r.DataCodewords},
+	})
+
+	fmt.Println()
+	fmt.Println("STEP 3: 誤り訂正コード語 (RS 符号)")
+	exponents := make([]string, len(r.Generator))
+	for i, e := range r.Generator {
+		exponents[i] = fmt.Sprintf("α^%d", e)
+	}
+	printTable([][2]string{
+		{"生成多項式の係数", strings.Join(exponents, " ")},
+		{"誤り訂正コード語", r.ECCCodewords},
+		{"符号語", r.Codeword},
+	})
+
+	fmt.Println()
+	fmt.Println("STEP 4: マスク")
+	printTable([][2]string{
+		{"マスクパターン", fmt.Sprint(r.MaskPattern)},
+		{"マスク", r.MaskBytes},
+		{"マスク後の符号語", r.MaskedCodeword},
+		{"形式情報", r.FormatInformation},
+	})
+}
+
+// groupBits はビット列を8桁ずつ空白で区切る
+func groupBits(bits string) string {
+	var groups []string
+	for i := 0; i < len(bits); i += 8 {
+		end := i + 8
+		if end > len(bits) {
+			end = len(bits)
+		}
+		groups = append(groups, bits[i:end])
+	}
+	return strings.Join(groups, " ")
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/classroom"
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// maxRequestBytes は受け付ける要求の本文の大きさの上限
+const maxRequestBytes = 64 << 10
+
+// serveRequest は API の要求の本文と, ライブ表示で教員が送る JSON.
+// 省略した項目は各サブコマンドのフラグの既定値と同じにする.
+type serveRequest struct {
+	Input       string `json:"input"`       // 漢字 (encode, explain) かバイト列 (ecc, mask, decode)
+	MaskPattern *int   `json:"maskPattern"` // 省略すると0
+	Parity      *int   `json:"parity"`      // ecc の誤り訂正コード語の数. 省略すると7
+}
+
+func (r serveRequest) maskPattern() int {
+	if r.MaskPattern == nil {
+		return 0
+	}
+	return *r.MaskPattern
+}
+
+// serveError は処理できなかった要求への応答
+type serveError struct {
+	Error string `json:"Error"`
+}
+
+// serveCommands は API の名前ごとの処理 (パス /api/encode などに当たる). 結果は -json で書くものと同じ.
+var serveCommands = map[string]func(r serveRequest) (interface{}, error){
+	"encode": func(r serveRequest) (interface{}, error) {
+		if err := checkMask(r.maskPattern()); err != nil {
+			return nil, err
+		}
+		return encode(r.Input, r.maskPattern(), false)
+	},
+	"explain": func(r serveRequest) (interface{}, error) {
+		if err := checkMask(r.maskPattern()); err != nil {
+			return nil, err
+		}
+		return encode(r.Input, r.maskPattern(), true)
+	},
+	"ecc": func(r serveRequest) (interface{}, error) {
+		parity := qr.ECCCodewords
+		if r.Parity != nil {
+			parity = *r.Parity
+		}
+		return ecc(r.Input, parity)
+	},
+	"mask": func(r serveRequest) (interface{}, error) {
+		if err := checkMask(r.maskPattern()); err != nil {
+			return nil, err
+		}
+		return mask(r.Input, r.maskPattern())
+	},
+	"decode": func(r serveRequest) (interface{}, error) {
+		if err := checkMask(r.maskPattern()); err != nil {
+			return nil, err
+		}
+		return decode(r.Input, r.maskPattern())
+	},
+}
+
+// runServe は学習用のページと API, 授業のライブ表示をまとめて公開する. 1つのプログラムを動かすだけでブラウザから使える.
+//
+//	rscoder serve -addr localhost:8080 -token 合言葉
+//	curl -d '{"input": "漢字", "maskPattern": 2}' localhost:8080/api/encode
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "使い方: rscoder serve [オプション]")
+		fmt.Fprintln(fs.Output(), "学習用のページ, POST /api/encode, /api/explain, /api/ecc, /api/mask, /api/decode と, ライブ表示 (/live.html) を公開します.")
+		fs.PrintDefaults()
+	}
+	addr := fs.String("addr", "localhost:8080", "公開するアドレス")
+	token := fs.String("token", os.Getenv("QRLAB_INSTRUCTOR_TOKEN"), "ライブ表示の教員の合言葉 (省略すると起動するたびに作る. 環境変数 QRLAB_INSTRUCTOR_TOKEN でも指定できる)")
+	fs.Parse(args)
+	host, port, err := net.SplitHostPort(*addr)
+	if err != nil {
+		return fmt.Errorf("アドレスを読めませんでした: %v", err)
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	if *token == "" {
+		if *token, err = classroom.NewToken(); err != nil {
+			return fmt.Errorf("教員の合言葉を作れませんでした: %v", err)
+		}
+	}
+	base := "http://" + net.JoinHostPort(host, port)
+	log.Printf("学習用のページを %s/ で公開します (API は /api/encode など, ライブ表示は /live.html)", base)
+	log.Printf("教員は %s/live.html?role=instructor&token=%s を開いてください", base, url.QueryEscape(*token))
+	return http.ListenAndServe(*addr, classroom.NewHandler(newServeAPI(), livePipeline, *token))
+}
+
+// newServeAPI は serveCommands を POST /encode などで公開する http.Handler を返す.
+// 入力の誤りは 400 で serveError を返す.
+func newServeAPI() http.Handler {
+	mux := http.NewServeMux()
+	for name, process := range serveCommands {
+		mux.HandleFunc("/"+name, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				w.Header().Set("Allow", http.MethodPost)
+				writeServeResponse(w, http.StatusMethodNotAllowed, serveError{fmt.Sprintf("%s には対応していません. POST で要求してください.", r.Method)})
+				return
+			}
+			var req serveRequest
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+			if err == nil {
+				err = json.Unmarshal(body, &req)
+			}
+			if err != nil {
+				writeServeResponse(w, http.StatusBadRequest, serveError{fmt.Sprintf("要求の本文を JSON として読めませんでした: %v", err)})
+				return
+			}
+			result, err := process(req)
+			if err != nil {
+				writeServeResponse(w, http.StatusBadRequest, serveError{err.Error()})
+				return
+			}
+			writeServeResponse(w, http.StatusOK, result)
+		})
+	}
+	return mux
+}
+
+func writeServeResponse(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// liveSteps はライブ表示の段階の名前と, その段階で使う serveCommands の処理.
+// 最初の段階は各文字の圧縮とビット列も示すため explain を使う.
+var liveSteps = []struct{ name, command string }{
+	{"encode", "explain"},
+	{"ecc", "ecc"},
+	{"mask", "mask"},
+}
+
+// livePipeline はライブ表示で教員の入力を liveSteps の順に処理する.
+// 教員の端末は入力の文字列をそのまま送るか, serveRequest と同じ項目の JSON ({"input": "漢字", "maskPattern": 2}) を送る.
+// 次の段階の入力は前の段階の結果 (データコード語, 符号語) から取る.
+func livePipeline(ctx context.Context, payload []byte) []classroom.Step {
+	var req serveRequest
+	if trimmed := bytes.TrimSpace(payload); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &req); err != nil {
+			req = serveRequest{Input: string(payload)}
+		}
+	} else {
+		req.Input = string(payload)
+	}
+
+	var steps []classroom.Step
+	for _, step := range liveSteps {
+		if ctx.Err() != nil {
+			break
+		}
+		result, err := serveCommands[step.command](req)
+		var data []byte
+		if err != nil {
+			data, _ = json.Marshal(serveError{err.Error()})
+		} else {
+			data, _ = json.Marshal(result)
+		}
+		steps = append(steps, classroom.Step{Name: step.name, Input: req.Input, Data: data})
+		if err != nil {
+			break
+		}
+		switch r := result.(type) {
+		case EncodeResult:
+			req.Input = r.DataCodewords
+		case ECCResult:
+			req.Input = r.Codeword
+		}
+	}
+	return steps
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/rs"
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// --- 端末での段階ごとの説明 (tui) ---
+
+// ブラウザのない教室でも, 圧縮 → ビット列 → RS 符号の割り算 → マスクを1画面ずつ進めて見せる.
+// 各画面では前の画面から変わった部分を反転表示する. 端末を1文字ずつ読めるようにするのに stty を使い,
+// stty のない環境ではキーを押した後に Enter で確定する.
+
+// 画面の操作のキー
+const tuiHelp = "→ n Enter: 次へ   ← p: 前へ   0〜7: マスクを変える   q: 終わる"
+
+// tuiScreen は1つの画面
+type tuiScreen struct {
+	title string
+	lines []string
+}
+
+func runTUI(args []string) error {
+	fs := flag.NewFlagSet("tui", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "使い方: rscoder tui [オプション] 漢字")
+		fmt.Fprintln(fs.Output(), tuiHelp)
+		fs.PrintDefaults()
+	}
+	pattern := maskFlag(fs)
+	fs.Parse(args)
+	if err := checkMask(*pattern); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("キーの入力に標準入力を使うので, 漢字は引数で指定してください.")
+	}
+	text := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	screens, err := tuiScreens(text, *pattern)
+	if err != nil {
+		return err
+	}
+
+	restore := enableCbreak()
+	defer restore()
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt)
+	defer signal.Stop(interrupted)
+	go func() {
+		if _, ok := <-interrupted; ok {
+			restore()
+			os.Exit(130)
+		}
+	}()
+
+	keys := bufio.NewReader(os.Stdin)
+	for page := 0; ; {
+		drawScreen(os.Stdout, text, screens, page)
+		key, err := readKey(keys)
+		if err != nil {
+			return nil
+		}
+		switch {
+		case key == "next" && page < len(screens)-1:
+			page++
+		case key == "prev" && page > 0:
+			page--
+		case key == "quit":
+			return nil
+		case len(key) == 1 && key[0] >= '0' && key[0] <= '7':
+			// 同じ画面のまま, 別のマスクで作り直す
+			if screens, err = tuiScreens(text, int(key[0]-'0')); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// drawScreen は画面を消して page 番目の画面を書く
+func drawScreen(w io.Writer, text string, screens []tuiScreen, page int) {
+	s := screens[page]
+	fmt.Fprint(w, "\x1b[H\x1b[2J")
+	fmt.Fprintf(w, "rscoder tui  %s  (%d/%d)\n\n", text, page+1, len(screens))
+	fmt.Fprintf(w, "%s\n\n", s.title)
+	for _, line := range s.lines {
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "\n%s\n", tuiHelp)
+}
+
+// readKey は1つのキーを読んで next, prev, quit か, そのほかの1文字を返す. 矢印キーはエスケープシーケンスで届く.
+func readKey(r *bufio.Reader) (string, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch b {
+		case '\n', '\r', ' ', 'n', 'l', 'j':
+			return "next", nil
+		case 'p', 'h', 'k', 0x7F:
+			return "prev", nil
+		case 'q', 0x04:
+			return "quit", nil
+		case 0x1B:
+			if next, _ := r.ReadByte(); next != '[' {
+				continue
+			}
+			switch arrow, _ := r.ReadByte(); arrow {
+			case 'C', 'B':
+				return "next", nil
+			case 'D', 'A':
+				return "prev", nil
+			}
+		default:
+			return string(b), nil
+		}
+	}
+}
+
+// enableCbreak は端末を1文字ずつ, 表示せずに読めるようにし (stty), 元に戻す関数を返す.
+// stty がない, または標準入力が端末でない場合は何もしない.
+func enableCbreak() func() {
+	state, err := stty("-g")
+	if err != nil {
+		return func() {}
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return func() {}
+	}
+	return func() { stty(strings.TrimSpace(state)) }
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// highlight は s を反転表示にする
+func highlight(s string) string {
+	return "\x1b[7m" + s + "\x1b[0m"
+}
+
+// hexHighlighted はバイト列を16進数で並べ, changed が true の位置を反転表示にする
+func hexHighlighted(values []int, changed func(i int) bool) string {
+	words := make([]string, len(values))
+	for i, v := range values {
+		words[i] = fmt.Sprintf("%02X", v)
+		if changed(i) {
+			words[i] = highlight(words[i])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// tuiScreens は text をマスク pattern で符号化し, 各段階の画面を作る
+func tuiScreens(text string, pattern int) ([]tuiScreen, error) {
+	if err := checkText(text); err != nil {
+		return nil, err
+	}
+	r, err := qr.Encode(text, qr.WithMask(pattern))
+	if err != nil {
+		return nil, err
+	}
+	var screens []tuiScreen
+
+	// STEP 1: 1文字ずつ圧縮する
+	chars := charResults(r.Chars)
+	for i := range chars {
+		var b strings.Builder
+		w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "文字\tShift JIS\t引き算\t13ビット (16進)\t13ビット (2進)")
+		for j, c := range chars[:i+1] {
+			binary := c.Binary
+			if j == i {
+				binary = highlight(binary)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.Char, c.ShiftJIS, c.Subtracted, c.Value, binary)
+		}
+		w.Flush()
+		screens = append(screens, tuiScreen{
+			title: fmt.Sprintf("STEP 1: 漢字の圧縮 (%d文字目)", i+1),
+			lines: strings.Split(strings.TrimRight(b.String(), "\n"), "\n"),
+		})
+	}
+
+	// STEP 2: ビット列に1つずつ付け足す
+	s := r.Stream
+	header := s.ModeIndicator + s.CharCountIndicator
+	parts := []struct{ name, before, added string }{
+		{"モード指示子 (漢字モード)", "", s.ModeIndicator},
+		{"文字数指示子", s.ModeIndicator, s.CharCountIndicator},
+		{"各文字の13ビット", header, s.Body},
+		{"終端パターン", header + s.Body, s.Terminated[len(header+s.Body):]},
+		{"バイト境界までの0", s.Terminated, s.Padded[len(s.Terminated):]},
+	}
+	for _, p := range parts {
+		screens = append(screens, tuiScreen{
+			title: "STEP 2: ビット列の組み立て — " + p.name,
+			lines: []string{groupBits(p.before) + " " + highlight(p.added), "", fmt.Sprintf("%dビット", len(p.before)+len(p.added))},
+		})
+	}
+	data := bytesToInts(r.DataCodewords)
+	padStart := len(s.Padded) / 8
+	screens = append(screens, tuiScreen{
+		title: "STEP 2: ビット列の組み立て — 埋め草コード語 (EC 11 の繰り返し)",
+		lines: []string{"データコード語  " + hexHighlighted(data, func(i int) bool { return i >= padStart })},
+	})
+
+	// STEP 3: x^7 を掛けたデータを生成多項式で割る. 1段ごとに先頭の係数を消す.
+	generator := rs.QR.Generator(qr.ECCCodewords)
+	field := rs.QR.Field()
+	register := append(append([]int(nil), data...), make([]int, qr.ECCCodewords)...)
+	for i := range data {
+		coefficient := register[i]
+		lines := []string{"割る前  " + hexHighlighted(register, func(j int) bool { return j == i })}
+		if coefficient != 0 {
+			for j, g := range generator {
+				register[i+j] ^= field.Mul(coefficient, g)
+			}
+			lines = append(lines, fmt.Sprintf("先頭の係数 %02X (α^%d) × 生成多項式を引く", coefficient, field.Log(coefficient)))
+		} else {
+			lines = append(lines, "先頭の係数が 00 なので引かない")
+		}
+		lines = append(lines, "割った後 "+hexHighlighted(register, func(j int) bool { return coefficient != 0 && j >= i && j <= i+qr.ECCCodewords }))
+		screens = append(screens, tuiScreen{title: fmt.Sprintf("STEP 3: RS 符号の割り算 (%d/%d段目)", i+1, len(data)), lines: lines})
+	}
+	codeword := bytesToInts(r.Codeword)
+	screens = append(screens, tuiScreen{
+		title: "STEP 3: 余りが誤り訂正コード語になる",
+		lines: []string{
+			"誤り訂正コード語 " + formatHex(r.ECCCodewords),
+			"符号語          " + hexHighlighted(codeword, func(i int) bool { return i >= qr.DataCodewords }),
+		},
+	})
+
+	// STEP 4: マスクを掛ける
+	masked := bytesToInts(r.MaskedCodeword)
+	screens = append(screens, tuiScreen{
+		title: fmt.Sprintf("STEP 4: マスク (パターン %d: %s)", pattern, qr.MaskConditionTexts[pattern]),
+		lines: []string{
+			"符号語          " + formatHex(r.Codeword),
+			"マスク          " + formatHex(r.MaskBytes),
+			"マスク後の符号語 " + hexHighlighted(masked, func(i int) bool { return masked[i] != codeword[i] }),
+			"",
+			"形式情報        " + fmt.Sprintf("%015b", r.FormatInformation),
+		},
+	})
+	return screens, nil
+}
+
+func bytesToInts(b []byte) []int {
+	values := make([]int, len(b))
+	for i, v := range b {
+		values[i] = int(v)
+	}
+	return values
+}
@@ -0,0 +1,14 @@
+// Package docs は学習用のページ (index.html, main.js と WebAssembly のモジュールなど) と, 授業のライブ表示 (live.html) を埋め込む.
+// GitHub Pages ではこのディレクトリをそのまま公開し, 手元では rscoder serve (internal/classroom) が Files を配る.
+// main.wasm と wasm_exec.js は `go generate ./docs` で wasm のソースから作り直す (gen_wasm.go).
+// wasm を変えたら, rscoder を作る前に実行すること.
+package docs
+
+import "embed"
+
+//go:generate go run gen_wasm.go
+
+// Files は学習用のページのファイル
+//
+//go:embed index.html live.html main.js main.wasm wasm_exec.js qrcode.js qrcode.d.ts qrcode.pdf
+var Files embed.FS
@@ -0,0 +1,47 @@
+//go:build ignore
+
+// gen_wasm.go は wasm のソースから main.wasm を作り直し, それを動かす wasm_exec.js を同じ Go のものに置き換える.
+// embed.go の go:generate から docs ディレクトリで `go run gen_wasm.go` として実行する.
+// 埋め込む main.wasm が古いと rscoder serve が配るページが wasm のソースと食い違うので, rscoder を作る前に実行する.
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	// 埋め込むファイルが git のコミットによって変わらないよう, VCS の情報は入れない
+	build := exec.Command("go", "build", "-buildvcs=false", "-o", "main.wasm", "../wasm")
+	build.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+	if err := build.Run(); err != nil {
+		fail(err)
+	}
+
+	out, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		fail(err)
+	}
+	goroot := strings.TrimSpace(string(out))
+	// Go 1.24 から wasm_exec.js は misc/wasm ではなく lib/wasm にある
+	for _, dir := range []string{"lib/wasm", "misc/wasm"} {
+		src, err := os.ReadFile(filepath.Join(goroot, dir, "wasm_exec.js"))
+		if err != nil {
+			continue
+		}
+		if err := os.WriteFile("wasm_exec.js", src, 0o644); err != nil {
+			fail(err)
+		}
+		return
+	}
+	fail(fmt.Errorf("%s に wasm_exec.js が見つかりません", goroot))
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "gen_wasm:", err)
+	os.Exit(1)
+}
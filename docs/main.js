@@ -47,11 +47,11 @@ document.getElementById('maskForm').addEventListener('submit', (e) => {
     renderStep4(JSON.parse(resultJson));
 });
 
-// エラー表示を管理する関数
-function displayError(message) {
+// エラー表示を管理する関数 (error は { Code, Message, Value, Position } または null)
+function displayError(error) {
     const errorContainer = document.getElementById('error-container');
-    errorContainer.textContent = message;
-    errorContainer.style.display = message ? 'block' : 'none';
+    errorContainer.textContent = error ? error.Message : '';
+    errorContainer.style.display = error ? 'block' : 'none';
 }
 
 // STEP1とSTEP2の結果を描画
@@ -0,0 +1,661 @@
+// Code generated by gen_dts.go; DO NOT EDIT.
+
+export interface AnswerCheckData {
+  Text: string;
+  Success: boolean;
+  Error: string;
+}
+
+export interface BerlekampMasseyStep {
+  Step: number;
+  Discrepancy: number;
+  Locator: number[] | null;
+  LocatorLatex: string;
+  L: number;
+}
+
+export interface BitPositionData {
+  CodewordIndex: number;
+  BitIndex: number;
+  Row: number;
+  Column: number;
+}
+
+export interface BurstAnalysis {
+  StreamHex: string;
+  Blocks: BurstBlock[] | null;
+  AllCorrectable: boolean;
+}
+
+export interface BurstBlock {
+  Index: number;
+  ReceivedHex: string;
+  ErrorPositions: number[] | null;
+  Correctable: boolean;
+  CorrectedHex: string;
+  Message: string;
+}
+
+export interface BurstData {
+  Start: number;
+  Length: number;
+  Depth: number;
+  Sequential: BurstAnalysis;
+  Interleaved: BurstAnalysis;
+}
+
+export interface ByteDiff {
+  Position: number;
+  Received: string;
+  Corrected: string;
+  Magnitude: number;
+  XOR: string;
+  Changed: boolean;
+}
+
+export interface CallStatsData {
+  Name: string;
+  Count: number;
+  LastMs: number;
+  TotalMs: number;
+}
+
+export interface CapabilitiesData {
+  Version: string;
+  SchemaVersions: number[] | null;
+  Modes: string[] | null;
+  Versions: number[] | null;
+  ECCLevels: string[] | null;
+  MaskPatterns: number[] | null;
+  OutputFormats: string[] | null;
+  ParsingModes: string[] | null;
+  Locales: string[] | null;
+  Verbosities: string[] | null;
+  RenderFormats: string[] | null;
+  CoefficientFormats: string[] | null;
+  RSModes: string[] | null;
+  MaxCharCount: number;
+  Functions: string[] | null;
+}
+
+export interface ChienSearchStep {
+  Degree: number;
+  Position: number;
+  Element: string;
+  Value: number;
+  IsRoot: boolean;
+}
+
+export interface ChunkData {
+  Chunks: number;
+  Length: number;
+  Finished: boolean;
+  Preview: string;
+  Capacity: number;
+  Fits: boolean;
+}
+
+export interface CodeCapacity {
+  N: number;
+  K: number;
+  T: number;
+  MinimumDistance: number;
+  ErasureCapacity: number;
+  Description: string;
+}
+
+export interface CodeMatrixData {
+  N: number;
+  K: number;
+  GeneratorMatrix: (number[] | null)[] | null;
+  ParityCheckMatrix: (number[] | null)[] | null;
+  GeneratorMatrixLatex: string;
+  ParityCheckMatrixLatex: string;
+  Orthogonal: boolean;
+  Received: number[] | null;
+  Syndromes: number[] | null;
+  SyndromeRows: string[] | null;
+  SyndromeLatex: string;
+}
+
+export interface ConjugacyClass {
+  Exponents: number[] | null;
+  Elements: number[] | null;
+  MinimalPolynomial: number;
+  MinimalPolynomialText: string;
+  Degree: number;
+}
+
+export interface ConstantsData {
+  ModeIndicators: Record<string, string> | null;
+  CharCountBits: Record<string, number> | null;
+  Terminator: string;
+  PadCodewords: string[] | null;
+  PrimitivePolynomial: number;
+  PrimitivePolynomialHex: string;
+  FieldPolynomial: number;
+  SymbolSize: number;
+  DataCodewords: number;
+  ECCodewords: number;
+  TotalCodewords: number;
+  DataBits: number;
+  MaxKanji: number;
+  MaxBytes: number;
+  CorrectableErrors: number;
+  ECCLevelBits: string;
+  FormatInformationMask: string;
+}
+
+export interface DecodingBudget {
+  Errors: number;
+  Erasures: number;
+  Used: number;
+  Capacity: number;
+}
+
+export interface DecodingData {
+  Syndromes: SyndromeData[] | null;
+  SyndromesZero: boolean;
+  SyndromeTableLatex: string;
+  Method: string;
+  BerlekampMassey: BerlekampMasseyStep[] | null;
+  Euclid: EuclideanStep[] | null;
+  ErrorLocator: number[] | null;
+  ErrorLocatorLatex: string;
+  ErasurePositions: number[] | null;
+  ErasureLocator: number[] | null;
+  ErasureLocatorLatex: string;
+  ChienSearch: ChienSearchStep[] | null;
+  ErrorEvaluator: number[] | null;
+  ErrorEvaluatorLatex: string;
+  LocatorDerivative: number[] | null;
+  LocatorDerivativeLatex: string;
+  Forney: ForneyStep[] | null;
+  ErrorPositions: number[] | null;
+  ErrorValues: number[] | null;
+  ReceivedHex: string;
+  ReceivedBinary: string;
+  ErrorVector: number[] | null;
+  CorrectedHex: string;
+  CorrectedBinary: string;
+  Diff: ByteDiff[] | null;
+  XORBinary: string;
+  Budget: DecodingBudget;
+  Verification: VerificationData;
+  Capacity: CodeCapacity;
+  Repair: RepairData;
+  Success: boolean;
+  Failure: DecodingFailure | null;
+}
+
+export interface DecodingFailure {
+  Reason: string;
+  Message: string;
+  LocatorDegree: number;
+  RootCount: number;
+  RootsOutsideCodeword: number;
+  ResidualSyndromes: number[] | null;
+}
+
+export interface DiscrepancyData {
+  ByteIndex: number;
+  BitIndex: number;
+  Position: number;
+  Expected: string;
+  Actual: string;
+}
+
+export interface DivisionRow {
+  Step: number;
+  QuotientDegree: number;
+  Factor: number;
+  FactorAlpha: string;
+  Subtrahend: number[] | null;
+  Result: number[] | null;
+  SubtrahendTex: string;
+  ResultTex: string;
+}
+
+export interface DivisionStep {
+  Step: number;
+  Dividend: number[] | null;
+  Divisor: number[] | null;
+  Quotient: number[] | null;
+  Remainder: number[] | null;
+  DividendTex: string;
+  DivisorTex: string;
+  QuotientTex: string;
+  RemainderTex: string;
+}
+
+export interface ErrorInfo {
+  Code: string;
+  Message: string;
+  Value: string;
+  Position: number;
+}
+
+export interface EuclideanStep {
+  Step: number;
+  Quotient: number[] | null;
+  Remainder: number[] | null;
+  Locator: number[] | null;
+}
+
+export interface FieldData {
+  M: number;
+  Operation: string;
+  Operands: number[] | null;
+  Result: number;
+  ResultHex: string;
+  ResultAlpha: string;
+  Expression: string;
+  Polynomial: number;
+  PolynomialText: string;
+  ExpTable: number[] | null;
+  LogTable: number[] | null;
+  ExpGrid: (string[] | null)[] | null;
+  LogGrid: (string[] | null)[] | null;
+  ConjugacyClasses: ConjugacyClass[] | null;
+  ElementOrder: number;
+  IsPrimitive: boolean;
+  PrimitiveCount: number;
+  TargetPolynomial: number;
+  TargetPolynomialText: string;
+  IsomorphismRoot: number;
+  IsomorphismRootAlpha: string;
+  Mapping: number[] | null;
+}
+
+export interface ForneyStep {
+  Position: number;
+  Locator: string;
+  OmegaValue: number;
+  DerivativeValue: number;
+  Value: number;
+}
+
+export interface GeneratorEntry {
+  Degree: number;
+  Coefficients: number[] | null;
+  Exponents: number[] | null;
+  Latex: string;
+}
+
+export interface GeneratorStep {
+  Index: number;
+  Factor: string;
+  Coefficients: number[] | null;
+  Latex: string;
+}
+
+export interface HornerStep {
+  Degree: number;
+  Coefficient: number;
+  Product: number;
+  Accumulator: number;
+}
+
+export interface KanjiCompressionResult {
+  Kanji: string;
+  ShiftJISCode: string;
+  SubtractedCode: string;
+  CompressedHex: string;
+  Binary13Bit: string;
+}
+
+export interface MaskByteDerivation {
+  CodewordIndex: number;
+  Positions: (number[] | null)[] | null;
+  Binary: string;
+  Hex: string;
+}
+
+export interface MaskComparisonData {
+  PatternNumber: number;
+  Condition: string;
+  MaskedMatrix: (number[] | null)[] | null;
+  FormatInformation: string;
+  PenaltyN1: number;
+  PenaltyN2: number;
+  PenaltyN3: number;
+  PenaltyN4: number;
+  PenaltyTotal: number;
+  DarkModuleCount: number;
+  Rank: number;
+}
+
+export interface MaskDerivationData {
+  PatternNumber: number;
+  Condition: string;
+  MaskHex: string;
+  Bytes: MaskByteDerivation[] | null;
+}
+
+export interface PartialProduct {
+  Degree: number;
+  Coefficient: number;
+  Product: number[] | null;
+  ProductTex: string;
+}
+
+export interface PolynomialCoefficients {
+  Decimal: number[] | null;
+  Hex: string[] | null;
+  Exponents: number[] | null;
+}
+
+export interface PolynomialData {
+  Operands: (number[] | null)[] | null;
+  OperandsTex: string[] | null;
+  X: number;
+  Value: number;
+  ValueAlpha: string;
+  Horner: HornerStep[] | null;
+  Result: number[] | null;
+  ResultTex: string;
+  PartialProducts: PartialProduct[] | null;
+  GCDSteps: DivisionStep[] | null;
+  DivisionRows: DivisionRow[] | null;
+  Quotient: number[] | null;
+  QuotientTex: string;
+  GeneratorSteps: GeneratorStep[] | null;
+  GeneratorTable: GeneratorEntry[] | null;
+  CoefficientFormat: string;
+}
+
+export interface QRCodeIntermediateData {
+  ModeIndicator: string;
+  CharCountIndicator: string;
+  ConcatenatedBinary: string;
+  TerminatedBinary: string;
+  PaddedBinaryBlocks: string;
+  PaddedHex: string;
+  PaddedBinary: string;
+  DataPolynomial: string;
+  ErrorCorrectionPolynomial: string;
+  CodewordPolynomial: string;
+  DataCoefficients: PolynomialCoefficients;
+  ErrorCorrectionCoefficients: PolynomialCoefficients;
+  CodewordCoefficients: PolynomialCoefficients;
+  CodewordHex: string;
+  CodewordBinary: string;
+  MaskPatternHex: string;
+  MaskedCodewordHex: string;
+  MaskedCodewordBinary: string;
+}
+
+export interface QRMatrixData {
+  Size: number;
+  QuietZone: number;
+  FunctionPatternMatrix: (number[] | null)[] | null;
+  DataPlacedMatrix: (number[] | null)[] | null;
+  MaskedMatrix: (number[] | null)[] | null;
+  FormatInformation: string;
+  RegionMatrix: (number[] | null)[] | null;
+  RegionNames: string[] | null;
+  CodewordMatrix: (number[] | null)[] | null;
+  SourceMatrix: (number[] | null)[] | null;
+  MaskPatternMatrix: (number[] | null)[] | null;
+}
+
+export interface QuizData {
+  Seed: number;
+  Problems: QuizProblem[] | null;
+}
+
+export interface QuizProblem {
+  Number: number;
+  Operation: string;
+  Operands: number[] | null;
+  Question: string;
+  Answer: number;
+  AnswerHex: string;
+  AnswerAlpha: string;
+  Solution: string[] | null;
+}
+
+export interface RSCodeData {
+  Mode: string;
+  FirstRoot: number;
+  N: number;
+  K: number;
+  Parity: number;
+  ParityHex: string;
+  Generator: string;
+}
+
+export interface RenderData {
+  Format: string;
+  Content: string;
+  Width: number;
+  Height: number;
+  DataURI: string;
+}
+
+export interface RepairData {
+  Corrected: boolean;
+  ChangedPositions: number[] | null;
+  ValidKanjiData: boolean;
+  Text: string;
+  Message: string;
+}
+
+export interface SessionData {
+  ID: number;
+  CompletedStep: number;
+  NextStep: number;
+}
+
+export interface StatsData {
+  Mallocs: number;
+  Frees: number;
+  HeapAlloc: number;
+  TotalAlloc: number;
+  NumGC: number;
+  PauseTotalMs: number;
+  RecentPausesMs: number[] | null;
+  Calls: CallStatsData[] | null;
+  LastPipeline: StepTiming[] | null;
+}
+
+export interface StepTiming {
+  Step: string;
+  Ms: number;
+}
+
+export interface SyndromeData {
+  Index: number;
+  Value: number;
+  Alpha: string;
+  Latex: string;
+}
+
+export interface TemplateData {
+  SchemaVersion: number;
+  KanjiInput: string;
+  Results: KanjiCompressionResult[] | null;
+  Intermediate: QRCodeIntermediateData;
+  Matrix: QRMatrixData;
+  BitPosition: BitPositionData;
+  Mask: MaskDerivationData;
+  MaskComparisons: MaskComparisonData[] | null;
+  Render: RenderData;
+  Worksheet: WorksheetData;
+  Decoding: DecodingData;
+  Burst: BurstData;
+  Field: FieldData;
+  Polynomial: PolynomialData;
+  RS: RSCodeData;
+  Quiz: QuizData;
+  CodeMatrix: CodeMatrixData;
+  Session: SessionData;
+  Capabilities: CapabilitiesData;
+  Stats: StatsData;
+  Validation: ValidationData;
+  Chunk: ChunkData;
+  Constants: ConstantsData;
+  Error: ErrorInfo | null;
+  MaxCharCount: number;
+}
+
+export interface ValidationData {
+  Stage: string;
+  Valid: boolean;
+  ExpectedHex: string;
+  ActualHex: string;
+  Discrepancy: DiscrepancyData | null;
+  Message: string;
+}
+
+export interface VerificationData {
+  Valid: boolean;
+  ExpectedECCHex: string;
+  ReceivedECCHex: string;
+  MismatchedECC: number[] | null;
+  RemainderIsZero: boolean;
+}
+
+export interface WorksheetData {
+  Worksheet: string;
+  AnswerKey: string;
+}
+
+export declare function generateDataCodewords(...args: unknown[]): TemplateData;
+export declare function generateDataCodewordsAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function applyEcc(...args: unknown[]): TemplateData;
+export declare function applyEccAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function encodeAll(...args: unknown[]): TemplateData;
+export declare function encodeAllAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function applyMask(...args: unknown[]): TemplateData;
+export declare function applyMaskAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getMatrix(...args: unknown[]): TemplateData;
+export declare function getMatrixAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function bitToModule(...args: unknown[]): TemplateData;
+export declare function bitToModuleAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getMaskPattern(...args: unknown[]): TemplateData;
+export declare function getMaskPatternAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderMaskPattern(...args: unknown[]): TemplateData;
+export declare function renderMaskPatternAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function extractCodewords(...args: unknown[]): TemplateData;
+export declare function extractCodewordsAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function compareMasks(...args: unknown[]): TemplateData;
+export declare function compareMasksAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderSVG(...args: unknown[]): TemplateData;
+export declare function renderSVGAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderAnimatedSVG(...args: unknown[]): TemplateData;
+export declare function renderAnimatedSVGAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderPNG(...args: unknown[]): TemplateData;
+export declare function renderPNGAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderPBM(...args: unknown[]): TemplateData;
+export declare function renderPBMAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderXBM(...args: unknown[]): TemplateData;
+export declare function renderXBMAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderText(...args: unknown[]): TemplateData;
+export declare function renderTextAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderHTML(...args: unknown[]): TemplateData;
+export declare function renderHTMLAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderSourceMap(...args: unknown[]): TemplateData;
+export declare function renderSourceMapAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function renderPDF(...args: unknown[]): TemplateData;
+export declare function renderPDFAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function generateWorksheet(...args: unknown[]): TemplateData;
+export declare function generateWorksheetAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function computeSyndromes(...args: unknown[]): TemplateData;
+export declare function computeSyndromesAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function correctCodeword(...args: unknown[]): TemplateData;
+export declare function correctCodewordAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function correctErasures(...args: unknown[]): TemplateData;
+export declare function correctErasuresAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function injectErrors(...args: unknown[]): TemplateData;
+export declare function injectErrorsAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function simulateBurst(...args: unknown[]): TemplateData;
+export declare function simulateBurstAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function decodeAll(...args: unknown[]): TemplateData;
+export declare function decodeAllAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function verifyCodeword(...args: unknown[]): TemplateData;
+export declare function verifyCodewordAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function checkAnswer(...args: unknown[]): AnswerCheckData;
+export declare function checkAnswerAsync(...args: unknown[]): Promise<AnswerCheckData>;
+export declare function validateDataCodewords(...args: unknown[]): TemplateData;
+export declare function validateDataCodewordsAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function validateCodeword(...args: unknown[]): TemplateData;
+export declare function validateCodewordAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function validateMaskedCodeword(...args: unknown[]): TemplateData;
+export declare function validateMaskedCodewordAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getCorrectionCapacity(...args: unknown[]): TemplateData;
+export declare function getCorrectionCapacityAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function repairCodeword(...args: unknown[]): TemplateData;
+export declare function repairCodewordAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function gfAdd(...args: unknown[]): TemplateData;
+export declare function gfAddAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function gfMul(...args: unknown[]): TemplateData;
+export declare function gfMulAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function gfDiv(...args: unknown[]): TemplateData;
+export declare function gfDivAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function gfInv(...args: unknown[]): TemplateData;
+export declare function gfInvAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function gfPow(...args: unknown[]): TemplateData;
+export declare function gfPowAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getGFTables(...args: unknown[]): TemplateData;
+export declare function getGFTablesAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function setPrimitivePolynomial(...args: unknown[]): TemplateData;
+export declare function setPrimitivePolynomialAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getFieldTables(...args: unknown[]): TemplateData;
+export declare function getFieldTablesAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function fieldOperation(...args: unknown[]): TemplateData;
+export declare function fieldOperationAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getConjugacyClasses(...args: unknown[]): TemplateData;
+export declare function getConjugacyClassesAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getElementOrder(...args: unknown[]): TemplateData;
+export declare function getElementOrderAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function mapFieldElement(...args: unknown[]): TemplateData;
+export declare function mapFieldElementAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function polyEval(...args: unknown[]): TemplateData;
+export declare function polyEvalAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function polyMul(...args: unknown[]): TemplateData;
+export declare function polyMulAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function polyGCD(...args: unknown[]): TemplateData;
+export declare function polyGCDAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function polyDiv(...args: unknown[]): TemplateData;
+export declare function polyDivAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getGeneratorPolynomial(...args: unknown[]): TemplateData;
+export declare function getGeneratorPolynomialAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getGeneratorTable(...args: unknown[]): TemplateData;
+export declare function getGeneratorTableAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function setCoefficientFormat(...args: unknown[]): TemplateData;
+export declare function setCoefficientFormatAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function rsEncode(...args: unknown[]): TemplateData;
+export declare function rsEncodeAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function generateGFQuiz(...args: unknown[]): TemplateData;
+export declare function generateGFQuizAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getCodeMatrices(...args: unknown[]): TemplateData;
+export declare function getCodeMatricesAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function setResponseFormat(...args: unknown[]): TemplateData;
+export declare function setResponseFormatAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function setLocale(...args: unknown[]): TemplateData;
+export declare function setLocaleAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function setSchemaVersion(...args: unknown[]): TemplateData;
+export declare function setSchemaVersionAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function appendChunk(...args: unknown[]): TemplateData;
+export declare function appendChunkAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function finishChunks(...args: unknown[]): TemplateData;
+export declare function finishChunksAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function createSession(...args: unknown[]): TemplateData;
+export declare function createSessionAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function runStep(...args: unknown[]): TemplateData;
+export declare function runStepAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function closeSession(...args: unknown[]): TemplateData;
+export declare function closeSessionAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getTypeDefinitions(...args: unknown[]): string;
+export declare function getTypeDefinitionsAsync(...args: unknown[]): Promise<string>;
+export declare function getCapabilities(...args: unknown[]): TemplateData;
+export declare function getCapabilitiesAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getConstants(...args: unknown[]): TemplateData;
+export declare function getConstantsAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function getStats(...args: unknown[]): TemplateData;
+export declare function getStatsAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function shutdown(...args: unknown[]): TemplateData;
+export declare function shutdownAsync(...args: unknown[]): Promise<TemplateData>;
+export declare function moduleToBit(...args: unknown[]): TemplateData;
+export declare function moduleToBitAsync(...args: unknown[]): Promise<TemplateData>;
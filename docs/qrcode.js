@@ -0,0 +1,143 @@
+// Code generated by gen_dts.go; DO NOT EDIT.
+// Wasm が登録したグローバル関数を呼び, JSON 文字列の応答をオブジェクトにして返す.
+
+const parse = (response) => (typeof response === 'string' ? JSON.parse(response) : response);
+
+export const generateDataCodewords = (...args) => parse(globalThis.generateDataCodewords(...args));
+export const generateDataCodewordsAsync = (...args) => globalThis.generateDataCodewordsAsync(...args).then(parse);
+export const applyEcc = (...args) => parse(globalThis.applyEcc(...args));
+export const applyEccAsync = (...args) => globalThis.applyEccAsync(...args).then(parse);
+export const encodeAll = (...args) => parse(globalThis.encodeAll(...args));
+export const encodeAllAsync = (...args) => globalThis.encodeAllAsync(...args).then(parse);
+export const applyMask = (...args) => parse(globalThis.applyMask(...args));
+export const applyMaskAsync = (...args) => globalThis.applyMaskAsync(...args).then(parse);
+export const getMatrix = (...args) => parse(globalThis.getMatrix(...args));
+export const getMatrixAsync = (...args) => globalThis.getMatrixAsync(...args).then(parse);
+export const bitToModule = (...args) => parse(globalThis.bitToModule(...args));
+export const bitToModuleAsync = (...args) => globalThis.bitToModuleAsync(...args).then(parse);
+export const getMaskPattern = (...args) => parse(globalThis.getMaskPattern(...args));
+export const getMaskPatternAsync = (...args) => globalThis.getMaskPatternAsync(...args).then(parse);
+export const renderMaskPattern = (...args) => parse(globalThis.renderMaskPattern(...args));
+export const renderMaskPatternAsync = (...args) => globalThis.renderMaskPatternAsync(...args).then(parse);
+export const extractCodewords = (...args) => parse(globalThis.extractCodewords(...args));
+export const extractCodewordsAsync = (...args) => globalThis.extractCodewordsAsync(...args).then(parse);
+export const compareMasks = (...args) => parse(globalThis.compareMasks(...args));
+export const compareMasksAsync = (...args) => globalThis.compareMasksAsync(...args).then(parse);
+export const renderSVG = (...args) => parse(globalThis.renderSVG(...args));
+export const renderSVGAsync = (...args) => globalThis.renderSVGAsync(...args).then(parse);
+export const renderAnimatedSVG = (...args) => parse(globalThis.renderAnimatedSVG(...args));
+export const renderAnimatedSVGAsync = (...args) => globalThis.renderAnimatedSVGAsync(...args).then(parse);
+export const renderPNG = (...args) => parse(globalThis.renderPNG(...args));
+export const renderPNGAsync = (...args) => globalThis.renderPNGAsync(...args).then(parse);
+export const renderPBM = (...args) => parse(globalThis.renderPBM(...args));
+export const renderPBMAsync = (...args) => globalThis.renderPBMAsync(...args).then(parse);
+export const renderXBM = (...args) => parse(globalThis.renderXBM(...args));
+export const renderXBMAsync = (...args) => globalThis.renderXBMAsync(...args).then(parse);
+export const renderText = (...args) => parse(globalThis.renderText(...args));
+export const renderTextAsync = (...args) => globalThis.renderTextAsync(...args).then(parse);
+export const renderHTML = (...args) => parse(globalThis.renderHTML(...args));
+export const renderHTMLAsync = (...args) => globalThis.renderHTMLAsync(...args).then(parse);
+export const renderSourceMap = (...args) => parse(globalThis.renderSourceMap(...args));
+export const renderSourceMapAsync = (...args) => globalThis.renderSourceMapAsync(...args).then(parse);
+export const renderPDF = (...args) => parse(globalThis.renderPDF(...args));
+export const renderPDFAsync = (...args) => globalThis.renderPDFAsync(...args).then(parse);
+export const generateWorksheet = (...args) => parse(globalThis.generateWorksheet(...args));
+export const generateWorksheetAsync = (...args) => globalThis.generateWorksheetAsync(...args).then(parse);
+export const computeSyndromes = (...args) => parse(globalThis.computeSyndromes(...args));
+export const computeSyndromesAsync = (...args) => globalThis.computeSyndromesAsync(...args).then(parse);
+export const correctCodeword = (...args) => parse(globalThis.correctCodeword(...args));
+export const correctCodewordAsync = (...args) => globalThis.correctCodewordAsync(...args).then(parse);
+export const correctErasures = (...args) => parse(globalThis.correctErasures(...args));
+export const correctErasuresAsync = (...args) => globalThis.correctErasuresAsync(...args).then(parse);
+export const injectErrors = (...args) => parse(globalThis.injectErrors(...args));
+export const injectErrorsAsync = (...args) => globalThis.injectErrorsAsync(...args).then(parse);
+export const simulateBurst = (...args) => parse(globalThis.simulateBurst(...args));
+export const simulateBurstAsync = (...args) => globalThis.simulateBurstAsync(...args).then(parse);
+export const decodeAll = (...args) => parse(globalThis.decodeAll(...args));
+export const decodeAllAsync = (...args) => globalThis.decodeAllAsync(...args).then(parse);
+export const verifyCodeword = (...args) => parse(globalThis.verifyCodeword(...args));
+export const verifyCodewordAsync = (...args) => globalThis.verifyCodewordAsync(...args).then(parse);
+export const checkAnswer = (...args) => parse(globalThis.checkAnswer(...args));
+export const checkAnswerAsync = (...args) => globalThis.checkAnswerAsync(...args).then(parse);
+export const validateDataCodewords = (...args) => parse(globalThis.validateDataCodewords(...args));
+export const validateDataCodewordsAsync = (...args) => globalThis.validateDataCodewordsAsync(...args).then(parse);
+export const validateCodeword = (...args) => parse(globalThis.validateCodeword(...args));
+export const validateCodewordAsync = (...args) => globalThis.validateCodewordAsync(...args).then(parse);
+export const validateMaskedCodeword = (...args) => parse(globalThis.validateMaskedCodeword(...args));
+export const validateMaskedCodewordAsync = (...args) => globalThis.validateMaskedCodewordAsync(...args).then(parse);
+export const getCorrectionCapacity = (...args) => parse(globalThis.getCorrectionCapacity(...args));
+export const getCorrectionCapacityAsync = (...args) => globalThis.getCorrectionCapacityAsync(...args).then(parse);
+export const repairCodeword = (...args) => parse(globalThis.repairCodeword(...args));
+export const repairCodewordAsync = (...args) => globalThis.repairCodewordAsync(...args).then(parse);
+export const gfAdd = (...args) => parse(globalThis.gfAdd(...args));
+export const gfAddAsync = (...args) => globalThis.gfAddAsync(...args).then(parse);
+export const gfMul = (...args) => parse(globalThis.gfMul(...args));
+export const gfMulAsync = (...args) => globalThis.gfMulAsync(...args).then(parse);
+export const gfDiv = (...args) => parse(globalThis.gfDiv(...args));
+export const gfDivAsync = (...args) => globalThis.gfDivAsync(...args).then(parse);
+export const gfInv = (...args) => parse(globalThis.gfInv(...args));
+export const gfInvAsync = (...args) => globalThis.gfInvAsync(...args).then(parse);
+export const gfPow = (...args) => parse(globalThis.gfPow(...args));
+export const gfPowAsync = (...args) => globalThis.gfPowAsync(...args).then(parse);
+export const getGFTables = (...args) => parse(globalThis.getGFTables(...args));
+export const getGFTablesAsync = (...args) => globalThis.getGFTablesAsync(...args).then(parse);
+export const setPrimitivePolynomial = (...args) => parse(globalThis.setPrimitivePolynomial(...args));
+export const setPrimitivePolynomialAsync = (...args) => globalThis.setPrimitivePolynomialAsync(...args).then(parse);
+export const getFieldTables = (...args) => parse(globalThis.getFieldTables(...args));
+export const getFieldTablesAsync = (...args) => globalThis.getFieldTablesAsync(...args).then(parse);
+export const fieldOperation = (...args) => parse(globalThis.fieldOperation(...args));
+export const fieldOperationAsync = (...args) => globalThis.fieldOperationAsync(...args).then(parse);
+export const getConjugacyClasses = (...args) => parse(globalThis.getConjugacyClasses(...args));
+export const getConjugacyClassesAsync = (...args) => globalThis.getConjugacyClassesAsync(...args).then(parse);
+export const getElementOrder = (...args) => parse(globalThis.getElementOrder(...args));
+export const getElementOrderAsync = (...args) => globalThis.getElementOrderAsync(...args).then(parse);
+export const mapFieldElement = (...args) => parse(globalThis.mapFieldElement(...args));
+export const mapFieldElementAsync = (...args) => globalThis.mapFieldElementAsync(...args).then(parse);
+export const polyEval = (...args) => parse(globalThis.polyEval(...args));
+export const polyEvalAsync = (...args) => globalThis.polyEvalAsync(...args).then(parse);
+export const polyMul = (...args) => parse(globalThis.polyMul(...args));
+export const polyMulAsync = (...args) => globalThis.polyMulAsync(...args).then(parse);
+export const polyGCD = (...args) => parse(globalThis.polyGCD(...args));
+export const polyGCDAsync = (...args) => globalThis.polyGCDAsync(...args).then(parse);
+export const polyDiv = (...args) => parse(globalThis.polyDiv(...args));
+export const polyDivAsync = (...args) => globalThis.polyDivAsync(...args).then(parse);
+export const getGeneratorPolynomial = (...args) => parse(globalThis.getGeneratorPolynomial(...args));
+export const getGeneratorPolynomialAsync = (...args) => globalThis.getGeneratorPolynomialAsync(...args).then(parse);
+export const getGeneratorTable = (...args) => parse(globalThis.getGeneratorTable(...args));
+export const getGeneratorTableAsync = (...args) => globalThis.getGeneratorTableAsync(...args).then(parse);
+export const setCoefficientFormat = (...args) => parse(globalThis.setCoefficientFormat(...args));
+export const setCoefficientFormatAsync = (...args) => globalThis.setCoefficientFormatAsync(...args).then(parse);
+export const rsEncode = (...args) => parse(globalThis.rsEncode(...args));
+export const rsEncodeAsync = (...args) => globalThis.rsEncodeAsync(...args).then(parse);
+export const generateGFQuiz = (...args) => parse(globalThis.generateGFQuiz(...args));
+export const generateGFQuizAsync = (...args) => globalThis.generateGFQuizAsync(...args).then(parse);
+export const getCodeMatrices = (...args) => parse(globalThis.getCodeMatrices(...args));
+export const getCodeMatricesAsync = (...args) => globalThis.getCodeMatricesAsync(...args).then(parse);
+export const setResponseFormat = (...args) => parse(globalThis.setResponseFormat(...args));
+export const setResponseFormatAsync = (...args) => globalThis.setResponseFormatAsync(...args).then(parse);
+export const setLocale = (...args) => parse(globalThis.setLocale(...args));
+export const setLocaleAsync = (...args) => globalThis.setLocaleAsync(...args).then(parse);
+export const setSchemaVersion = (...args) => parse(globalThis.setSchemaVersion(...args));
+export const setSchemaVersionAsync = (...args) => globalThis.setSchemaVersionAsync(...args).then(parse);
+export const appendChunk = (...args) => parse(globalThis.appendChunk(...args));
+export const appendChunkAsync = (...args) => globalThis.appendChunkAsync(...args).then(parse);
+export const finishChunks = (...args) => parse(globalThis.finishChunks(...args));
+export const finishChunksAsync = (...args) => globalThis.finishChunksAsync(...args).then(parse);
+export const createSession = (...args) => parse(globalThis.createSession(...args));
+export const createSessionAsync = (...args) => globalThis.createSessionAsync(...args).then(parse);
+export const runStep = (...args) => parse(globalThis.runStep(...args));
+export const runStepAsync = (...args) => globalThis.runStepAsync(...args).then(parse);
+export const closeSession = (...args) => parse(globalThis.closeSession(...args));
+export const closeSessionAsync = (...args) => globalThis.closeSessionAsync(...args).then(parse);
+export const getTypeDefinitions = (...args) => globalThis.getTypeDefinitions(...args);
+export const getTypeDefinitionsAsync = (...args) => globalThis.getTypeDefinitionsAsync(...args);
+export const getCapabilities = (...args) => parse(globalThis.getCapabilities(...args));
+export const getCapabilitiesAsync = (...args) => globalThis.getCapabilitiesAsync(...args).then(parse);
+export const getConstants = (...args) => parse(globalThis.getConstants(...args));
+export const getConstantsAsync = (...args) => globalThis.getConstantsAsync(...args).then(parse);
+export const getStats = (...args) => parse(globalThis.getStats(...args));
+export const getStatsAsync = (...args) => globalThis.getStatsAsync(...args).then(parse);
+export const shutdown = (...args) => parse(globalThis.shutdown(...args));
+export const shutdownAsync = (...args) => globalThis.shutdownAsync(...args).then(parse);
+export const moduleToBit = (...args) => parse(globalThis.moduleToBit(...args));
+export const moduleToBitAsync = (...args) => globalThis.moduleToBitAsync(...args).then(parse);
@@ -0,0 +1,32 @@
+// Package classroom は学習用のページ (docs.Files) と API, 授業のライブ表示 (/live) をまとめて配る http.Handler を作る.
+// rscoder serve が使う. 符号化の処理は API の http.Handler と Pipeline として呼ぶ側が渡す.
+package classroom
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/mocho271828/rs_coding-compresser/docs"
+)
+
+// NewHandler は学習用のページと, /api/ の下の api (/api/encode など), /live のライブ表示をまとめて配る.
+// ページはブラウザで main.wasm を動かすので, API は他のプログラムや WASM を動かせない端末から使う.
+// /live は live.html から接続する WebSocket で, 教員の入力を pipeline で処理して配る.
+// 合言葉 instructorToken を知る端末が教員になる (空なら誰も教員になれない).
+func NewHandler(api http.Handler, pipeline Pipeline, instructorToken string) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/api/", http.StripPrefix("/api", api))
+	mux.Handle("/live", newLiveHub(instructorToken, pipeline))
+	mux.Handle("/", http.FileServer(http.FS(docs.Files)))
+	return mux
+}
+
+// NewToken は教員の合言葉にする推測できない文字列 (16バイトの乱数の16進数) を作る
+func NewToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,103 @@
+package classroom
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// NewHandler がページと API を配り, 教員の入力を Pipeline で処理して WebSocket で配ることを確かめる
+
+func TestNewHandler(t *testing.T) {
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "api "+r.URL.Path)
+	})
+	pipeline := func(ctx context.Context, payload []byte) []Step {
+		return []Step{
+			{Name: "encode", Input: string(payload), Data: json.RawMessage(`{"Text":"` + string(payload) + `"}`)},
+			{Name: "ecc", Input: "80 23", Data: json.RawMessage(`{"Error":"誤り"}`)},
+		}
+	}
+	server := httptest.NewServer(NewHandler(api, pipeline, "secret"))
+	defer server.Close()
+
+	for path, want := range map[string]string{"/api/encode": "api /encode", "/live.html": "<!DOCTYPE html>"} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.HasPrefix(string(body), want) {
+			t.Errorf("GET %s: %d %.40q", path, resp.StatusCode, body)
+		}
+	}
+
+	conn, r := dialLive(t, server.URL, "/live?role=instructor&token=secret")
+	defer conn.Close()
+	// クライアントからのフレームはマスクを掛けて送る
+	payload := []byte("漢字")
+	key := [4]byte{1, 2, 3, 4}
+	frame := append([]byte{0x80 | wsText, 0x80 | byte(len(payload))}, key[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^key[i%4])
+	}
+	if _, err := conn.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []liveMessage{
+		{Seq: 1, Step: "encode", Input: "漢字", Data: json.RawMessage(`{"Text":"漢字"}`)},
+		{Seq: 1, Step: "ecc", Input: "80 23", Data: json.RawMessage(`{"Error":"誤り"}`)},
+	} {
+		var got liveMessage
+		if err := json.Unmarshal(readServerFrame(t, r), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Seq != want.Seq || got.Step != want.Step || got.Input != want.Input || string(got.Data) != string(want.Data) {
+			t.Errorf("%+v, want %+v", got, want)
+		}
+	}
+}
+
+// dialLive は WebSocket の接続を始め, サーバーが切り替えに応じたことを確かめる
+func dialLive(t *testing.T, serverURL, target string) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(serverURL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(conn, "GET "+target+" HTTP/1.1\r\nHost: "+strings.TrimPrefix(serverURL, "http://")+
+		"\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n")
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("%s, Sec-WebSocket-Accept: %s", resp.Status, resp.Header.Get("Sec-WebSocket-Accept"))
+	}
+	return conn, r
+}
+
+// readServerFrame はサーバーからのテキストのフレーム (マスクなし, 125バイト以下) を1つ読む
+func readServerFrame(t *testing.T, r *bufio.Reader) []byte {
+	t.Helper()
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		t.Fatal(err)
+	}
+	if header[0] != 0x80|wsText || header[1] > 125 {
+		t.Fatalf("フレームの先頭 % X", header)
+	}
+	payload := make([]byte, header[1])
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
@@ -0,0 +1,165 @@
+package classroom
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// --- 授業のライブ表示 ---
+
+// /live は WebSocket の接続を受け付け, 教員 (?role=instructor&token=... で接続した端末) が入力を送るたびに
+// 各段階の結果 (JSON) を接続中のすべての端末に配る.
+// 教員が1文字打つごとに学生の画面も同じ段階まで進むので, 教室で同じ例を一緒に追える.
+// 各段階の処理は Pipeline として NewHandler に渡すので, このパッケージは符号化の実装に依らない.
+// 途中から接続した端末には, 最後の入力の各段階をまとめて送る.
+// 教員になれるのは serve を起動したときの合言葉 (token) を知っている端末だけで, 他のサイトのページから
+// 接続されないように, ブラウザが送る Origin のホストが接続先のホストと違う要求は断る.
+
+// Step は1段階の結果
+type Step struct {
+	Name  string          // 段階の名前 (encode, ecc, mask など)
+	Input string          // その段階に渡した入力
+	Data  json.RawMessage // 結果の JSON
+}
+
+// Pipeline は教員の入力 payload を各段階で順に処理し, 段階ごとの結果を返す.
+// 段階が誤りで終わったら, その結果 (誤りを含む) までを返して後の段階は省く.
+type Pipeline func(ctx context.Context, payload []byte) []Step
+
+// liveMessage は配る1段階のメッセージ
+type liveMessage struct {
+	Seq   int             `json:"Seq"`   // 入力の通し番号 (同じ入力の段階は同じ番号)
+	Step  string          `json:"Step"`  // encode, ecc, mask
+	Input string          `json:"Input"` // その段階に渡した入力
+	Data  json.RawMessage `json:"Data"`  // 結果
+}
+
+// liveHub は接続中の端末と最後の入力の各段階のメッセージ
+type liveHub struct {
+	token    string // 教員の合言葉 (空なら誰も教員になれない)
+	pipeline Pipeline
+
+	mu      sync.Mutex
+	clients map[*wsConn]bool
+	seq     int
+	last    [][]byte
+}
+
+// newLiveHub は合言葉 token を知っている端末を教員とし, 教員の入力を pipeline で処理する liveHub を作る
+func newLiveHub(token string, pipeline Pipeline) *liveHub {
+	return &liveHub{token: token, pipeline: pipeline, clients: map[*wsConn]bool{}}
+}
+
+func (h *liveHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !sameOrigin(r) {
+		http.Error(w, "他のサイトのページからは接続できません: "+r.Header.Get("Origin"), http.StatusForbidden)
+		return
+	}
+	query := r.URL.Query()
+	instructor := query.Get("role") == "instructor"
+	if instructor && !h.isInstructor(query.Get("token")) {
+		http.Error(w, "教員の合言葉が違います.", http.StatusForbidden)
+		return
+	}
+	c, err := upgradeWebSocket(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer c.Close()
+	h.join(c)
+	defer h.leave(c)
+	for {
+		opcode, payload, err := c.readMessage()
+		if err != nil {
+			return
+		}
+		if instructor && opcode == wsText {
+			h.publish(r, payload)
+		}
+	}
+}
+
+// isInstructor は token が教員の合言葉と一致するかを返す (比べる時間から合言葉を推測されないようにする)
+func (h *liveHub) isInstructor(token string) bool {
+	return h.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) == 1
+}
+
+// sameOrigin は要求に Origin があれば, そのホストが接続先のホストと同じかを返す.
+// ブラウザは WebSocket の接続に必ず Origin を付けるので, Origin のない要求はブラウザ以外からのものとして通す.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host != "" && u.Host == r.Host
+}
+
+// join は端末を加え, 最後の入力の各段階を送る. 書き込みはロックの外で行う.
+func (h *liveHub) join(c *wsConn) {
+	h.mu.Lock()
+	h.clients[c] = true
+	last := h.last
+	h.mu.Unlock()
+	for _, message := range last {
+		if err := c.writeFrame(wsText, message); err != nil {
+			c.Close()
+			return
+		}
+	}
+}
+
+func (h *liveHub) leave(c *wsConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, c)
+}
+
+// publish は教員の入力を各段階で処理し, 段階ごとのメッセージをすべての端末に配る
+func (h *liveHub) publish(r *http.Request, payload []byte) {
+	h.mu.Lock()
+	h.seq++
+	seq := h.seq
+	h.mu.Unlock()
+
+	var messages [][]byte
+	for _, step := range h.pipeline(r.Context(), payload) {
+		message, _ := json.Marshal(liveMessage{Seq: seq, Step: step.Name, Input: step.Input, Data: step.Data})
+		messages = append(messages, message)
+	}
+
+	h.mu.Lock()
+	if seq != h.seq {
+		// 処理している間に新しい入力が来た (そちらを配る)
+		h.mu.Unlock()
+		return
+	}
+	h.last = messages
+	clients := make([]*wsConn, 0, len(h.clients))
+	for c := range h.clients {
+		clients = append(clients, c)
+	}
+	h.mu.Unlock()
+
+	// 遅い端末を待つ間も他の端末には配れるように, ロックの外で端末ごとに並行して書く
+	var wg sync.WaitGroup
+	for _, c := range clients {
+		wg.Add(1)
+		go func(c *wsConn) {
+			defer wg.Done()
+			for _, message := range messages {
+				if err := c.writeFrame(wsText, message); err != nil {
+					// 書き込めない端末は切る (読み込みの側が終わって leave する)
+					c.Close()
+					return
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+}
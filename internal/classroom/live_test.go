@@ -0,0 +1,44 @@
+package classroom
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// 合言葉のない教員と, 他のサイトのページからの接続を WebSocket に切り替える前に断ることを確かめる
+
+func TestLiveHubRejects(t *testing.T) {
+	hub := newLiveHub("secret", nil)
+	tests := []struct {
+		name   string
+		target string
+		origin string
+		status int
+	}{
+		{name: "他のサイト", target: "/live", origin: "http://evil.example", status: http.StatusForbidden},
+		{name: "合言葉なしの教員", target: "/live?role=instructor", status: http.StatusForbidden},
+		{name: "合言葉が違う教員", target: "/live?role=instructor&token=guess", origin: "http://example.com", status: http.StatusForbidden},
+		// 合言葉と Origin が合っていれば, WebSocket の要求でないことで断られる
+		{name: "教員", target: "/live?role=instructor&token=secret", origin: "http://example.com", status: http.StatusBadRequest},
+		{name: "学生", target: "/live", origin: "http://example.com", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://example.com"+tt.target, nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			hub.ServeHTTP(w, r)
+			if w.Code != tt.status {
+				t.Errorf("%s: %d, want %d", tt.target, w.Code, tt.status)
+			}
+		})
+	}
+
+	// 合言葉を決めていなければ, 誰も教員になれない
+	if newLiveHub("", nil).isInstructor("") {
+		t.Error("空の合言葉で教員になれました")
+	}
+}
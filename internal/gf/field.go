@@ -0,0 +1,311 @@
+// Package gf は有限体 GF(2^m) (m = 3〜16) の演算と, その体を係数とする多項式の演算を提供する.
+//
+// QRコードの RS 符号は GF(2^8) (原始多項式 0x11D) 上で計算する. 他の大きさの体も
+// 実験用に作れるよう, 体ごとに指数表と対数表を持つ Field 型として実装する.
+package gf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 作れる体の次数の範囲
+const (
+	MinDegree = 3
+	MaxDegree = 16
+)
+
+// QRPolynomial は QRコードの GF(2^8) を作る原始多項式 x^8 + x^4 + x^3 + x^2 + 1
+const QRPolynomial = 0x11D
+
+// defaultPolynomials は各次数の既定の原始多項式
+var defaultPolynomials = map[int]int{
+	3:  0xB,     // x^3 + x + 1
+	4:  0x13,    // x^4 + x + 1
+	5:  0x25,    // x^5 + x^2 + 1
+	6:  0x43,    // x^6 + x + 1
+	7:  0x89,    // x^7 + x^3 + 1
+	8:  0x11D,   // x^8 + x^4 + x^3 + x^2 + 1
+	9:  0x211,   // x^9 + x^4 + 1
+	10: 0x409,   // x^10 + x^3 + 1
+	11: 0x805,   // x^11 + x^2 + 1
+	12: 0x1053,  // x^12 + x^6 + x^4 + x + 1
+	13: 0x201B,  // x^13 + x^4 + x^3 + x + 1
+	14: 0x4443,  // x^14 + x^10 + x^6 + x + 1
+	15: 0x8003,  // x^15 + x + 1
+	16: 0x1100B, // x^16 + x^12 + x^3 + x + 1
+}
+
+// DefaultPolynomial は m 次の既定の原始多項式を返す (範囲外の m では0)
+func DefaultPolynomial(m int) int {
+	return defaultPolynomials[m]
+}
+
+// ErrDivisionByZero は0で割ろうとした (0の逆元を求めようとした) ことを表す
+var ErrDivisionByZero = errors.New("0で割ることはできません.")
+
+// NotElementError は値が体の要素 (0〜2^m-1) でないことを表す
+type NotElementError struct {
+	M     int
+	Value int
+}
+
+func (e *NotElementError) Error() string {
+	return fmt.Sprintf("GF(2^%d) の要素は0〜%dで指定してください: %d", e.M, 1<<e.M-1, e.Value)
+}
+
+// NotPrimitiveError は指定した多項式が原始多項式でない (α の位数が 2^m - 1 にならない) ことを表す
+type NotPrimitiveError struct {
+	Polynomial int
+	Order      int // 実際の α の位数
+}
+
+func (e *NotPrimitiveError) Error() string {
+	return fmt.Sprintf("0x%X は原始多項式ではありません (α の位数が %d です).", e.Polynomial, e.Order)
+}
+
+// Field は GF(2^m) の指数表と対数表. 作った後は読み取り専用なので, 複数のゴルーチンから同時に使える.
+type Field struct {
+	m          int
+	order      int // 乗法群の位数 2^m - 1
+	polynomial int
+	exp        []int // exp[k] = α^k (k = 0〜order-1)
+	log        []int // log[v] = log_α v (v = 0 は使わない)
+}
+
+// New は m 次の原始多項式 polynomial から GF(2^m) を作る. polynomial が0なら既定の原始多項式を使う.
+// polynomial が原始多項式でない場合は *NotPrimitiveError を返す.
+func New(m, polynomial int) (*Field, error) {
+	if m < MinDegree || m > MaxDegree {
+		return nil, fmt.Errorf("体の次数 m は%d〜%dで指定してください: %d", MinDegree, MaxDegree, m)
+	}
+	if polynomial == 0 {
+		polynomial = defaultPolynomials[m]
+	}
+	size := 1 << m
+	if polynomial < size || polynomial >= 2*size {
+		return nil, fmt.Errorf("%d次の多項式 (0x%X〜0x%X) を指定してください: 0x%X", m, size, 2*size-1, polynomial)
+	}
+
+	f := &Field{m: m, order: size - 1, polynomial: polynomial, exp: make([]int, size-1), log: make([]int, size)}
+	seen := make([]bool, size)
+	x := 1
+	for i := 0; i < f.order; i++ {
+		if seen[x] {
+			return nil, &NotPrimitiveError{Polynomial: polynomial, Order: i}
+		}
+		seen[x] = true
+		f.exp[i] = x
+		f.log[x] = i
+		x <<= 1
+		if x&size != 0 {
+			x ^= polynomial
+		}
+	}
+	return f, nil
+}
+
+// MustNew は New と同じだが, 作れない場合は panic する (定数の多項式で体を作るとき用)
+func MustNew(m, polynomial int) *Field {
+	f, err := New(m, polynomial)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// M は体の次数 m を返す
+func (f *Field) M() int { return f.m }
+
+// Order は乗法群の位数 2^m - 1 を返す
+func (f *Field) Order() int { return f.order }
+
+// Polynomial は体を作った原始多項式を返す
+func (f *Field) Polynomial() int { return f.polynomial }
+
+// Exp は α^k を返す (k は 2^m - 1 を法として扱うので, 負でもよい)
+func (f *Field) Exp(k int) int {
+	k %= f.order
+	if k < 0 {
+		k += f.order
+	}
+	return f.exp[k]
+}
+
+// Log は log_α v を返す (v = 0 の対数は定義されないので -1)
+func (f *Field) Log(v int) int {
+	if v == 0 {
+		return -1
+	}
+	return f.log[v]
+}
+
+// Contains は v が体の要素 (0〜2^m-1) かどうかを返す
+func (f *Field) Contains(v int) bool {
+	return v >= 0 && v <= f.order
+}
+
+// Add は a + b (= a XOR b) を返す
+func (f *Field) Add(a, b int) int {
+	return a ^ b
+}
+
+// Mul は a × b を返す
+func (f *Field) Mul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return f.exp[(f.log[a]+f.log[b])%f.order]
+}
+
+// Div は a ÷ b を返す. b = 0 なら ErrDivisionByZero, 体の要素でない値には *NotElementError を返す.
+func (f *Field) Div(a, b int) (int, error) {
+	if err := f.checkElements(a, b); err != nil {
+		return 0, err
+	}
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	if a == 0 {
+		return 0, nil
+	}
+	return f.exp[(f.log[a]-f.log[b]+f.order)%f.order], nil
+}
+
+// Inv は a の逆元を返す. a = 0 なら ErrDivisionByZero, 体の要素でない値には *NotElementError を返す.
+func (f *Field) Inv(a int) (int, error) {
+	if err := f.checkElements(a); err != nil {
+		return 0, err
+	}
+	if a == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return f.exp[(f.order-f.log[a])%f.order], nil
+}
+
+// checkElements は値が全て体の要素であることを確かめる
+func (f *Field) checkElements(values ...int) error {
+	for _, v := range values {
+		if !f.Contains(v) {
+			return &NotElementError{M: f.m, Value: v}
+		}
+	}
+	return nil
+}
+
+// Pow は a^n を返す (n は負でもよい)
+func (f *Field) Pow(a, n int) int {
+	if a == 0 {
+		if n == 0 {
+			return 1
+		}
+		return 0
+	}
+	e := (f.log[a] * n) % f.order
+	if e < 0 {
+		e += f.order
+	}
+	return f.exp[e]
+}
+
+// Alpha は要素を α^k の形で表す (0 は "0")
+func (f *Field) Alpha(v int) string {
+	if v == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("α^%d", f.log[v])
+}
+
+// Conjugates は α^k の共役元 α^k, α^(2k), α^(4k), ... の指数 (mod 2^m-1) を返す (円分剰余類)
+func (f *Field) Conjugates(k int) []int {
+	k %= f.order
+	exponents := []int{k}
+	for e := (2 * k) % f.order; e != k; e = (2 * e) % f.order {
+		exponents = append(exponents, e)
+	}
+	return exponents
+}
+
+// MinimalPolynomial は α^k の最小多項式 (α^k を根に持つ GF(2) 係数の既約多項式) をビット列で返す.
+// 最小多項式は共役元 α^e 全てについての (x + α^e) の積で, 係数は全て0か1になる.
+func (f *Field) MinimalPolynomial(k int) int {
+	p := []int{1} // 最高次から
+	for _, e := range f.Conjugates(k) {
+		root := f.exp[e]
+		next := make([]int, len(p)+1)
+		for i, c := range p {
+			next[i] ^= c
+			next[i+1] ^= f.Mul(c, root)
+		}
+		p = next
+	}
+	bits := 0
+	for _, c := range p {
+		bits = bits<<1 | c
+	}
+	return bits
+}
+
+// ElementOrder は a (≠ 0) の乗法的な位数 (a^d = 1 となる最小の d) を返す.
+// a = α^k なら位数は (2^m-1) / gcd(k, 2^m-1).
+// 0 は乗法群の元ではなく a^d = 1 となる d がないので, 0 と体の要素でない値には 0 を返す.
+func (f *Field) ElementOrder(a int) int {
+	if a == 0 || !f.Contains(a) {
+		return 0
+	}
+	return f.order / GCD(f.log[a], f.order)
+}
+
+// IsPrimitive は a が原始元 (位数が 2^m-1 で, 累乗で0以外の全ての元を作れる) かどうかを返す
+func (f *Field) IsPrimitive(a int) bool {
+	return a != 0 && f.ElementOrder(a) == f.order
+}
+
+// EvalBinaryPolynomial は GF(2) 係数の多項式 (ビット列) に体の元 x を代入した値を返す
+func (f *Field) EvalBinaryPolynomial(poly, x int) int {
+	result := 0
+	for k := bitLength(poly) - 1; k >= 0; k-- {
+		result = f.Mul(result, x) ^ (poly >> k & 1)
+	}
+	return result
+}
+
+// Isomorphism は体 from から体 to への同型写像を返す (mapping[v] は from の元 v に対応する to の元).
+// from の原始多項式の to での根 β を1つ選び, from の α^k を β^k に移す. root は選んだ β.
+func Isomorphism(from, to *Field) (mapping []int, root int, err error) {
+	if from.m != to.m {
+		return nil, 0, fmt.Errorf("次数の異なる体 GF(2^%d) と GF(2^%d) は同型ではありません.", from.m, to.m)
+	}
+	// 根は m 個の共役元があるので, α の指数が最も小さいものを選ぶ
+	for k := 0; k < to.order; k++ {
+		if to.EvalBinaryPolynomial(from.polynomial, to.exp[k]) == 0 {
+			root = to.exp[k]
+			break
+		}
+	}
+	if root == 0 {
+		return nil, 0, fmt.Errorf("0x%X の根が GF(2^%d) (0x%X) に見つかりません.", from.polynomial, to.m, to.polynomial)
+	}
+	mapping = make([]int, from.order+1)
+	for v := 1; v <= from.order; v++ {
+		mapping[v] = to.Pow(root, from.log[v])
+	}
+	return mapping, root, nil
+}
+
+// GCD は整数の最大公約数を返す (gcd(0, n) = n)
+func GCD(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// bitLength は v を2進数で表したときの桁数を返す
+func bitLength(v int) int {
+	n := 0
+	for ; v > 0; v >>= 1 {
+		n++
+	}
+	return n
+}
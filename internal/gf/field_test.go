@@ -0,0 +1,46 @@
+package gf
+
+import "testing"
+
+// 元の位数と原始元の判定を, 位数の定義 (a^d = 1 となる最小の d) と突き合わせる
+
+func TestElementOrder(t *testing.T) {
+	field := MustNew(8, QRPolynomial)
+	tests := []struct {
+		name      string
+		a         int
+		order     int
+		primitive bool
+	}{
+		{name: "0", a: 0, order: 0},
+		{name: "1", a: 1, order: 1},
+		{name: "α", a: 2, order: 255, primitive: true},
+		{name: "α^3", a: field.Exp(3), order: 85},
+		{name: "α^85", a: field.Exp(85), order: 3},
+		{name: "α^51", a: field.Exp(51), order: 5},
+		{name: "α^7", a: field.Exp(7), order: 255, primitive: true},
+		{name: "体の外", a: 256, order: 0},
+		{name: "負の値", a: -1, order: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := field.ElementOrder(tt.a); got != tt.order {
+				t.Errorf("ElementOrder(%d) = %d, want %d", tt.a, got, tt.order)
+			}
+			if got := field.IsPrimitive(tt.a); got != tt.primitive {
+				t.Errorf("IsPrimitive(%d) = %v, want %v", tt.a, got, tt.primitive)
+			}
+		})
+	}
+
+	// 0 以外の全ての元で, 位数 d は a^d = 1 となる最小の d
+	for a := 1; a <= field.Order(); a++ {
+		d := 1
+		for p := a; p != 1; p = field.Mul(p, a) {
+			d++
+		}
+		if got := field.ElementOrder(a); got != d {
+			t.Fatalf("ElementOrder(%d) = %d, want %d", a, got, d)
+		}
+	}
+}
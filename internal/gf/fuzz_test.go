@@ -0,0 +1,50 @@
+package gf
+
+import (
+	"errors"
+	"testing"
+)
+
+// PolyDivMod に任意の係数 (体の要素でない値も含む) を与えても panic せず,
+// 割り切れた場合は 被除数 = 商·除数 + 剰余 で剰余の次数が除数より低いことを確かめる.
+
+// coefficients はバイト列を2バイトずつ係数にする (0〜255 を超える値も作るため)
+func coefficients(b []byte) []int {
+	p := make([]int, len(b)/2)
+	for i := range p {
+		p[i] = int(b[2*i])<<1 | int(b[2*i+1])>>7
+	}
+	return p
+}
+
+func FuzzPolyDivMod(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 0, 3}, []byte{0, 1, 0, 1})
+	f.Add([]byte{0, 5}, []byte{0, 0})
+	f.Add([]byte{}, []byte{})
+	f.Add([]byte{1, 0x80, 0, 1}, []byte{0, 2})
+	field := MustNew(8, QRPolynomial)
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		dividend, divisor := coefficients(a), coefficients(b)
+		quotient, remainder, err := field.PolyDivMod(dividend, divisor)
+		var notElement *NotElementError
+		if errors.Is(err, ErrDivisionByZero) || errors.As(err, &notElement) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("PolyDivMod(%v, %v): %v", dividend, divisor, err)
+		}
+		if len(PolyTrim(remainder)) >= len(PolyTrim(divisor)) && PolyTrim(remainder)[0] != 0 {
+			t.Fatalf("PolyDivMod(%v, %v): 剰余 %v の次数が除数以上です", dividend, divisor, remainder)
+		}
+		got := PolyTrim(PolyAdd(field.PolyMul(quotient, PolyTrim(divisor)), remainder))
+		want := PolyTrim(dividend)
+		if len(got) != len(want) {
+			t.Fatalf("PolyDivMod(%v, %v): 商·除数 + 剰余 = %v", dividend, divisor, got)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("PolyDivMod(%v, %v): 商·除数 + 剰余 = %v", dividend, divisor, got)
+			}
+		}
+	})
+}
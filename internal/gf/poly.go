@@ -0,0 +1,119 @@
+package gf
+
+// --- 体の要素を係数とする多項式 ---
+
+// 多項式は係数の配列で, 最高次の係数から順に格納する (例: [1, 0, 3] は x^2 + 3).
+
+// PolyAdd は2つの多項式の和を返す (次数の低い側をそろえて係数ごとに XOR する)
+func PolyAdd(p1, p2 []int) []int {
+	maxLen := len(p1)
+	if len(p2) > maxLen {
+		maxLen = len(p2)
+	}
+	result := make([]int, maxLen)
+	copy(result[maxLen-len(p1):], p1)
+	offset := len(result) - len(p2)
+	for i := 0; i < len(p2); i++ {
+		result[offset+i] ^= p2[i]
+	}
+	return result
+}
+
+// PolyTrim は最高次側の0の係数を取り除く (0多項式は [0] とする)
+func PolyTrim(p []int) []int {
+	for i, coeff := range p {
+		if coeff != 0 {
+			return p[i:]
+		}
+	}
+	return []int{0}
+}
+
+// PolyCoeff は x^i の係数を返す (次数を超える場合は0)
+func PolyCoeff(p []int, i int) int {
+	if i < 0 || i >= len(p) {
+		return 0
+	}
+	return p[len(p)-1-i]
+}
+
+// PolyDerivative は形式微分を返す (標数2なので奇数次の項だけが残る)
+func PolyDerivative(p []int) []int {
+	degree := len(p) - 1
+	if degree <= 0 {
+		return []int{0}
+	}
+	result := make([]int, degree)
+	for i := 1; i <= degree; i++ {
+		if i%2 == 1 {
+			result[degree-i] = PolyCoeff(p, i)
+		}
+	}
+	return PolyTrim(result)
+}
+
+// PolyMul は2つの多項式の積を返す
+func (f *Field) PolyMul(p1, p2 []int) []int {
+	result := make([]int, len(p1)+len(p2)-1)
+	for i, a := range p1 {
+		for j, b := range p2 {
+			result[i+j] ^= f.Mul(a, b)
+		}
+	}
+	return result
+}
+
+// PolyEval は多項式 p の x における値をホーナー法で求める
+func (f *Field) PolyEval(p []int, x int) int {
+	result := 0
+	for _, coeff := range p {
+		result = f.Mul(result, x) ^ coeff
+	}
+	return result
+}
+
+// PolyDivMod は多項式どうしの除算を行い, 商と剰余を返す.
+// 除数が0多項式 (空も含む) なら ErrDivisionByZero, 体の要素でない係数には *NotElementError を返す.
+func (f *Field) PolyDivMod(dividend, divisor []int) ([]int, []int, error) {
+	if err := f.checkElements(dividend...); err != nil {
+		return nil, nil, err
+	}
+	if err := f.checkElements(divisor...); err != nil {
+		return nil, nil, err
+	}
+	divisor = PolyTrim(divisor)
+	if divisor[0] == 0 {
+		return nil, nil, ErrDivisionByZero
+	}
+	remainder := make([]int, len(dividend))
+	copy(remainder, dividend)
+	if len(dividend) < len(divisor) {
+		return []int{0}, PolyTrim(remainder), nil
+	}
+	quotient := make([]int, len(dividend)-len(divisor)+1)
+	for i := range quotient {
+		coeff := remainder[i]
+		if coeff == 0 {
+			continue
+		}
+		factor, err := f.Div(coeff, divisor[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		quotient[i] = factor
+		for j, d := range divisor {
+			remainder[i+j] ^= f.Mul(d, factor)
+		}
+	}
+	return PolyTrim(quotient), PolyTrim(remainder[len(quotient):]), nil
+}
+
+// Generator は根が α^b, α^(b+1), ..., α^(b+degree-1) (b = firstRoot) の RS 符号の生成多項式
+// g(x) = (x + α^b)(x + α^(b+1))...(x + α^(b+degree-1)) を返す. QRコードでは b = 0.
+func (f *Field) Generator(degree, firstRoot int) []int {
+	p := []int{1}
+	for i := 0; i < degree; i++ {
+		p = f.PolyMul(p, []int{1, f.Exp(firstRoot + i)})
+	}
+	return p
+}
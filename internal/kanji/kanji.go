@@ -0,0 +1,164 @@
+// Package kanji は QRコードの漢字モードでの文字の圧縮 (Shift JIS の2バイトを13ビットにする) と, その逆を提供する.
+package kanji
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// 漢字モードの値
+const (
+	ModeIndicator = "1000" // モード指示子
+	Bits          = 13     // 1文字を圧縮したビット数
+)
+
+// Shift JIS の2つの範囲とそれぞれから引く値
+const (
+	lowerStart, lowerEnd, lowerOffset = 0x8140, 0x9FFC, 0x8140
+	upperStart, upperEnd, upperOffset = 0xE040, 0xEBBF, 0xC140
+)
+
+// Char は1文字の圧縮の過程
+type Char struct {
+	Rune       rune
+	ShiftJIS   uint16 // Shift JIS のコード
+	Offset     uint16 // 引いた値 (0x8140 または 0xC140)
+	Subtracted uint16 // ShiftJIS - Offset
+	Value      uint16 // 圧縮した13ビットの値 (上位バイト × 0xC0 + 下位バイト)
+}
+
+// RangeError は文字の Shift JIS のコードが漢字モードで扱える範囲にないことを表す
+type RangeError struct {
+	Index int // 文字列の何文字目か (0始まり)
+	Rune  rune
+	Code  uint16 // Shift JIS のコード (1バイトの文字はそのバイト)
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("'%c' (%04X) はサポート外のShift-JISコード範囲です", e.Rune, e.Code)
+}
+
+// ValueError は13ビットの値が漢字モードで扱える Shift JIS のコードに対応しないことを表す
+type ValueError struct {
+	Value uint16
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("13ビットの値 %04X はサポート外のShift-JISコード範囲に対応します", e.Value)
+}
+
+// UnmappedError は13ビットの値の Shift JIS のコードに文字が割り当てられていない,
+// または同じ文字に割り当てた別のコードがあり, 符号化し直すと同じ値に戻らないことを表す
+type UnmappedError struct {
+	Value    uint16
+	ShiftJIS uint16
+}
+
+func (e *UnmappedError) Error() string {
+	return fmt.Sprintf("13ビットの値 %04X (Shift JIS %04X) に対応する文字がありません", e.Value, e.ShiftJIS)
+}
+
+// Compress は文字列の各文字を Shift JIS にして13ビットに圧縮する.
+// Shift JIS にできない文字があれば変換のエラーを, 漢字モードの範囲外の文字があれば *RangeError を返す.
+func Compress(s string) ([]Char, error) {
+	encoder := japanese.ShiftJIS.NewEncoder()
+	var chars []Char
+	for i, r := range []rune(s) {
+		b, err := encoder.Bytes([]byte(string(r)))
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != 2 {
+			var code uint16
+			for _, v := range b {
+				code = code<<8 | uint16(v)
+			}
+			return nil, &RangeError{Index: i, Rune: r, Code: code}
+		}
+		c, ok := compress(uint16(b[0])<<8 | uint16(b[1]))
+		if !ok {
+			return nil, &RangeError{Index: i, Rune: r, Code: c.ShiftJIS}
+		}
+		c.Rune = r
+		chars = append(chars, c)
+	}
+	return chars, nil
+}
+
+// compress は Shift JIS のコードを13ビットに圧縮する. 範囲外なら false を返す.
+func compress(code uint16) (Char, bool) {
+	c := Char{ShiftJIS: code}
+	switch {
+	case code >= lowerStart && code <= lowerEnd:
+		c.Offset = lowerOffset
+	case code >= upperStart && code <= upperEnd:
+		c.Offset = upperOffset
+	default:
+		return c, false
+	}
+	c.Subtracted = code - c.Offset
+	c.Value = (c.Subtracted>>8)*0xC0 + c.Subtracted&0xFF
+	return c, true
+}
+
+// Expand は13ビットの値から Shift JIS のコードを復元する (Compress の逆). Rune は設定しない.
+func Expand(value uint16) (Char, error) {
+	c := Char{Value: value, Subtracted: (value/0xC0)<<8 | value%0xC0}
+	switch {
+	case c.Subtracted <= lowerEnd-lowerOffset:
+		c.Offset = lowerOffset
+	case c.Subtracted >= upperStart-upperOffset && c.Subtracted <= upperEnd-upperOffset:
+		c.Offset = upperOffset
+	default:
+		return c, &ValueError{Value: value}
+	}
+	c.ShiftJIS = c.Subtracted + c.Offset
+	return c, nil
+}
+
+// DecodeShiftJIS は Shift JIS のコードの列を文字列にする
+func DecodeShiftJIS(codes []uint16) (string, error) {
+	b := make([]byte, 0, len(codes)*2)
+	for _, code := range codes {
+		b = append(b, byte(code>>8), byte(code))
+	}
+	decoded, err := japanese.ShiftJIS.NewDecoder().Bytes(b)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// Decode は13ビットの値の列を文字列に戻す
+func Decode(values []uint16) (string, []Char, error) {
+	chars := make([]Char, len(values))
+	codes := make([]uint16, len(values))
+	for i, v := range values {
+		c, err := Expand(v)
+		if err != nil {
+			return "", nil, err
+		}
+		chars[i] = c
+		codes[i] = c.ShiftJIS
+	}
+	s, err := DecodeShiftJIS(codes)
+	if err != nil {
+		return "", nil, err
+	}
+	runes := []rune(s)
+	if len(runes) != len(chars) {
+		return "", nil, fmt.Errorf("復元した文字数(%d)が値の数(%d)と一致しません.", len(runes), len(chars))
+	}
+	for i := range chars {
+		// 割り当てのないコードは U+FFFD になり, NEC と IBM の拡張文字のような重複したコードは別のコードに符号化されるので,
+		// 符号化し直して同じコードに戻る文字だけを受け付ける
+		again, err := Compress(string(runes[i]))
+		if err != nil || runes[i] == utf8.RuneError || again[0].ShiftJIS != chars[i].ShiftJIS {
+			return "", nil, &UnmappedError{Value: chars[i].Value, ShiftJIS: chars[i].ShiftJIS}
+		}
+		chars[i].Rune = runes[i]
+	}
+	return s, chars, nil
+}
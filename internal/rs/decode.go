@@ -0,0 +1,415 @@
+package rs
+
+import (
+	"fmt"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/gf"
+)
+
+// --- 復号 (Berlekamp-Massey 法またはユークリッド互除法, Chien 探索, Forney のアルゴリズム) ---
+
+// Method は誤り位置多項式の求め方
+type Method int
+
+const (
+	BerlekampMassey Method = iota // Berlekamp-Massey 法 (既定)
+	Euclid                        // 拡張ユークリッド互除法で鍵方程式を解く
+	ErasuresOnly                  // 消失位置多項式をそのまま使い, 位置の分かっている消失だけを訂正する
+)
+
+// FailureReason は訂正できなかった理由
+type FailureReason string
+
+const (
+	ReasonTooManyErrors     FailureReason = "too-many-errors"     // 誤り位置多項式の次数が訂正能力 (2e+f ≦ nParity) を超えた
+	ReasonRootCountMismatch FailureReason = "root-count-mismatch" // 符号語の中にある Λ(x) の根の数が次数と一致しない
+	ReasonResidualSyndrome  FailureReason = "residual-syndrome"   // 訂正してもシンドロームが0にならない
+)
+
+// DecodeError は誤りが訂正能力を超えていて訂正できなかったことと, その診断情報を表す.
+// errors.Is(err, ErrTooManyErrors) が true になる.
+// 訂正能力を超えた誤りは多くの場合このいずれかで検出できるが, 別の正しい符号語に誤訂正されて検出できないこともある.
+type DecodeError struct {
+	Reason               FailureReason
+	Errors               int   // 誤りの個数 e (誤り位置多項式の次数 - 消失の個数)
+	Erasures             int   // 消失の個数 f
+	LocatorDegree        int   // 誤り位置多項式の次数
+	RootCount            int   // 符号語の中にある根の数
+	RootsOutsideCodeword int   // 符号語の外にある根の数
+	ResidualSyndromes    []int // 訂正後のシンドローム (ReasonResidualSyndrome の場合)
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%v (%s)", ErrTooManyErrors, e.Reason)
+}
+
+func (e *DecodeError) Unwrap() error { return ErrTooManyErrors }
+
+// DecodeOptions は DecodeWith の設定
+type DecodeOptions struct {
+	Method   Method
+	Erasures []int  // 位置の分かっている消失 (先頭のバイトを0とする位置. 重複しないこと)
+	Trace    *Trace // 途中の値を受け取る関数 (nil なら呼ばない)
+}
+
+// Trace は復号の途中の値を受け取る関数をまとめたもの. nil の項目は呼ばない.
+// 渡す配列は呼び出しごとに新しく作るので, そのまま残してよい.
+type Trace struct {
+	Syndromes       func(syndromes []int)          // S_b, S_(b+1), ...
+	ErasureLocator  func(gamma []int)              // 消失位置多項式 Γ(x) (最高次から)
+	BerlekampMassey func(step BerlekampMasseyStep) // Berlekamp-Massey 法の各反復
+	Euclid          func(step EuclidStep)          // ユークリッド互除法の各回の割り算
+	Locator         func(locator []int)            // 求めた誤り位置多項式 Λ(x) (最高次から)
+	ChienSearch     func(step ChienStep)           // Chien 探索で代入した各要素
+	Evaluator       func(omega, derivative []int)  // 誤り評価多項式 Ω(x) と Λ'(x)
+	Forney          func(step ForneyStep)          // Forney のアルゴリズムで求めた各位置の誤りの大きさ
+}
+
+// BerlekampMasseyStep は Berlekamp-Massey 法の1回の反復
+type BerlekampMasseyStep struct {
+	R           int   // 反復の番号 (S_r までを使う)
+	Discrepancy int   // 食い違い Δ_r
+	Locator     []int // この反復の後の Λ(x)
+	L           int   // この反復の後の線形帰還シフトレジスタの長さ
+}
+
+// EuclidStep はユークリッド互除法の1回の割り算
+type EuclidStep struct {
+	Quotient  []int // 商 q_i(x)
+	Remainder []int // 剰余 r_i(x)
+	Locator   []int // t_i(x) = t_{i-2}(x) - q_i(x) t_{i-1}(x)
+}
+
+// ChienStep は Chien 探索で体の要素 α^{-p} を代入した結果
+type ChienStep struct {
+	Degree   int // p (X = α^p が誤り位置の候補)
+	Position int // 対応するバイトの位置 (符号語の外なら -1)
+	Element  int // 代入した要素 α^{-p}
+	Value    int // Λ(α^{-p})
+}
+
+// ForneyStep は1つの位置の誤りの大きさ e_k = X_k^(1-b) Ω(X_k^{-1}) / Λ'(X_k^{-1}) の計算
+type ForneyStep struct {
+	Position        int // バイトの位置
+	Locator         int // X_k = α^(n-1-位置)
+	OmegaValue      int // Ω(X_k^{-1})
+	DerivativeValue int // Λ'(X_k^{-1})
+	Value           int // e_k (Λ'(X_k^{-1}) = 0 なら求められないので0)
+}
+
+// Result は DecodeWith の結果
+type Result struct {
+	Corrected []byte // 訂正した符号語
+	Positions []int  // 訂正したバイトの位置 (ErasuresOnly では消失の指定順, それ以外は先頭から順)
+	Values    []int  // 各位置の誤りの大きさ
+	Errors    int    // 誤りの個数 e (消失は含まない)
+}
+
+// Decode は受信語 received (末尾 nParity バイトが誤り訂正コード語) の誤りを Berlekamp-Massey 法で訂正した符号語と,
+// 訂正したバイトの位置 (先頭のバイトから順) を返す. received は書き換えない.
+// 誤りが floor(nParity/2) 個を超えていて訂正できない場合は ErrTooManyErrors (*DecodeError) を返す.
+func (c *Codec) Decode(received []byte, nParity int) ([]byte, []int, error) {
+	r, err := c.DecodeWith(received, nParity, DecodeOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.Corrected, r.Positions, nil
+}
+
+// DecodeWith は opts の方法で受信語の誤りと消失を訂正する. received は書き換えない.
+// e 個の誤りと f 個の消失は 2e+f ≦ nParity なら訂正できる. 訂正できない場合は *DecodeError を返す.
+func (c *Codec) DecodeWith(received []byte, nParity int, opts DecodeOptions) (Result, error) {
+	n := len(received)
+	if err := checkLength(n-nParity, nParity); err != nil {
+		return Result{}, err
+	}
+	if opts.Method < BerlekampMassey || opts.Method > ErasuresOnly {
+		return Result{}, fmt.Errorf("不明な復号法です: %d", opts.Method)
+	}
+	if err := checkErasures(opts.Erasures, n, nParity); err != nil {
+		return Result{}, err
+	}
+	trace := opts.Trace
+	if trace == nil {
+		trace = &Trace{}
+	}
+
+	corrected := append([]byte(nil), received...)
+	syndromes := c.Syndromes(received, nParity)
+	if trace.Syndromes != nil {
+		trace.Syndromes(append([]int(nil), syndromes...))
+	}
+	if allZero(syndromes) {
+		return Result{Corrected: corrected}, nil
+	}
+
+	f := len(opts.Erasures)
+	gamma := c.erasureLocator(opts.Erasures, n)
+	if trace.ErasureLocator != nil {
+		trace.ErasureLocator(append([]int(nil), gamma...))
+	}
+	var locator []int
+	var err error
+	switch opts.Method {
+	case Euclid:
+		locator, err = c.euclid(syndromes, gamma, trace.Euclid)
+	case ErasuresOnly:
+		locator = gamma
+	default:
+		locator, err = c.berlekampMassey(syndromes, gamma, trace.BerlekampMassey)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	if trace.Locator != nil {
+		trace.Locator(append([]int(nil), locator...))
+	}
+
+	// Λ(x) は消失の位置も根に持つので, 誤りの個数は次数から消失の個数を引いたもの
+	degree := len(locator) - 1
+	failure := &DecodeError{Errors: degree - f, Erasures: f, LocatorDegree: degree}
+	if 2*failure.Errors+f > nParity {
+		failure.Reason = ReasonTooManyErrors
+		return Result{}, failure
+	}
+	positions := append([]int(nil), opts.Erasures...)
+	if opts.Method != ErasuresOnly {
+		var outside int
+		positions, outside = c.chienSearch(locator, n, trace.ChienSearch)
+		if len(positions) != degree {
+			failure.Reason = ReasonRootCountMismatch
+			failure.RootCount = len(positions)
+			failure.RootsOutsideCodeword = outside
+			return Result{}, failure
+		}
+	}
+
+	values, err := c.forney(syndromes, locator, positions, n, trace)
+	if err != nil {
+		return Result{}, err
+	}
+	for k, pos := range positions {
+		corrected[pos] ^= byte(values[k])
+	}
+	if residual := c.Syndromes(corrected, nParity); !allZero(residual) {
+		failure.Reason = ReasonResidualSyndrome
+		failure.RootCount = len(positions)
+		failure.ResidualSyndromes = residual
+		return Result{}, failure
+	}
+	return Result{Corrected: corrected, Positions: positions, Values: values, Errors: failure.Errors}, nil
+}
+
+// checkErasures は消失位置が符号語の範囲内で重複しておらず, 個数が nParity 以下であることを確かめる
+func checkErasures(erasures []int, n, nParity int) error {
+	if len(erasures) > nParity {
+		return fmt.Errorf("消失が多すぎます (%d個). 訂正できるのは%d個までです.", len(erasures), nParity)
+	}
+	seen := make(map[int]bool, len(erasures))
+	for _, pos := range erasures {
+		if pos < 0 || pos >= n {
+			return fmt.Errorf("消失位置 %d が範囲外です (0〜%d).", pos, n-1)
+		}
+		if seen[pos] {
+			return fmt.Errorf("消失位置 %d が重複しています.", pos)
+		}
+		seen[pos] = true
+	}
+	return nil
+}
+
+// erasureLocator は消失位置多項式 Γ(x) = Π (1 + X_j x) を返す (X_j = α^(n-1-位置)). 消失がなければ Γ(x) = 1.
+func (c *Codec) erasureLocator(erasures []int, n int) []int {
+	locator := []int{1}
+	for _, pos := range erasures {
+		locator = c.PolyMul(locator, []int{c.field.Exp(n - 1 - pos), 1})
+	}
+	return locator
+}
+
+// berlekampMassey はシンドロームから誤り位置多項式 Λ(x) (最高次の係数から順) を求める.
+// 消失がある場合は Λ(x) = Γ(x), L = f (消失の個数) から始め, 反復も r = f から始める.
+// このとき得られる Λ(x) は誤りと消失の両方の位置を根に持つ.
+func (c *Codec) berlekampMassey(syndromes, erasureLocator []int, step func(BerlekampMasseyStep)) ([]int, error) {
+	locator := erasureLocator  // Λ(x)
+	previous := erasureLocator // 最後に L が変わる直前の Λ(x)
+	f := len(erasureLocator) - 1
+	L := f
+	shift := 1           // previous に掛ける x の次数
+	lastDiscrepancy := 1 // previous に対応する食い違い
+	for r := f; r < len(syndromes); r++ {
+		// Δ_r = S_r + Σ_{i=1} Λ_i S_{r-i}
+		discrepancy := syndromes[r]
+		for i := 1; i < len(locator) && i <= r; i++ {
+			discrepancy ^= int(c.mul[gf.PolyCoeff(locator, i)][syndromes[r-i]])
+		}
+		if discrepancy == 0 {
+			shift++
+		} else {
+			// Λ(x) ← Λ(x) - (Δ_r / Δ_prev) x^shift B(x)
+			factor, err := c.field.Div(discrepancy, lastDiscrepancy)
+			if err != nil {
+				return nil, err
+			}
+			correction := make([]int, len(previous)+shift)
+			for i, coeff := range previous {
+				correction[i] = int(c.mul[factor][coeff])
+			}
+			next := gf.PolyTrim(gf.PolyAdd(locator, correction))
+			if 2*L <= r+f {
+				previous = locator
+				L = r + 1 + f - L
+				lastDiscrepancy = discrepancy
+				shift = 1
+			} else {
+				shift++
+			}
+			locator = next
+		}
+		if step != nil {
+			step(BerlekampMasseyStep{R: r, Discrepancy: discrepancy, Locator: append([]int(nil), locator...), L: L})
+		}
+	}
+	return locator, nil
+}
+
+// euclid は拡張ユークリッド互除法で鍵方程式 Λ(x)S(x) ≡ Ω(x) (mod x^count) を解き, Λ(x) を返す.
+// r_{-1} = x^count, r_0 = S(x)Γ(x) mod x^count, t_0 = Γ(x) から割り算を繰り返し,
+// 剰余の次数が (count+f)/2 未満になったら止める (f は消失の個数). Λ(x) は Λ(0) = 1 となるよう正規化する.
+func (c *Codec) euclid(syndromes, erasureLocator []int, step func(EuclidStep)) ([]int, error) {
+	count := len(syndromes)
+	f := len(erasureLocator) - 1
+	previousRemainder := make([]int, count+1) // x^count
+	previousRemainder[0] = 1
+	remainder := c.errorEvaluator(syndromes, erasureLocator)
+	previousLocator := []int{0}
+	locator := erasureLocator
+	for 2*(len(remainder)-1) >= count+f {
+		quotient, nextRemainder, err := c.field.PolyDivMod(previousRemainder, remainder)
+		if err != nil {
+			return nil, err
+		}
+		nextLocator := gf.PolyTrim(gf.PolyAdd(previousLocator, c.PolyMul(quotient, locator)))
+		previousRemainder, remainder = remainder, nextRemainder
+		previousLocator, locator = locator, nextLocator
+		if step != nil {
+			step(EuclidStep{Quotient: quotient, Remainder: append([]int(nil), remainder...), Locator: append([]int(nil), locator...)})
+		}
+	}
+
+	constant := gf.PolyCoeff(locator, 0)
+	if constant == 0 {
+		// Λ(0) = 0 となるのは訂正できない場合なので, そのまま返す (Chien 探索で根の数が合わなくなる)
+		return locator, nil
+	}
+	scale, err := c.field.Inv(constant)
+	if err != nil {
+		return nil, err
+	}
+	normalized := make([]int, len(locator))
+	c.ScaleInto(normalized, locator, scale)
+	return normalized, nil
+}
+
+// chienSearch は Λ(x) に α^0, α^{-1}, ..., α^{-(2^m-2)} を順に代入して根を探し,
+// 根に対応するバイトの位置 (n-1-p) を先頭のバイトから順に返す. 符号語の外 (p ≧ n) にある根の数も返す.
+// 各項 Λ_i α^{-ip} は前の要素での値に α^{-i} を掛けて更新する.
+func (c *Codec) chienSearch(locator []int, n int, step func(ChienStep)) ([]int, int) {
+	degree := len(locator) - 1
+	terms := make([]int, degree+1)
+	for i := range terms {
+		terms[i] = gf.PolyCoeff(locator, i)
+	}
+	var positions []int
+	outside := 0
+	for p := 0; p < c.field.Order(); p++ {
+		if p > 0 {
+			for i := 1; i <= degree; i++ {
+				terms[i] = int(c.mul[terms[i]][c.field.Exp(-i)])
+			}
+		}
+		value := 0
+		for _, term := range terms {
+			value ^= term
+		}
+		position := -1
+		if p < n {
+			position = n - 1 - p
+		}
+		if step != nil {
+			step(ChienStep{Degree: p, Position: position, Element: c.field.Exp(-p), Value: value})
+		}
+		if value != 0 {
+			continue
+		}
+		if position < 0 {
+			outside++
+		} else {
+			positions = append(positions, position)
+		}
+	}
+	// 位置を先頭のバイトから順に並べる
+	for i, j := 0, len(positions)-1; i < j; i, j = i+1, j-1 {
+		positions[i], positions[j] = positions[j], positions[i]
+	}
+	return positions, outside
+}
+
+// forney は Forney のアルゴリズムで各位置の誤りの大きさ e_k = X_k^(1-b) Ω(X_k^{-1}) / Λ'(X_k^{-1}) を求める.
+// Λ'(X_k^{-1}) = 0 (重根) の位置は大きさを求められないので0とする (訂正後のシンドロームの確認で失敗になる).
+func (c *Codec) forney(syndromes, locator, positions []int, n int, trace *Trace) ([]int, error) {
+	omega := c.errorEvaluator(syndromes, locator)
+	derivative := gf.PolyDerivative(locator)
+	if trace.Evaluator != nil {
+		trace.Evaluator(append([]int(nil), omega...), derivative)
+	}
+	values := make([]int, len(positions))
+	for k, pos := range positions {
+		// X_k = α^(n-1-pos), X_k^{-1} = α^-(n-1-pos)
+		e := n - 1 - pos
+		xInv := c.field.Exp(-e)
+		omegaValue := c.polyEval(omega, xInv)
+		derivativeValue := c.polyEval(derivative, xInv)
+		if derivativeValue != 0 {
+			quotient, err := c.field.Div(omegaValue, derivativeValue)
+			if err != nil {
+				return nil, err
+			}
+			values[k] = int(c.mul[c.field.Exp(e*(1-c.firstRoot))][quotient])
+		}
+		if trace.Forney != nil {
+			trace.Forney(ForneyStep{Position: pos, Locator: c.field.Exp(e), OmegaValue: omegaValue, DerivativeValue: derivativeValue, Value: values[k]})
+		}
+	}
+	return values, nil
+}
+
+// errorEvaluator は誤り評価多項式 Ω(x) = S(x)Λ(x) mod x^count を返す (S(x) = Σ S_i x^i, count はシンドロームの個数)
+func (c *Codec) errorEvaluator(syndromes, locator []int) []int {
+	count := len(syndromes)
+	result := make([]int, count)
+	for i := 0; i < count; i++ {
+		for j := 0; j <= i; j++ {
+			result[count-1-i] ^= int(c.mul[syndromes[i-j]][gf.PolyCoeff(locator, j)])
+		}
+	}
+	return gf.PolyTrim(result)
+}
+
+// polyEval は多項式 p の x における値をホーナー法で求める (係数と x は復号の途中の値なので0〜255)
+func (c *Codec) polyEval(p []int, x int) int {
+	result := 0
+	for _, coeff := range p {
+		result = int(c.mul[result][x]) ^ coeff
+	}
+	return result
+}
+
+func allZero(values []int) bool {
+	for _, v := range values {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
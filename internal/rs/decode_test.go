@@ -0,0 +1,229 @@
+package rs
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/gf"
+)
+
+// 型番1-L と同じ RS(26, 19) 符号で, 誤りと消失の訂正と, 訂正能力を超えた場合の診断情報を確かめる
+
+const testParity = 7
+
+// testCodec は QRコードと同じ体と最初の根 α^0 の Codec を返す
+func testCodec(t *testing.T) *Codec {
+	t.Helper()
+	c, err := NewCodec(gf.MustNew(8, gf.QRPolynomial), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// testCodeword は19バイトのデータに誤り訂正コード語を付けた26バイトの符号語を返す
+func testCodeword(t *testing.T, c *Codec) []byte {
+	t.Helper()
+	data := make([]byte, 19)
+	for i := range data {
+		data[i] = byte(i*37 + 11)
+	}
+	codeword, err := c.Encode(data, testParity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return codeword
+}
+
+// corrupt は符号語の各位置に誤りの大きさを加えた受信語を返す
+func corrupt(codeword []byte, errs map[int]int) []byte {
+	received := append([]byte(nil), codeword...)
+	for pos, value := range errs {
+		received[pos] ^= byte(value)
+	}
+	return received
+}
+
+func TestDecodeWith(t *testing.T) {
+	c := testCodec(t)
+	codeword := testCodeword(t, c)
+	tests := []struct {
+		name      string
+		errs      map[int]int // 位置ごとの誤りの大きさ (消失の位置も含む)
+		erasures  []int
+		positions []int // 訂正したバイトの位置 (先頭から順)
+		values    []int
+		errors    int // 誤りの個数 e
+	}{
+		{name: "誤りなし", errs: nil},
+		{name: "誤り1個", errs: map[int]int{5: 0x5A}, positions: []int{5}, values: []int{0x5A}, errors: 1},
+		{name: "誤り2個", errs: map[int]int{0: 0x01, 25: 0xFF}, positions: []int{0, 25}, values: []int{0x01, 0xFF}, errors: 2},
+		{name: "誤り3個", errs: map[int]int{3: 0x10, 12: 0x80, 20: 0x33}, positions: []int{3, 12, 20}, values: []int{0x10, 0x80, 0x33}, errors: 3},
+		{name: "消失7個", errs: map[int]int{0: 1, 4: 2, 8: 3, 12: 4, 16: 5, 20: 6, 24: 7}, erasures: []int{0, 4, 8, 12, 16, 20, 24}, positions: []int{0, 4, 8, 12, 16, 20, 24}, values: []int{1, 2, 3, 4, 5, 6, 7}},
+		{name: "誤り1個と消失5個", errs: map[int]int{1: 0xA0, 6: 0x11, 7: 0x22, 9: 0x33, 18: 0x44, 22: 0x55}, erasures: []int{6, 7, 9, 18, 22}, positions: []int{1, 6, 7, 9, 18, 22}, values: []int{0xA0, 0x11, 0x22, 0x33, 0x44, 0x55}, errors: 1},
+		{name: "誤り2個と消失3個", errs: map[int]int{2: 0x0F, 11: 0xF0, 13: 0x3C, 14: 0xC3, 25: 0x99}, erasures: []int{25, 13, 14}, positions: []int{2, 11, 13, 14, 25}, values: []int{0x0F, 0xF0, 0x3C, 0xC3, 0x99}, errors: 2},
+		{name: "誤り3個と消失1個", errs: map[int]int{0: 0x42, 10: 0x24, 19: 0x81, 23: 0x18}, erasures: []int{23}, positions: []int{0, 10, 19, 23}, values: []int{0x42, 0x24, 0x81, 0x18}, errors: 3},
+	}
+	for _, method := range []struct {
+		name   string
+		method Method
+	}{{"berlekamp-massey", BerlekampMassey}, {"euclid", Euclid}} {
+		for _, tt := range tests {
+			t.Run(method.name+"/"+tt.name, func(t *testing.T) {
+				received := corrupt(codeword, tt.errs)
+				before := append([]byte(nil), received...)
+				result, err := c.DecodeWith(received, testParity, DecodeOptions{Method: method.method, Erasures: tt.erasures})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(received, before) {
+					t.Error("受信語が書き換えられました")
+				}
+				if !bytes.Equal(result.Corrected, codeword) {
+					t.Errorf("Corrected = % X, want % X", result.Corrected, codeword)
+				}
+				if !reflect.DeepEqual(result.Positions, tt.positions) || !reflect.DeepEqual(result.Values, tt.values) || result.Errors != tt.errors {
+					t.Errorf("位置 %v, 大きさ %v, 誤り %d個, want %v, %v, %d個", result.Positions, result.Values, result.Errors, tt.positions, tt.values, tt.errors)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeWithErasuresOnly(t *testing.T) {
+	c := testCodec(t)
+	codeword := testCodeword(t, c)
+	erasures := []int{24, 2, 17, 9}
+	received := corrupt(codeword, map[int]int{24: 0x01, 2: 0x02, 17: 0x03, 9: 0x04})
+	var gamma []int
+	result, err := c.DecodeWith(received, testParity, DecodeOptions{Method: ErasuresOnly, Erasures: erasures, Trace: &Trace{
+		ErasureLocator: func(g []int) { gamma = g },
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result.Corrected, codeword) {
+		t.Fatalf("Corrected = % X, want % X", result.Corrected, codeword)
+	}
+	// 消失だけの訂正では, 位置は指定した順に返す
+	if !reflect.DeepEqual(result.Positions, erasures) || !reflect.DeepEqual(result.Values, []int{1, 2, 3, 4}) || result.Errors != 0 {
+		t.Errorf("位置 %v, 大きさ %v, 誤り %d個", result.Positions, result.Values, result.Errors)
+	}
+	if len(gamma) != len(erasures)+1 {
+		t.Errorf("Γ(x) = %v", gamma)
+	}
+
+	// 消失の位置の指定が正しくなければ訂正する前にエラーにする
+	for _, bad := range [][]int{{26}, {-1}, {3, 3}, {0, 1, 2, 3, 4, 5, 6, 7}} {
+		if _, err := c.DecodeWith(received, testParity, DecodeOptions{Method: ErasuresOnly, Erasures: bad}); err == nil || errors.Is(err, ErrTooManyErrors) {
+			t.Errorf("消失 %v: %v", bad, err)
+		}
+	}
+}
+
+func TestDecodeWithTrace(t *testing.T) {
+	c := testCodec(t)
+	received := corrupt(testCodeword(t, c), map[int]int{3: 0x10, 12: 0x80})
+
+	var bm []BerlekampMasseyStep
+	var chien []ChienStep
+	var forney []ForneyStep
+	var bmLocator, euclidLocator []int
+	if _, err := c.DecodeWith(received, testParity, DecodeOptions{Trace: &Trace{
+		BerlekampMassey: func(step BerlekampMasseyStep) { bm = append(bm, step) },
+		Locator:         func(locator []int) { bmLocator = locator },
+		ChienSearch:     func(step ChienStep) { chien = append(chien, step) },
+		Forney:          func(step ForneyStep) { forney = append(forney, step) },
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	// シンドローム1つにつき1回反復し, 最後のシフトレジスタの長さは誤りの個数になる
+	if len(bm) != testParity || bm[len(bm)-1].L != 2 {
+		t.Errorf("Berlekamp-Massey 法の反復 %+v", bm)
+	}
+	// Chien 探索は体の0以外の全ての要素を代入し, 根は誤りの位置になる
+	roots := 0
+	for _, step := range chien {
+		if step.Value == 0 {
+			roots++
+			if step.Position != 3 && step.Position != 12 {
+				t.Errorf("根の位置 %d", step.Position)
+			}
+		}
+	}
+	if len(chien) != 255 || roots != 2 {
+		t.Errorf("Chien 探索 %d 回, 根 %d 個", len(chien), roots)
+	}
+	if len(forney) != 2 || forney[0].Value != 0x10 || forney[1].Value != 0x80 {
+		t.Errorf("Forney %+v", forney)
+	}
+
+	var euclid []EuclidStep
+	if _, err := c.DecodeWith(received, testParity, DecodeOptions{Method: Euclid, Trace: &Trace{
+		Euclid:  func(step EuclidStep) { euclid = append(euclid, step) },
+		Locator: func(locator []int) { euclidLocator = locator },
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(euclid) == 0 {
+		t.Error("ユークリッド互除法の割り算がありません")
+	}
+	// どちらの方法でも Λ(0) = 1 に正規化した同じ誤り位置多項式になる
+	if !reflect.DeepEqual(bmLocator, euclidLocator) {
+		t.Errorf("Λ(x) = %v (Berlekamp-Massey), %v (Euclid)", bmLocator, euclidLocator)
+	}
+}
+
+func TestDecodeWithFailure(t *testing.T) {
+	c := testCodec(t)
+	codeword := testCodeword(t, c)
+	// 2e+f > 7 の受信語. Berlekamp-Massey 法は次数が訂正能力を超えた誤り位置多項式を返すが,
+	// ユークリッド互除法は剰余の次数で止めるので, 次数の低い多項式の根の数や訂正後のシンドロームで失敗が分かる.
+	tests := []struct {
+		name     string
+		errs     map[int]int
+		erasures []int
+		bm       FailureReason // Berlekamp-Massey 法で訂正できなかった理由
+		euclid   FailureReason // ユークリッド互除法で訂正できなかった理由
+	}{
+		{name: "誤り4個", errs: map[int]int{0: 0x11, 5: 0x22, 10: 0x33, 15: 0x44}, bm: ReasonTooManyErrors, euclid: ReasonRootCountMismatch},
+		{name: "誤り5個", errs: map[int]int{1: 0x01, 7: 0x02, 13: 0x03, 19: 0x04, 25: 0x05}, bm: ReasonTooManyErrors, euclid: ReasonRootCountMismatch},
+		{name: "誤り8個", errs: map[int]int{0: 1, 3: 2, 6: 3, 9: 4, 12: 5, 15: 6, 18: 7, 21: 8}, bm: ReasonTooManyErrors, euclid: ReasonRootCountMismatch},
+		{name: "誤り2個と消失4個", errs: map[int]int{0: 0x55, 1: 0xAA, 10: 1, 11: 2, 12: 3, 13: 4}, erasures: []int{10, 11, 12, 13}, bm: ReasonTooManyErrors, euclid: ReasonRootCountMismatch},
+		{name: "誤り1個と消失6個", errs: map[int]int{25: 0x80, 0: 1, 1: 2, 2: 3, 3: 4, 4: 5, 5: 6}, erasures: []int{0, 1, 2, 3, 4, 5}, bm: ReasonTooManyErrors, euclid: ReasonResidualSyndrome},
+	}
+	for _, tt := range tests {
+		for _, method := range []struct {
+			name   string
+			method Method
+			reason FailureReason
+		}{{"berlekamp-massey", BerlekampMassey, tt.bm}, {"euclid", Euclid, tt.euclid}} {
+			t.Run(method.name+"/"+tt.name, func(t *testing.T) {
+				_, err := c.DecodeWith(corrupt(codeword, tt.errs), testParity, DecodeOptions{Method: method.method, Erasures: tt.erasures})
+				var failure *DecodeError
+				if !errors.As(err, &failure) || !errors.Is(err, ErrTooManyErrors) {
+					t.Fatalf("訂正能力を超えた誤りで %v が返りました", err)
+				}
+				if failure.Reason != method.reason || failure.Erasures != len(tt.erasures) {
+					t.Fatalf("%+v, want Reason %s", failure, method.reason)
+				}
+				switch failure.Reason {
+				case ReasonTooManyErrors:
+					if 2*failure.Errors+failure.Erasures <= testParity || failure.LocatorDegree != failure.Errors+failure.Erasures {
+						t.Errorf("誤り %d個, 消失 %d個, 次数 %d", failure.Errors, failure.Erasures, failure.LocatorDegree)
+					}
+				case ReasonRootCountMismatch:
+					if failure.RootCount == failure.LocatorDegree {
+						t.Errorf("根の数 %d, 次数 %d", failure.RootCount, failure.LocatorDegree)
+					}
+				case ReasonResidualSyndrome:
+					if allZero(failure.ResidualSyndromes) {
+						t.Error("訂正後のシンドロームが0です")
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package rs は GF(2^8) 上のリード・ソロモン符号の符号化と復号を提供する.
+//
+// QRコードの符号は生成多項式の最初の根が α^0 の組織符号で, 型番1-L では
+// 19バイトのデータに7バイトの誤り訂正コード語を付ける. ここでは任意の長さ
+// (符号長255バイトまで) と最初の根 α^b を扱う.
+package rs
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/gf"
+)
+
+// MaxCodeLength は GF(2^8) 上の RS 符号の符号長の上限
+const MaxCodeLength = 255
+
+// ErrTooManyErrors は誤りが訂正能力を超えていて復号できないことを表す
+var ErrTooManyErrors = errors.New("誤りが多すぎるため訂正できません")
+
+// Codec は1つの体と生成多項式の最初の根で符号化・復号するための表をまとめたもの.
+// 作った後は複数のゴルーチンから同時に使える.
+type Codec struct {
+	field     *gf.Field
+	firstRoot int
+	mul       [256][256]byte // mul[c][v] = c·v (定数倍をまとめて計算するための表)
+
+	mu         sync.Mutex
+	generators map[int][]int // 求めておいた生成多項式 (次数ごと)
+}
+
+// NewCodec は体 field (GF(2^8)) 上で, 生成多項式の根が α^firstRoot から始まる RS 符号の Codec を作る
+func NewCodec(field *gf.Field, firstRoot int) (*Codec, error) {
+	if field.M() != 8 {
+		return nil, fmt.Errorf("RS 符号は GF(2^8) の体で作ってください: GF(2^%d)", field.M())
+	}
+	if firstRoot < 0 || firstRoot > 254 {
+		return nil, fmt.Errorf("最初の根の指数 b は0〜254で指定してください: %d", firstRoot)
+	}
+	c := &Codec{field: field, firstRoot: firstRoot, generators: map[int][]int{}}
+	for a := 0; a < 256; a++ {
+		for v := 0; v < 256; v++ {
+			c.mul[a][v] = byte(field.Mul(a, v))
+		}
+	}
+	return c, nil
+}
+
+// QR は QRコードの RS 符号 (原始多項式 0x11D, 最初の根 α^0) の Codec
+var QR = mustNewCodec(gf.MustNew(8, gf.QRPolynomial), 0)
+
+func mustNewCodec(field *gf.Field, firstRoot int) *Codec {
+	c, err := NewCodec(field, firstRoot)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Field は符号の体を返す
+func (c *Codec) Field() *gf.Field { return c.field }
+
+// FirstRoot は生成多項式の最初の根 α^b の指数 b を返す
+func (c *Codec) FirstRoot() int { return c.firstRoot }
+
+// Generator は nParity 次の生成多項式を返す. 一度求めたものは次から使い回すので, 返す配列は書き換えないこと.
+func (c *Codec) Generator(nParity int) []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if g, ok := c.generators[nParity]; ok {
+		return g
+	}
+	g := c.field.Generator(nParity, c.firstRoot)
+	c.generators[nParity] = g
+	return g
+}
+
+// PolyMul は2つの多項式の積を返す (定数倍の表を使う)
+func (c *Codec) PolyMul(p1, p2 []int) []int {
+	result := make([]int, len(p1)+len(p2)-1)
+	for i, a := range p1 {
+		row := &c.mul[a]
+		for j, b := range p2 {
+			result[i+j] ^= int(row[b])
+		}
+	}
+	return result
+}
+
+// ScaleInto は p の各係数に定数 k を掛けた値を dst に書き込む.
+// 定数 k の表を引くだけなので, 対数を経由する体の乗算より速く, 割り当てもしない.
+func (c *Codec) ScaleInto(dst, p []int, k int) {
+	row := &c.mul[k]
+	for i, coeff := range p {
+		dst[i] = int(row[coeff])
+	}
+}
+
+// DivInPlace は buf に入れた被除数を除数 (最高次係数は1) で割り, 剰余を buf の末尾の部分スライスとして返す.
+// buf は書き換えられる. 割り当てをしないので, 呼び出し側で buf を使い回せば多数の符号化を速く行える.
+// 除数が空か最高次係数が1でない, 被除数が除数より短い, または係数が0〜255でない場合はエラーを返す (buf は書き換えない).
+func (c *Codec) DivInPlace(buf []int, divisor []int) ([]int, error) {
+	divLen := len(divisor)
+	resLen := len(buf)
+	if divLen == 0 || divisor[0] != 1 {
+		return nil, errors.New("除数は最高次係数が1の多項式で指定してください.")
+	}
+	if resLen < divLen {
+		return nil, fmt.Errorf("被除数 (%d項) が除数 (%d項) より短くなっています.", resLen, divLen)
+	}
+	for _, p := range [][]int{buf, divisor} {
+		for _, coeff := range p {
+			if coeff < 0 || coeff > 255 {
+				return nil, &gf.NotElementError{M: 8, Value: coeff}
+			}
+		}
+	}
+	for i := 0; i <= resLen-divLen; i++ {
+		coeff := buf[i]
+		if coeff == 0 {
+			continue
+		}
+		// 生成多項式の最高次係数は常に1なので, 逆元の計算は不要
+		row := &c.mul[coeff]
+		for j := 0; j < divLen; j++ {
+			buf[i+j] ^= int(row[divisor[j]])
+		}
+	}
+	return buf[resLen-divLen+1:], nil
+}
+
+// checkLength はデータ長と誤り訂正コード語の数が GF(2^8) 上の RS 符号として成り立つか確かめる
+func checkLength(dataLength, nParity int) error {
+	if dataLength < 1 {
+		return errors.New("データを1バイト以上指定してください.")
+	}
+	if nParity < 1 {
+		return fmt.Errorf("誤り訂正コード語の数は1以上で指定してください: %d", nParity)
+	}
+	if dataLength+nParity > MaxCodeLength {
+		return fmt.Errorf("符号長 (データ%dバイト + 誤り訂正%dバイト) が%dを超えています.", dataLength, nParity, MaxCodeLength)
+	}
+	return nil
+}
+
+// Encode はデータに nParity 個の誤り訂正コード語を付けた符号語 (組織符号) を返す.
+// 誤り訂正コード語は I(x)·x^nParity を生成多項式で割った余り.
+func (c *Codec) Encode(data []byte, nParity int) ([]byte, error) {
+	if err := checkLength(len(data), nParity); err != nil {
+		return nil, err
+	}
+	buf := make([]int, len(data)+nParity)
+	for i, b := range data {
+		buf[i] = int(b)
+	}
+	remainder, err := c.DivInPlace(buf, c.Generator(nParity))
+	if err != nil {
+		return nil, err
+	}
+	codeword := make([]byte, len(data)+nParity)
+	copy(codeword, data)
+	for i, r := range remainder {
+		codeword[len(data)+i] = byte(r)
+	}
+	return codeword, nil
+}
+
+// EncodeNonSystematic はデータの多項式に生成多項式を掛けた符号語 (非組織符号) を返す.
+// 符号語にデータがそのままの形では現れないので, 復号後は c(x) ÷ g(x) でデータを取り出す.
+func (c *Codec) EncodeNonSystematic(data []byte, nParity int) ([]byte, error) {
+	if err := checkLength(len(data), nParity); err != nil {
+		return nil, err
+	}
+	p := make([]int, len(data))
+	for i, b := range data {
+		p[i] = int(b)
+	}
+	product := c.PolyMul(p, c.Generator(nParity))
+	codeword := make([]byte, len(product))
+	for i, v := range product {
+		codeword[i] = byte(v)
+	}
+	return codeword, nil
+}
+
+// Syndromes は受信語 r(x) (先頭のバイトが最高次の係数) に α^b〜α^(b+count-1) を代入した値を返す
+func (c *Codec) Syndromes(received []byte, count int) []int {
+	syndromes := make([]int, count)
+	for i := range syndromes {
+		x := c.field.Exp(c.firstRoot + i)
+		v := 0
+		for _, r := range received {
+			v = int(c.mul[v][x]) ^ int(r)
+		}
+		syndromes[i] = v
+	}
+	return syndromes
+}
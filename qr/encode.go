@@ -0,0 +1,136 @@
+package qr
+
+import (
+	"fmt"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/kanji"
+)
+
+// ECCLevel は誤り訂正レベル
+type ECCLevel int
+
+// 誤り訂正レベル (現在は L だけに対応する)
+const (
+	L ECCLevel = iota // 約7%の誤りを訂正できる
+	M                 // 約15%
+	Q                 // 約25%
+	H                 // 約30%
+)
+
+func (l ECCLevel) String() string {
+	if l < L || l > H {
+		return fmt.Sprintf("ECCLevel(%d)", int(l))
+	}
+	return "LMQH"[l : l+1]
+}
+
+// Char は漢字モードで圧縮した1文字 (Shift JIS のコード, 引いた値, 13ビットの値)
+type Char = kanji.Char
+
+// Option は Encode の設定を変える
+type Option func(*config)
+
+type config struct {
+	version     int
+	level       ECCLevel
+	maskPattern int
+	segmenter   Segmenter
+}
+
+// WithVersion は型番を指定する (現在は1だけ. 既定は1)
+func WithVersion(version int) Option {
+	return func(c *config) { c.version = version }
+}
+
+// WithECCLevel は誤り訂正レベルを指定する (現在は L だけ. 既定は L)
+func WithECCLevel(level ECCLevel) Option {
+	return func(c *config) { c.level = level }
+}
+
+// WithMask はマスクパターン参照子 (0〜7) を指定する (既定は0)
+func WithMask(pattern int) Option {
+	return func(c *config) { c.maskPattern = pattern }
+}
+
+// WithSegmenter は入力をモードごとの区間に分ける方法を指定する (既定は DefaultSegmenter)
+func WithSegmenter(segmenter Segmenter) Option {
+	return func(c *config) { c.segmenter = segmenter }
+}
+
+// Result は Encode の結果. 途中の各段階の値も含む.
+type Result struct {
+	Text              string
+	Version           int
+	ECCLevel          ECCLevel
+	MaskPattern       int
+	Chars             []Char     // 漢字モードの各文字の圧縮の過程
+	Units             []Unit     // 各区間を符号化した単位 (すべてのモード)
+	Stream            DataStream // データコード語を組み立てる各段階のビット列
+	DataCodewords     []byte     // 19バイト
+	ECCCodewords      []byte     // 7バイト
+	Codeword          []byte     // マスクを掛ける前の26バイト
+	MaskBytes         []byte     // 符号語に重ねたマスクパターン
+	MaskedCodeword    []byte     // マスクを掛けた26バイト
+	FormatInformation int        // マスクを掛けた15ビットの形式情報
+	Matrix            *Matrix    // 符号語と形式情報を配置し, マスクを掛けたシンボル
+}
+
+// Encode は文字列を符号化し (既定は漢字モード), マスクを掛けたシンボルまで求める
+func Encode(text string, options ...Option) (Result, error) {
+	c := config{version: 1, level: L, segmenter: DefaultSegmenter}
+	for _, option := range options {
+		option(&c)
+	}
+	if c.version != 1 {
+		return Result{}, fmt.Errorf("型番は現在1だけに対応しています: %d", c.version)
+	}
+	if c.level != L {
+		return Result{}, fmt.Errorf("誤り訂正レベルは現在 L だけに対応しています: %s", c.level)
+	}
+	if err := checkMaskPattern(c.maskPattern); err != nil {
+		return Result{}, err
+	}
+
+	segments, err := c.segmenter.Segment(text)
+	if err != nil {
+		return Result{}, err
+	}
+	stream, units, err := AssembleSegments(segments)
+	if err != nil {
+		return Result{}, err
+	}
+	var chars []Char
+	for _, u := range units {
+		if ch, ok := u.Detail.(Char); ok {
+			chars = append(chars, ch)
+		}
+	}
+	codeword, err := Codeword(stream.Bytes)
+	if err != nil {
+		return Result{}, err
+	}
+	maskBytes, err := MaskBytes(c.maskPattern)
+	if err != nil {
+		return Result{}, err
+	}
+	symbol, err := Symbol(codeword, c.maskPattern)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Text:              text,
+		Version:           c.version,
+		ECCLevel:          c.level,
+		MaskPattern:       c.maskPattern,
+		Chars:             chars,
+		Units:             units,
+		Stream:            stream,
+		DataCodewords:     codeword[:DataCodewords],
+		ECCCodewords:      codeword[DataCodewords:],
+		Codeword:          codeword,
+		MaskBytes:         maskBytes,
+		MaskedCodeword:    Mask(codeword, maskBytes),
+		FormatInformation: FormatInformation(ECCLevelL, c.maskPattern),
+		Matrix:            symbol,
+	}, nil
+}
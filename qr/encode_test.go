@@ -0,0 +1,109 @@
+package qr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// 決まった入力から決まった符号語と形式情報が得られることと, Option の既定値を確かめる.
+// 期待する符号語は生成多項式 g(x) = (x-α^0)…(x-α^6) での割り算を別に計算して求めた値.
+
+// mustHex は空白で区切った16進数をバイト列にする
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name              string
+		text              string
+		options           []Option
+		codeword          string // マスクを掛ける前の26バイト
+		maskedCodeword    string
+		maskPattern       int
+		formatInformation int
+	}{
+		{
+			name:              "既定値",
+			text:              "漢字",
+			codeword:          "80 23 9F A8 68 00 EC 11 EC 11 EC 11 EC 11 EC 11 EC 11 EC B0 F5 2C 21 C8 D0 40",
+			maskedCodeword:    "19 BA 06 CE 0E 66 75 88 75 77 8A 77 75 88 75 87 8A 88 7A D6 93 4A B8 51 B6 D9",
+			formatInformation: 0b111011111000100,
+		},
+		{
+			name:              "すべて指定",
+			text:              "漢字",
+			options:           []Option{WithVersion(1), WithECCLevel(L), WithMask(0), WithSegmenter(DefaultSegmenter)},
+			codeword:          "80 23 9F A8 68 00 EC 11 EC 11 EC 11 EC 11 EC 11 EC 11 EC B0 F5 2C 21 C8 D0 40",
+			maskedCodeword:    "19 BA 06 CE 0E 66 75 88 75 77 8A 77 75 88 75 87 8A 88 7A D6 93 4A B8 51 B6 D9",
+			formatInformation: 0b111011111000100,
+		},
+		{
+			name:              "マスク5",
+			text:              "漢字",
+			options:           []Option{WithMask(5)},
+			codeword:          "80 23 9F A8 68 00 EC 11 EC 11 EC 11 EC 11 EC 11 EC 11 EC B0 F5 2C 21 C8 D0 40",
+			maskedCodeword:    "8C 03 5D 03 C2 BA A0 75 2A 92 E4 21 42 BB 06 BB 47 D7 AD 23 EC 1D E3 8B 19 17",
+			maskPattern:       5,
+			formatInformation: 0b110001100011000,
+		},
+		{
+			name:              "1文字",
+			text:              "亜",
+			codeword:          "80 12 CF 80 EC 11 EC 11 EC 11 EC 11 EC 11 EC 11 EC 11 EC 6A F6 AB E4 5B 92 D6",
+			maskedCodeword:    "19 8B 56 E6 8A 77 75 88 75 77 8A 77 75 88 75 87 8A 88 7A 0C 90 CD 7D C2 F4 4F",
+			formatInformation: 0b111011111000100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Encode(tt.text, tt.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Text != tt.text || r.Version != 1 || r.ECCLevel != L || r.MaskPattern != tt.maskPattern {
+				t.Errorf("Text %q, 型番 %d, レベル %s, マスク %d", r.Text, r.Version, r.ECCLevel, r.MaskPattern)
+			}
+			codeword := mustHex(t, tt.codeword)
+			if !bytes.Equal(r.Codeword, codeword) {
+				t.Errorf("Codeword = % X, want % X", r.Codeword, codeword)
+			}
+			if !bytes.Equal(r.DataCodewords, codeword[:DataCodewords]) || !bytes.Equal(r.ECCCodewords, codeword[DataCodewords:]) {
+				t.Errorf("DataCodewords = % X, ECCCodewords = % X", r.DataCodewords, r.ECCCodewords)
+			}
+			if masked := mustHex(t, tt.maskedCodeword); !bytes.Equal(r.MaskedCodeword, masked) {
+				t.Errorf("MaskedCodeword = % X, want % X", r.MaskedCodeword, masked)
+			}
+			if r.FormatInformation != tt.formatInformation {
+				t.Errorf("FormatInformation = %015b, want %015b", r.FormatInformation, tt.formatInformation)
+			}
+			if len(r.Chars) != len([]rune(tt.text)) || r.Matrix == nil {
+				t.Errorf("%d文字, Matrix = %v", len(r.Chars), r.Matrix)
+			}
+		})
+	}
+}
+
+func TestEncodeUnsupportedOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		option Option
+	}{
+		{"型番2", WithVersion(2)},
+		{"レベルM", WithECCLevel(M)},
+		{"マスク-1", WithMask(-1)},
+		{"マスク8", WithMask(8)},
+	}
+	for _, tt := range tests {
+		if _, err := Encode("漢字", tt.option); err == nil {
+			t.Errorf("%s: 対応していない設定でエラーになりません", tt.name)
+		}
+	}
+}
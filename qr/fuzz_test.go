@@ -0,0 +1,57 @@
+package qr
+
+import (
+	"bytes"
+	"testing"
+)
+
+// 公開している読み取りの入口に任意の入力を与えても panic せず, 読めたものは符号化と往復することを確かめる.
+// go test -fuzz=FuzzParseKanji ./qr のように1つずつ実行する.
+
+func FuzzEncode(f *testing.F) {
+	for _, seed := range []string{"漢字", "亜", "点茗", "", "kanji", "漢字漢字漢字漢字漢字", "\xff\xfe"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		r, err := Encode(text)
+		if err != nil {
+			return
+		}
+		if len(r.DataCodewords) != DataCodewords || len(r.MaskedCodeword) != TotalCodewords {
+			t.Fatalf("Encode(%q): %dバイトのデータコード語, %dバイトの符号語", text, len(r.DataCodewords), len(r.MaskedCodeword))
+		}
+		parsed, _, err := ParseKanji(r.DataCodewords)
+		if err != nil {
+			t.Fatalf("ParseKanji(Encode(%q)): %v", text, err)
+		}
+		if parsed != text {
+			t.Fatalf("ParseKanji(Encode(%q)) = %q", text, parsed)
+		}
+	})
+}
+
+func FuzzParseKanji(f *testing.F) {
+	for _, text := range []string{"漢字", "亜", "漢字漢字漢字漢字漢"} {
+		r, err := Encode(text)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(r.DataCodewords)
+	}
+	f.Add(make([]byte, DataCodewords))
+	f.Add([]byte{0x80})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		text, _, err := ParseKanji(data)
+		if err != nil {
+			return
+		}
+		// 読めたデータコード語は終端パターンと埋め草コード語まで正しいので, 符号化し直すと同じバイト列になる
+		r, err := Encode(text)
+		if err != nil {
+			t.Fatalf("ParseKanji(% X) = %q は符号化できません: %v", data, text, err)
+		}
+		if !bytes.Equal(r.DataCodewords, data) {
+			t.Fatalf("ParseKanji(% X) = %q を符号化すると % X", data, text, r.DataCodewords)
+		}
+	})
+}
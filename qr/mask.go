@@ -0,0 +1,66 @@
+package qr
+
+import "fmt"
+
+// --- マスクパターン ---
+
+// MaskConditions はパターン番号(参照子)ごとのマスク条件 (i:行, j:列). trueのモジュールを反転する.
+var MaskConditions = [8]func(i, j int) bool{
+	func(i, j int) bool { return (i+j)%2 == 0 },
+	func(i, j int) bool { return i%2 == 0 },
+	func(i, j int) bool { return j%3 == 0 },
+	func(i, j int) bool { return (i+j)%3 == 0 },
+	func(i, j int) bool { return (i/2+j/3)%2 == 0 },
+	func(i, j int) bool { return (i*j)%2+(i*j)%3 == 0 },
+	func(i, j int) bool { return ((i*j)%2+(i*j)%3)%2 == 0 },
+	func(i, j int) bool { return ((i+j)%2+(i*j)%3)%2 == 0 },
+}
+
+// MaskConditionTexts は表示用の条件式
+var MaskConditionTexts = [8]string{
+	"(i + j) mod 2 = 0",
+	"i mod 2 = 0",
+	"j mod 3 = 0",
+	"(i + j) mod 3 = 0",
+	"((i div 2) + (j div 3)) mod 2 = 0",
+	"(i j) mod 2 + (i j) mod 3 = 0",
+	"((i j) mod 2 + (i j) mod 3) mod 2 = 0",
+	"((i + j) mod 2 + (i j) mod 3) mod 2 = 0",
+}
+
+// checkMaskPattern はマスクパターン参照子が0〜7であることを確かめる
+func checkMaskPattern(pattern int) error {
+	if pattern < 0 || pattern >= len(MaskConditions) {
+		return fmt.Errorf("マスクパターン参照子は0〜%dで指定してください: %d", len(MaskConditions)-1, pattern)
+	}
+	return nil
+}
+
+// MaskBytes は符号語の配置順にマスク条件を評価した, バイト単位のマスク (26バイト) を返す.
+// pattern が0〜7でなければエラーを返す.
+func MaskBytes(pattern int) ([]byte, error) {
+	if err := checkMaskPattern(pattern); err != nil {
+		return nil, err
+	}
+	condition := MaskConditions[pattern]
+	positions := DataPositions()
+	maskBytes := make([]byte, len(positions)/8)
+	for i := range maskBytes {
+		for bit := 0; bit < 8; bit++ {
+			pos := positions[i*8+bit]
+			if condition(pos.Row, pos.Col) {
+				maskBytes[i] |= 0x80 >> bit
+			}
+		}
+	}
+	return maskBytes, nil
+}
+
+// Mask は26バイトの符号語にバイト単位のマスク maskBytes を掛けた結果を返す
+func Mask(codeword, maskBytes []byte) []byte {
+	masked := make([]byte, len(codeword))
+	for i := range codeword {
+		masked[i] = codeword[i] ^ maskBytes[i%len(maskBytes)]
+	}
+	return masked
+}
@@ -0,0 +1,256 @@
+package qr
+
+import "fmt"
+
+// --- シンボル行列 (型番1) ---
+
+// 行列の各モジュールの値
+const (
+	ModuleLight    = 0 // 明モジュール
+	ModuleDark     = 1 // 暗モジュール
+	ModuleReserved = 2 // 未確定 (形式情報やデータのために予約されている)
+)
+
+// 行列の各モジュールの領域 (Matrix.Region の値)
+const (
+	RegionQuietZone  = 0 // クワイエットゾーン
+	RegionData       = 1 // データコード語
+	RegionECC        = 2 // 誤り訂正コード語
+	RegionFinder     = 3 // 位置検出パターン
+	RegionSeparator  = 4 // 分離パターン
+	RegionTiming     = 5 // タイミングパターン
+	RegionDarkModule = 6 // 暗モジュール
+	RegionFormat     = 7 // 形式情報
+	RegionVersion    = 8 // 型番情報 (型番7以上のみ. 型番1には存在しない)
+)
+
+// RegionNames は Region の値に対応する領域名
+var RegionNames = []string{"quiet", "data", "ecc", "finder", "separator", "timing", "dark", "format", "version"}
+
+// Position は行列上のモジュールの位置
+type Position struct {
+	Row, Col int
+}
+
+// Matrix は組み立て途中のシンボル
+type Matrix struct {
+	Size    int
+	Modules [][]int // 各モジュールの値 (ModuleLight, ModuleDark, ModuleReserved)
+	Region  [][]int // 各モジュールの領域 (RegionData, RegionFinder, ...)
+}
+
+// NewMatrix は全てのモジュールが未確定の size × size の行列を作る
+func NewMatrix(size int) *Matrix {
+	m := &Matrix{Size: size}
+	m.Modules = make([][]int, size)
+	m.Region = make([][]int, size)
+	for r := 0; r < size; r++ {
+		m.Modules[r] = make([]int, size)
+		m.Region[r] = make([]int, size)
+		for c := 0; c < size; c++ {
+			m.Modules[r][c] = ModuleReserved
+			m.Region[r][c] = RegionData
+		}
+	}
+	return m
+}
+
+// SetFunction は機能パターンのモジュールを1つ置く
+func (m *Matrix) SetFunction(r, c, value, region int) {
+	m.Modules[r][c] = value
+	m.Region[r][c] = region
+}
+
+// IsFunction は機能パターン(形式情報・型番情報の領域を含む)のモジュールならtrueを返す
+func (m *Matrix) IsFunction(r, c int) bool {
+	return m.Region[r][c] != RegionData && m.Region[r][c] != RegionECC
+}
+
+// Snapshot は現在の行列のコピーを返す
+func (m *Matrix) Snapshot() [][]int {
+	return CopyModules(m.Modules)
+}
+
+// CopyModules は行列のコピーを返す
+func CopyModules(modules [][]int) [][]int {
+	result := make([][]int, len(modules))
+	for r := range modules {
+		result[r] = make([]int, len(modules[r]))
+		copy(result[r], modules[r])
+	}
+	return result
+}
+
+// PlaceFunctionPatterns は位置検出パターン, 分離パターン, タイミングパターン, 暗モジュールを配置し, 形式情報の領域を予約する
+func (m *Matrix) PlaceFunctionPatterns() {
+	m.placeFinderPattern(0, 0)
+	m.placeFinderPattern(0, m.Size-7)
+	m.placeFinderPattern(m.Size-7, 0)
+
+	// タイミングパターン
+	for i := 8; i < m.Size-8; i++ {
+		value := ModuleLight
+		if i%2 == 0 {
+			value = ModuleDark
+		}
+		m.SetFunction(6, i, value, RegionTiming)
+		m.SetFunction(i, 6, value, RegionTiming)
+	}
+
+	// 形式情報の領域 (値は未確定のまま)
+	first, second := FormatInformationPositions(m.Size)
+	for i := 0; i < 15; i++ {
+		m.Region[first[i].Row][first[i].Col] = RegionFormat
+		m.Region[second[i].Row][second[i].Col] = RegionFormat
+	}
+
+	// 暗モジュール
+	m.SetFunction(m.Size-8, 8, ModuleDark, RegionDarkModule)
+}
+
+// placeFinderPattern は (row, col) を左上とする位置検出パターンと, その周囲の分離パターンを配置する
+func (m *Matrix) placeFinderPattern(row, col int) {
+	for dr := -1; dr <= 7; dr++ {
+		for dc := -1; dc <= 7; dc++ {
+			r, c := row+dr, col+dc
+			if r < 0 || r >= m.Size || c < 0 || c >= m.Size {
+				continue
+			}
+			if dr < 0 || dr > 6 || dc < 0 || dc > 6 {
+				m.SetFunction(r, c, ModuleLight, RegionSeparator)
+				continue
+			}
+			value := ModuleLight
+			if dr == 0 || dr == 6 || dc == 0 || dc == 6 || (dr >= 2 && dr <= 4 && dc >= 2 && dc <= 4) {
+				value = ModuleDark
+			}
+			m.SetFunction(r, c, value, RegionFinder)
+		}
+	}
+}
+
+// DataModulePositions は機能パターン以外のモジュールを, 右下から2列ずつ上下に往復する配置順で返す
+func (m *Matrix) DataModulePositions() []Position {
+	var positions []Position
+	for right := m.Size - 1; right >= 1; right -= 2 {
+		if right == 6 { // 縦のタイミングパターンの列は飛ばす
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < m.Size; vert++ {
+			r := vert
+			if upward {
+				r = m.Size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				c := right - j
+				if !m.IsFunction(r, c) {
+					positions = append(positions, Position{r, c})
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// PlaceCodewords は符号語を配置順に並べる (各バイトは上位ビットから)
+func (m *Matrix) PlaceCodewords(codewordBytes []byte) {
+	for bitIndex, pos := range m.DataModulePositions() {
+		value := ModuleLight // 剰余ビットは0
+		if bitIndex < len(codewordBytes)*8 {
+			value = int(codewordBytes[bitIndex/8]>>(7-bitIndex%8)) & 1
+		}
+		m.Modules[pos.Row][pos.Col] = value
+	}
+}
+
+// ApplyMask は機能パターン以外のモジュールにマスクを適用する
+func (m *Matrix) ApplyMask(condition func(i, j int) bool) {
+	for r := 0; r < m.Size; r++ {
+		for c := 0; c < m.Size; c++ {
+			if !m.IsFunction(r, c) && condition(r, c) {
+				m.Modules[r][c] ^= 1
+			}
+		}
+	}
+}
+
+// FormatInformation は誤り訂正レベルとマスクパターン参照子から15ビットの形式情報を計算する
+func FormatInformation(eccBits, mask int) int {
+	data := eccBits<<3 | mask
+	// BCH(15,5)符号: 生成多項式 G(x) = x^10 + x^8 + x^5 + x^4 + x^2 + x + 1 (0x537)
+	remainder := data << 10
+	for i := 14; i >= 10; i-- {
+		if (remainder>>i)&1 != 0 {
+			remainder ^= 0x537 << (i - 10)
+		}
+	}
+	return (data<<10 | remainder) ^ FormatMask
+}
+
+// FormatInformationPositions は形式情報の各ビット(添字0が最下位)を配置する2か所の位置を返す
+func FormatInformationPositions(size int) (first, second [15]Position) {
+	// 左上の位置検出パターンの周囲
+	for i := 0; i <= 5; i++ {
+		first[i] = Position{i, 8}
+	}
+	first[6] = Position{7, 8}
+	first[7] = Position{8, 8}
+	first[8] = Position{8, 7}
+	for i := 9; i < 15; i++ {
+		first[i] = Position{8, 14 - i}
+	}
+
+	// 右上と左下の位置検出パターンの周囲
+	for i := 0; i < 8; i++ {
+		second[i] = Position{8, size - 1 - i}
+	}
+	for i := 8; i < 15; i++ {
+		second[i] = Position{size - 15 + i, 8}
+	}
+	return first, second
+}
+
+// PlaceFormatInformation は形式情報を2か所に配置する (bit14が最上位)
+func (m *Matrix) PlaceFormatInformation(bits int) {
+	first, second := FormatInformationPositions(m.Size)
+	for i := 0; i < 15; i++ {
+		bit := (bits >> i) & 1
+		m.SetFunction(first[i].Row, first[i].Col, bit, RegionFormat)
+		m.SetFunction(second[i].Row, second[i].Col, bit, RegionFormat)
+	}
+}
+
+// MarkECCRegion は先頭 dataCodewords 個より後の符号語が配置されたモジュールを誤り訂正コード語の領域とする
+func (m *Matrix) MarkECCRegion(dataCodewords int) {
+	for bitIndex, pos := range m.DataModulePositions() {
+		if bitIndex >= dataCodewords*8 {
+			m.Region[pos.Row][pos.Col] = RegionECC
+		}
+	}
+}
+
+// Symbol は符号語を配置し, マスクパターン pattern とその形式情報 (誤り訂正レベルL) を適用した行列を組み立てる.
+// 符号語が26バイトでないか pattern が0〜7でなければエラーを返す.
+func Symbol(codewordBytes []byte, pattern int) (*Matrix, error) {
+	if len(codewordBytes) != TotalCodewords {
+		return nil, fmt.Errorf("符号語は%dバイトである必要がありますが, %dバイトでした.", TotalCodewords, len(codewordBytes))
+	}
+	if err := checkMaskPattern(pattern); err != nil {
+		return nil, err
+	}
+	m := NewMatrix(Size)
+	m.PlaceFunctionPatterns()
+	m.PlaceCodewords(codewordBytes)
+	m.MarkECCRegion(DataCodewords)
+	m.ApplyMask(MaskConditions[pattern])
+	m.PlaceFormatInformation(FormatInformation(ECCLevelL, pattern))
+	return m, nil
+}
+
+// DataPositions は型番1のシンボルにおけるデータモジュールの配置順
+func DataPositions() []Position {
+	m := NewMatrix(Size)
+	m.PlaceFunctionPatterns()
+	return m.DataModulePositions()
+}
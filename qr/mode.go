@@ -0,0 +1,198 @@
+package qr
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/kanji"
+)
+
+// --- 符号化モード ---
+
+// 新しいモード (数字, 英数字, 8ビットバイト, ECI, 中国漢字など) は ModeEncoder を実装して RegisterMode で
+// 登録する. データコード語の組み立て (AssembleSegments) はモードの中身を知らず, モード指示子, 文字数指示子と
+// 各単位のビット列をつなげるだけなので, モードを加えても組み立ての処理は変わらない.
+
+// ModeEncoder は1つの符号化モード
+type ModeEncoder interface {
+	Name() string                  // モードの名前 ("kanji" など). RegisterMode と LookupMode で使う.
+	Indicator() string             // 4ビットのモード指示子
+	CharCountBits(version int) int // 型番 version での文字数指示子のビット数 (0なら文字数指示子を付けない)
+	CharCount(text string) int     // 文字数指示子に書く文字数 (8ビットバイトモードならバイト数)
+	Encode(text string) ([]Unit, error)
+}
+
+// Unit は符号化の1単位 (漢字モードなら1文字, 数字モードなら最大3桁) とそのビット列
+type Unit struct {
+	Text   string      // 元の文字
+	Value  int         // 符号化した値
+	Bits   int         // 値のビット数
+	Detail interface{} // モードごとの途中の値 (漢字モードなら Char)
+}
+
+// Segment は1つのモードで符号化する入力の区間
+type Segment struct {
+	Mode ModeEncoder
+	Text string
+}
+
+// Segmenter は入力をモードごとの区間に分ける
+type Segmenter interface {
+	Segment(text string) ([]Segment, error)
+}
+
+// SingleMode は入力全体を1つのモードで符号化する Segmenter
+type SingleMode struct {
+	Mode ModeEncoder
+}
+
+// Segment は text 全体を1つの区間にする
+func (s SingleMode) Segment(text string) ([]Segment, error) {
+	if s.Mode == nil {
+		return nil, fmt.Errorf("モードが指定されていません")
+	}
+	return []Segment{{Mode: s.Mode, Text: text}}, nil
+}
+
+// KanjiMode は漢字モード (Shift JIS の2バイトを13ビットにする)
+var KanjiMode ModeEncoder = kanjiMode{}
+
+// DefaultSegmenter は Encode が既定で使う Segmenter (入力全体を漢字モードにする)
+var DefaultSegmenter Segmenter = SingleMode{Mode: KanjiMode}
+
+type kanjiMode struct{}
+
+func (kanjiMode) Name() string      { return "kanji" }
+func (kanjiMode) Indicator() string { return kanji.ModeIndicator }
+
+// CharCountBits は型番1〜9で8ビット, 10〜26で10ビット, 27〜40で12ビット
+func (kanjiMode) CharCountBits(version int) int {
+	switch {
+	case version <= 9:
+		return CharCountBits
+	case version <= 26:
+		return 10
+	}
+	return 12
+}
+
+func (kanjiMode) CharCount(text string) int { return utf8.RuneCountInString(text) }
+
+// Encode は各文字を13ビットに圧縮する. 範囲外の文字は *kanji.RangeError を返す.
+// 型番1の文字数指示子で表せても, 漢字モードの文字数は MaxKanjiChars まで (ParseKanji と同じ) とする.
+func (kanjiMode) Encode(text string) ([]Unit, error) {
+	if n := utf8.RuneCountInString(text); n > MaxKanjiChars {
+		return nil, fmt.Errorf("文字数は1〜%dで指定してください: %d", MaxKanjiChars, n)
+	}
+	chars, err := kanji.Compress(text)
+	if err != nil {
+		return nil, err
+	}
+	return kanjiUnits(chars), nil
+}
+
+// kanjiUnits は漢字モードで圧縮した文字を Unit にする
+func kanjiUnits(chars []kanji.Char) []Unit {
+	units := make([]Unit, len(chars))
+	for i, c := range chars {
+		units[i] = Unit{Text: string(c.Rune), Value: int(c.Value), Bits: kanji.Bits, Detail: c}
+	}
+	return units
+}
+
+// --- モードの登録 ---
+
+var (
+	modeMu    sync.RWMutex
+	modes     = map[string]ModeEncoder{}
+	modeNames []string // 登録した順
+)
+
+func init() {
+	if err := RegisterMode(KanjiMode); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterMode はモードを名前で登録する. 同じ名前のモードが既にあればエラーを返す.
+func RegisterMode(m ModeEncoder) error {
+	if m == nil || m.Name() == "" {
+		return fmt.Errorf("モードの名前が空です")
+	}
+	if len(m.Indicator()) != 4 || strings.Trim(m.Indicator(), "01") != "" {
+		return fmt.Errorf("モード指示子は4ビットの2進数で指定してください: %s %q", m.Name(), m.Indicator())
+	}
+	modeMu.Lock()
+	defer modeMu.Unlock()
+	if _, ok := modes[m.Name()]; ok {
+		return fmt.Errorf("モード %s は既に登録されています", m.Name())
+	}
+	modes[m.Name()] = m
+	modeNames = append(modeNames, m.Name())
+	return nil
+}
+
+// LookupMode は名前で登録したモードを返す
+func LookupMode(name string) (ModeEncoder, bool) {
+	modeMu.RLock()
+	defer modeMu.RUnlock()
+	m, ok := modes[name]
+	return m, ok
+}
+
+// Modes は登録したモードの名前を登録した順に返す
+func Modes() []string {
+	modeMu.RLock()
+	defer modeMu.RUnlock()
+	return append([]string(nil), modeNames...)
+}
+
+// --- データコード語の組み立て ---
+
+// AssembleSegments は各区間をそのモードで符号化し, 型番1のデータコード語を組み立てる.
+// DataStream の ModeIndicator と CharCountIndicator は最初の区間のもので,
+// 2つ目以降の区間のモード指示子と文字数指示子は Body に含む.
+func AssembleSegments(segments []Segment) (DataStream, []Unit, error) {
+	if len(segments) == 0 {
+		return DataStream{}, nil, fmt.Errorf("符号化する区間がありません")
+	}
+	var s DataStream
+	var body strings.Builder
+	var units []Unit
+	for i, seg := range segments {
+		if seg.Mode == nil {
+			return DataStream{}, nil, fmt.Errorf("%d番目の区間のモードが指定されていません", i+1)
+		}
+		segUnits, err := seg.Mode.Encode(seg.Text)
+		if err != nil {
+			return DataStream{}, nil, err
+		}
+		count, countBits := seg.Mode.CharCount(seg.Text), seg.Mode.CharCountBits(1)
+		if count == 0 {
+			return DataStream{}, nil, fmt.Errorf("%d番目の区間 (%sモード) が空です", i+1, seg.Mode.Name())
+		}
+		if countBits > 0 && count >= 1<<countBits {
+			return DataStream{}, nil, fmt.Errorf("%sモードの文字数は%d以下で指定してください: %d", seg.Mode.Name(), 1<<countBits-1, count)
+		}
+		countIndicator := ""
+		if countBits > 0 {
+			countIndicator = fmt.Sprintf("%0*b", countBits, count)
+		}
+		if i == 0 {
+			s.ModeIndicator, s.CharCountIndicator = seg.Mode.Indicator(), countIndicator
+		} else {
+			body.WriteString(seg.Mode.Indicator() + countIndicator)
+		}
+		for _, u := range segUnits {
+			fmt.Fprintf(&body, "%0*b", u.Bits, u.Value)
+		}
+		units = append(units, segUnits...)
+	}
+	s.Body = body.String()
+	if n := len(s.ModeIndicator) + len(s.CharCountIndicator) + len(s.Body); n > DataCodewords*8 {
+		return DataStream{}, nil, fmt.Errorf("データが長すぎます. %dビット以下にしてください: %dビット", DataCodewords*8, n)
+	}
+	return terminate(s), units, nil
+}
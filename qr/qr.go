@@ -0,0 +1,144 @@
+// Package qr は型番1-L の QRコードについて, データコード語の組み立て, マスク,
+// 形式情報とシンボル行列への配置を提供する. 文字の圧縮は kanji, 誤り訂正コード語は rs を使う.
+//
+// 他の Go のプログラムからは Encode で漢字の文字列を符号化できる:
+//
+//	result, err := qr.Encode("漢字", qr.WithVersion(1), qr.WithECCLevel(qr.L), qr.WithMask(3))
+//	fmt.Printf("% X\n", result.MaskedCodeword)
+//
+// 符号化モードは ModeEncoder として RegisterMode で登録し, Segmenter で入力をモードごとの区間に分ける
+// (既定は入力全体を漢字モードにする DefaultSegmenter). 別のモードを使うには WithSegmenter を渡す.
+package qr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/kanji"
+	"github.com/mocho271828/rs_coding-compresser/internal/rs"
+)
+
+// 型番1-L の符号語の数とデータの組み立てに使う値
+const (
+	Size           = 21     // 1辺のモジュール数
+	DataCodewords  = 19     // データコード語の数
+	ECCCodewords   = 7      // 誤り訂正コード語の数
+	TotalCodewords = 26     // 符号語の総数
+	MaxKanjiChars  = 9      // 漢字モードの最大文字数
+	Terminator     = "0000" // 終端パターン
+	CharCountBits  = 8      // 漢字モードの文字数指示子のビット数
+
+	ECCLevelL  = 0x01   // 誤り訂正レベルLを示す形式情報の2ビット (L:01, M:00, Q:11, H:10)
+	FormatMask = 0x5412 // 形式情報に掛けるマスク 101010000010010
+)
+
+// PadCodewords は埋め草コード語 (交互に繰り返す). 書き換えないこと.
+var PadCodewords = []byte{0xEC, 0x11}
+
+// DataStream はデータコード語を組み立てる各段階のビット列
+type DataStream struct {
+	ModeIndicator      string // モード指示子
+	CharCountIndicator string // 文字数指示子
+	Body               string // 各単位のビット列をつなげたもの (2つ目以降の区間はモード指示子と文字数指示子も含む)
+	Terminated         string // 終端パターンまで付けたもの
+	Padded             string // バイト境界まで0を付けたもの
+	Bytes              []byte // 埋め草コード語まで付けた19バイト
+}
+
+// AssembleKanji は漢字モードで圧縮した文字からデータコード語を組み立てる
+func AssembleKanji(chars []kanji.Char) (DataStream, error) {
+	if len(chars) == 0 || len(chars) > MaxKanjiChars {
+		return DataStream{}, fmt.Errorf("文字数は1〜%dで指定してください: %d", MaxKanjiChars, len(chars))
+	}
+	var body strings.Builder
+	for _, u := range kanjiUnits(chars) {
+		fmt.Fprintf(&body, "%0*b", u.Bits, u.Value)
+	}
+	return terminate(DataStream{
+		ModeIndicator:      KanjiMode.Indicator(),
+		CharCountIndicator: fmt.Sprintf("%0*b", KanjiMode.CharCountBits(1), len(chars)),
+		Body:               body.String(),
+	}), nil
+}
+
+// terminate は s のモード指示子, 文字数指示子と Body に終端パターン, 0 と埋め草コード語を付ける
+func terminate(s DataStream) DataStream {
+	s.Terminated = s.ModeIndicator + s.CharCountIndicator + s.Body
+	if len(s.Terminated)+len(Terminator) <= DataCodewords*8 {
+		s.Terminated += Terminator
+	}
+	s.Padded = s.Terminated
+	if len(s.Padded)%8 != 0 {
+		s.Padded += strings.Repeat("0", 8-len(s.Padded)%8)
+	}
+	for i := 0; i < len(s.Padded); i += 8 {
+		var b byte
+		for _, bit := range s.Padded[i : i+8] {
+			b = b<<1 | byte(bit-'0')
+		}
+		s.Bytes = append(s.Bytes, b)
+	}
+	for i := 0; len(s.Bytes) < DataCodewords; i++ {
+		s.Bytes = append(s.Bytes, PadCodewords[i%2])
+	}
+	return s
+}
+
+// Codeword はデータコード語に誤り訂正コード語を付けた26バイトの符号語を返す
+func Codeword(dataCodewords []byte) ([]byte, error) {
+	if len(dataCodewords) != DataCodewords {
+		return nil, fmt.Errorf("データコード語は%dバイトである必要がありますが, %dバイトでした.", DataCodewords, len(dataCodewords))
+	}
+	return rs.QR.Encode(dataCodewords, ECCCodewords)
+}
+
+// ParseKanji は漢字モードのデータコード語 (19バイト) からモード指示子, 文字数指示子と13ビットの値を読み取り,
+// 元の文字列を返す. 終端パターンと埋め草コード語も確かめる (AssembleKanji の逆).
+func ParseKanji(dataCodewords []byte) (string, []kanji.Char, error) {
+	if len(dataCodewords) != DataCodewords {
+		return "", nil, fmt.Errorf("データコード語は%dバイトである必要がありますが, %dバイトでした.", DataCodewords, len(dataCodewords))
+	}
+	var bits strings.Builder
+	for _, b := range dataCodewords {
+		fmt.Fprintf(&bits, "%08b", b)
+	}
+	stream := bits.String()
+
+	header := len(kanji.ModeIndicator) + CharCountBits
+	if mode := stream[:len(kanji.ModeIndicator)]; mode != kanji.ModeIndicator {
+		return "", nil, fmt.Errorf("モード指示子が漢字モード(%s)ではありません: %s", kanji.ModeIndicator, mode)
+	}
+	count := 0
+	for _, bit := range stream[len(kanji.ModeIndicator):header] {
+		count = count<<1 | int(bit-'0')
+	}
+	if count == 0 || count > MaxKanjiChars {
+		return "", nil, fmt.Errorf("文字数指示子が不正です: %d文字", count)
+	}
+	end := header + count*kanji.Bits
+	values := make([]uint16, count)
+	for i := range values {
+		for _, bit := range stream[header+i*kanji.Bits : header+(i+1)*kanji.Bits] {
+			values[i] = values[i]<<1 | uint16(bit-'0')
+		}
+	}
+	text, chars, err := kanji.Decode(values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	terminatorEnd := end + len(Terminator)
+	if terminatorEnd > len(stream) {
+		terminatorEnd = len(stream)
+	}
+	paddedEnd := (terminatorEnd + 7) / 8 * 8
+	if strings.Trim(stream[end:paddedEnd], "0") != "" {
+		return "", nil, fmt.Errorf("終端パターンと埋め草ビットが0ではありません: %s", stream[end:paddedEnd])
+	}
+	for i, b := range dataCodewords[paddedEnd/8:] {
+		if b != PadCodewords[i%2] {
+			return "", nil, fmt.Errorf("埋め草コード語が EC 11 の繰り返しではありません: %02X", b)
+		}
+	}
+	return text, chars, nil
+}
@@ -0,0 +1,119 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// --- js/wasm 以外から呼ぶための API ---
+
+// HTTP の API (server.go) と標準入出力の API (stdio.go) は, どちらも apiRequest を受け取って
+// apiHandlers の処理を呼び, WASM の関数と同じ JSON (TemplateData) を返す.
+
+// maxRequestBytes は受け付ける要求の本文の大きさの上限
+const maxRequestBytes = 64 << 10
+
+// apiRequest は要求の本文. input は WASM の関数の引数に当たり,
+// ほかの項目は呼び出しのオプション (QROptions) と同じ名前で指定する.
+type apiRequest struct {
+	Input         string `json:"input"`         // 漢字 (encode) か2進数文字列 (ecc, mask, decode)
+	Bytes         []int  `json:"bytes"`         // input の代わりのバイト列 (ecc, mask. Uint8Array を渡す場合に当たる)
+	Version       int    `json:"version"`       // 省略すると1
+	ECCLevel      string `json:"eccLevel"`      // 省略すると "L"
+	MaskPattern   *int   `json:"maskPattern"`   // 省略すると 0
+	Parsing       string `json:"parsing"`       // "strict" か "lenient"
+	Locale        string `json:"locale"`        // "ja" か "en"
+	SchemaVersion int    `json:"schemaVersion"` // 1 か 2
+	Verbosity     string `json:"verbosity"`     // "full" か "compact"
+}
+
+// options は要求のオプションを QROptions にする. 応答は常に JSON なので outputFormat は使わない.
+func (r apiRequest) options() QROptions {
+	opts := defaultQROptions()
+	if r.Version != 0 {
+		opts.Version = r.Version
+	}
+	if r.ECCLevel != "" {
+		opts.ECCLevel = r.ECCLevel
+	}
+	if r.MaskPattern != nil {
+		opts.MaskPattern = *r.MaskPattern
+	}
+	opts.Parsing, opts.Locale, opts.SchemaVersion, opts.Verbosity = r.Parsing, r.Locale, r.SchemaVersion, r.Verbosity
+	return opts
+}
+
+// byteInput は bytes が指定されていればバイト列にする
+func (r apiRequest) byteInput() ([]byte, bool, error) {
+	if r.Bytes == nil {
+		return nil, false, nil
+	}
+	b := make([]byte, len(r.Bytes))
+	for i, v := range r.Bytes {
+		if v < 0 || v > 0xFF {
+			return nil, true, newCodedError(errCodeBadInput, fmt.Sprint(v), i, "bytes の%d番目が0〜255の範囲にありません: %d", i, v)
+		}
+		b[i] = byte(v)
+	}
+	return b, true, nil
+}
+
+// apiHandlers は API の名前ごとの処理 (HTTP ではパス /encode などに当たる)
+var apiHandlers = map[string]func(*call, apiRequest) (TemplateData, error){
+	"encode": func(c *call, r apiRequest) (TemplateData, error) {
+		return c.processStep1To2(r.Input)
+	},
+	"ecc": func(c *call, r apiRequest) (TemplateData, error) {
+		if b, ok, err := r.byteInput(); ok {
+			if err != nil {
+				return TemplateData{}, err
+			}
+			return c.processStep3Bytes(b)
+		}
+		return c.processStep3(r.Input)
+	},
+	"mask": func(c *call, r apiRequest) (TemplateData, error) {
+		if b, ok, err := r.byteInput(); ok {
+			if err != nil {
+				return TemplateData{}, err
+			}
+			return c.processStep4Bytes(b)
+		}
+		return c.processStep4(r.Input)
+	},
+	"decode": func(c *call, r apiRequest) (TemplateData, error) {
+		return c.processDecodeAll(r.Input)
+	},
+}
+
+// callAPI はオプションを設定した呼び出し (call) で fn を呼び, 応答とエラー (あれば) を返す. panic は ERR_INTERNAL のエラーにする.
+// 呼び出しごとに Encoder とオプションを持つ call を作るので, HTTP の要求などを同時に処理できる.
+// ctx が取り消されると, 長い処理の区切りでやめて ERR_CANCELLED を返す.
+// 応答の前処理 (詳しさ, 応答の版) も呼び出しのオプションで行う.
+func callAPI(ctx context.Context, name string, req apiRequest, fn func(*call, apiRequest) (TemplateData, error)) (response interface{}, err error) {
+	if err := checkEncoder(); err != nil {
+		return defaultCall().errorData(err), err
+	}
+	opts := req.options()
+	if err := opts.validate(); err != nil {
+		return defaultCall().errorData(err), err
+	}
+	if ctx.Err() != nil {
+		return defaultCall().errorData(errCancelled), errCancelled
+	}
+	opts.Context = ctx
+	c := newCall(currentEncoder.Load(), opts)
+	defer func() {
+		if r := recover(); r != nil {
+			err = newCodedError(errCodeInternal, fmt.Sprint(r), noErrorPosition, "%s の処理中に内部エラーが発生しました: %v", name, r)
+			response = c.errorData(err)
+		}
+	}()
+	data, err := fn(c, req)
+	if err != nil {
+		return c.errorData(err), err
+	}
+	return c.responseData(data), nil
+}
@@ -0,0 +1,51 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// 呼び出しごとのオプションが, 同時に処理している他の呼び出しに混ざらないことを確かめる
+
+func TestCallAPIConcurrent(t *testing.T) {
+	encoded, err := callAPI(context.Background(), "encode", apiRequest{Input: "漢字"}, apiHandlers["encode"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	codeword, err := callAPI(context.Background(), "ecc", apiRequest{Input: encoded.(TemplateData).Intermediate.PaddedBinary}, apiHandlers["ecc"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := codeword.(TemplateData).Intermediate.CodewordBinary
+
+	// マスクパターンごとの答えを1つずつ求めておき, 同時に呼んだ結果と比べる
+	want := make([]string, 8)
+	for pattern := range want {
+		pattern := pattern
+		data, err := callAPI(context.Background(), "mask", apiRequest{Input: input, MaskPattern: &pattern}, apiHandlers["mask"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		want[pattern] = data.(TemplateData).Intermediate.MaskedCodewordHex
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		pattern := i % 8
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := callAPI(context.Background(), "mask", apiRequest{Input: input, MaskPattern: &pattern}, apiHandlers["mask"])
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if got := data.(TemplateData).Intermediate.MaskedCodewordHex; got != want[pattern] {
+				t.Errorf("マスク %d: %s, want %s", pattern, got, want[pattern])
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/gf"
+)
+
+// --- GF(2^8)および多項式演算 (呼び出しの Encoder で計算する) ---
+func gfAdd(a, b int) int {
+	return a ^ b
+}
+
+// polyEval は多項式 p (最高次の係数から順に格納) の x における値をホーナー法で求める
+func (e *Encoder) polyEval(p []int, x int) int {
+	return e.field.PolyEval(p, x)
+}
+
+// alphaNotation は体の要素を α^k の形で表す (0 は "0")
+func (e *Encoder) alphaNotation(v int) string {
+	if v == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("α^%d", e.log[v])
+}
+func polyAdd(p1, p2 []int) []int {
+	return gf.PolyAdd(p1, p2)
+}
+
+// polyScale は多項式の各係数に c を掛ける
+func (e *Encoder) polyScale(p []int, c int) []int {
+	result := make([]int, len(p))
+	e.polyScaleInto(result, p, c)
+	return result
+}
+
+// polyTrim は最高次側の0の係数を取り除く (0多項式は [0] とする)
+func polyTrim(p []int) []int {
+	return gf.PolyTrim(p)
+}
+
+// polyCoeff は x^i の係数を返す (次数を超える場合は0)
+func polyCoeff(p []int, i int) int {
+	return gf.PolyCoeff(p, i)
+}
+
+// polyDivMod は一般の多項式どうしの除算を行い, 商と剰余を返す (除数が0多項式ならエラー)
+func (e *Encoder) polyDivMod(dividend, divisor []int) ([]int, []int, error) {
+	quotient, remainder, err := e.field.PolyDivMod(dividend, divisor)
+	return quotient, remainder, fieldError(err)
+}
+
+func polyLeftShift(p []int, count int) []int {
+	result := make([]int, len(p)+count)
+	copy(result, p)
+	return result
+}
+
+// polyDiv は dividend を除数 (最高次係数は1) で割った剰余を返す. dividend は書き換えない.
+func (e *Encoder) polyDiv(dividend []int, divisor []int) ([]int, error) {
+	result := make([]int, len(dividend))
+	copy(result, dividend)
+	return e.polyDivInPlace(result, divisor)
+}
+
+// fieldError は internal/gf, internal/rs の演算のエラーを現在の言語で返せるエラーにする.
+// 0 による除算と体の要素でない値は入力の誤り, それ以外 (除数の形など) は呼び出し側の誤りなので ERR_INTERNAL にする.
+func fieldError(err error) error {
+	var notElement *gf.NotElementError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gf.ErrDivisionByZero):
+		return errorf("0で割ることはできません.")
+	case errors.As(err, &notElement):
+		return errorf("GF(2^%d) の要素は0〜%dで指定してください: %d", notElement.M, 1<<notElement.M-1, notElement.Value)
+	}
+	return newCodedError(errCodeInternal, err.Error(), noErrorPosition, "多項式の演算に失敗しました: %v", err)
+}
+func (c *call) getGeneratorPolynomial(degree int) []int {
+	p, _ := c.getGeneratorPolynomialWithSteps(degree, 0)
+	return p
+}
+
+// getGeneratorPolynomialWithSteps は g(x) = (x + α^b)(x + α^(b+1))...(x + α^(b+degree-1)) を求め,
+// 1つずつ掛けるごとの途中の積 g_1(x), g_2(x), ... も返す. QRコードでは b = firstRoot = 0.
+func (c *call) getGeneratorPolynomialWithSteps(degree, firstRoot int) ([]int, []GeneratorStep) {
+	// 初期値は g(x) = 1
+	p := []int{1}
+	steps := make([]GeneratorStep, 0, degree)
+	for i := 0; i < degree; i++ {
+		// p(x) * (x + α^(b+i)) を計算する
+		e := (firstRoot + i) % 255
+		p = c.polyMul(p, []int{1, c.exp[e]})
+		steps = append(steps, GeneratorStep{
+			Index:        i + 1,
+			Factor:       fmt.Sprintf("x + \\alpha^{%d}", e),
+			Coefficients: append([]int(nil), p...),
+			Latex:        c.formatPolynomial(p, "x"),
+		})
+	}
+	return p, steps
+}
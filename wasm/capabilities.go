@@ -0,0 +1,54 @@
+package main
+
+import "github.com/mocho271828/rs_coding-compresser/qr"
+
+// --- 対応している機能の一覧 ---
+
+// moduleVersion はこの WebAssembly モジュールの版. 応答の形や関数が変わったら上げる.
+const moduleVersion = "1.2.0"
+
+// CapabilitiesData はモジュールが対応している機能の一覧. フロントエンドはこれを見て機能の有無を判断する.
+type CapabilitiesData struct {
+	Version            string   `json:"Version"`            // モジュールの版
+	SchemaVersions     []int    `json:"SchemaVersions"`     // 返せる応答の版 (最後が現在の版)
+	Modes              []string `json:"Modes"`              // 符号化モード
+	Versions           []int    `json:"Versions"`           // 型番
+	ECCLevels          []string `json:"ECCLevels"`          // 誤り訂正レベル
+	MaskPatterns       []int    `json:"MaskPatterns"`       // マスクパターン参照子
+	OutputFormats      []string `json:"OutputFormats"`      // 応答の形式 (setResponseFormat, outputFormat)
+	ParsingModes       []string `json:"ParsingModes"`       // 2進数文字列の読み取り方 (parsing)
+	Locales            []string `json:"Locales"`            // メッセージの言語 (setLocale, locale)
+	Verbosities        []string `json:"Verbosities"`        // 応答の詳しさ (verbosity)
+	RenderFormats      []string `json:"RenderFormats"`      // 描画形式
+	CoefficientFormats []string `json:"CoefficientFormats"` // 多項式の係数の表し方 (setCoefficientFormat)
+	RSModes            []string `json:"RSModes"`            // rsEncode の符号化の方式
+	MaxCharCount       int      `json:"MaxCharCount"`       // 入力できる最大文字数
+	Functions          []string `json:"Functions"`          // JS に登録した関数 (Async の版を除く)
+}
+
+// exportedFunctions は exportFunction で登録した関数の名前 (登録した順)
+var exportedFunctions []string
+
+// processCapabilities は対応している機能の一覧を返す
+func (c *call) processCapabilities() TemplateData {
+	var data TemplateData
+	data.Capabilities = CapabilitiesData{
+		Version:            moduleVersion,
+		SchemaVersions:     []int{schemaVersionV1, schemaVersion},
+		Modes:              qr.Modes(),
+		Versions:           []int{1},
+		ECCLevels:          []string{"L"},
+		MaskPatterns:       []int{0, 1, 2, 3, 4, 5, 6, 7},
+		OutputFormats:      []string{responseJSON, responseObject, responseTransferable},
+		ParsingModes:       []string{parsingStrict, parsingLenient},
+		Locales:            []string{localeJA, localeEN},
+		Verbosities:        []string{verbosityFull, verbosityCompact},
+		RenderFormats:      []string{"svg", "svg-animated", "svg-colored", "png", "pbm", "xbm", "html", "text", "pdf"},
+		CoefficientFormats: []string{coefficientAlpha, coefficientDecimal, coefficientHex},
+		RSModes:            []string{rsModeSystematic, rsModeNonSystematic},
+		MaxCharCount:       maxCharCount,
+		Functions:          append([]string(nil), exportedFunctions...),
+	}
+	data.MaxCharCount = maxCharCount
+	return data
+}
@@ -0,0 +1,69 @@
+//go:build cgo && !(js && wasm) && !wasip1
+
+package main
+
+// #include <stdlib.h>
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"unsafe"
+)
+
+// --- C から呼ぶための API ---
+
+// 共有ライブラリとして作ると, Python (ctypes) や C から WASM と同じ処理を呼べる.
+// 各関数は入力の文字列 (UTF-8) とオプションの JSON (apiRequest と同じ項目. 不要なら NULL) を受け取り,
+// WASM の関数と同じ JSON の応答を返す. 返した文字列は FreeString で解放する.
+//
+//	go build -buildmode=c-shared -o librscoder.so ./wasm
+//
+//	lib = ctypes.CDLL("./librscoder.so")
+//	lib.EncodeKanji.restype = ctypes.c_void_p
+//	p = lib.EncodeKanji("漢字".encode(), None)
+//	data = json.loads(ctypes.string_at(p))
+//	lib.FreeString(ctypes.c_void_p(p))
+
+// EncodeKanji は漢字を STEP1-2 (データコード語) まで符号化する (generateDataCodewords と同じ)
+//
+//export EncodeKanji
+func EncodeKanji(input, options *C.char) *C.char {
+	return callC("encode", input, options)
+}
+
+// ApplyECC は2進数文字列のデータコード語に誤り訂正コード語を付ける (applyEcc と同じ)
+//
+//export ApplyECC
+func ApplyECC(input, options *C.char) *C.char {
+	return callC("ecc", input, options)
+}
+
+// ApplyMask は2進数文字列の符号語にマスクを掛ける (applyMask と同じ)
+//
+//export ApplyMask
+func ApplyMask(input, options *C.char) *C.char {
+	return callC("mask", input, options)
+}
+
+// FreeString は EncodeKanji などが返した文字列を解放する
+//
+//export FreeString
+func FreeString(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+// callC はオプションを読んで apiHandlers の name の処理を呼び, 応答の JSON を C の文字列で返す
+func callC(name string, input, options *C.char) *C.char {
+	var req apiRequest
+	if options != nil {
+		if err := json.Unmarshal([]byte(C.GoString(options)), &req); err != nil {
+			return C.CString(jsonResponse(defaultCall().errorData(errorf("オプションを JSON として読めませんでした: %v", err))))
+		}
+	}
+	if input != nil {
+		req.Input = C.GoString(input)
+	}
+	data, _ := callAPI(context.Background(), name, req, apiHandlers[name])
+	return C.CString(jsonResponse(data))
+}
@@ -0,0 +1,64 @@
+package main
+
+import "fmt"
+
+// --- 分割した入力 ---
+
+// バイトモードでは数キロバイトの入力も扱えるようにするため, 大きな JS の文字列を一度に渡さず,
+// appendChunk で少しずつ Go 側に溜めてから finishChunks でまとめる.
+// まとめた入力は chunkedPayload に残し, バイトモードの符号化で使う.
+
+const maxChunkedInput = 64 * 1024 // 溜めておける入力のバイト数の上限
+
+// byteModeCapacity は型番1, 誤り訂正レベルL のバイトモードで入るバイト数
+const byteModeCapacity = 17
+
+// ChunkData は分割した入力の状態
+type ChunkData struct {
+	Chunks   int    `json:"Chunks"`   // これまでに受け取った断片の数
+	Length   int    `json:"Length"`   // これまでに受け取ったバイト数
+	Finished bool   `json:"Finished"` // finishChunks でまとめたか
+	Preview  string `json:"Preview"`  // 先頭 (最大16バイト) の16進数 (まとめたときだけ)
+	Capacity int    `json:"Capacity"` // 型番1-L のバイトモードに入るバイト数
+	Fits     bool   `json:"Fits"`     // 型番1-L のバイトモードに入るか
+}
+
+var pendingChunks []byte
+var pendingChunkCount int
+var chunkedPayload []byte // 最後に finishChunks でまとめた入力
+
+// processAppendChunk は入力の断片を溜める
+func (c *call) processAppendChunk(chunk []byte) (TemplateData, error) {
+	if len(pendingChunks)+len(chunk) > maxChunkedInput {
+		return TemplateData{}, newCodedError(errCodeTooLong, fmt.Sprint(len(pendingChunks)+len(chunk)), maxChunkedInput, "分割した入力が大きすぎます (%dバイトまで).", maxChunkedInput)
+	}
+	pendingChunks = append(pendingChunks, chunk...)
+	pendingChunkCount++
+
+	var data TemplateData
+	data.Chunk = ChunkData{Chunks: pendingChunkCount, Length: len(pendingChunks), Capacity: byteModeCapacity, Fits: len(pendingChunks) <= byteModeCapacity}
+	return data, nil
+}
+
+// processFinishChunks は溜めた断片を1つの入力にまとめ, 次の入力のために空にする
+func (c *call) processFinishChunks() (TemplateData, error) {
+	if pendingChunkCount == 0 {
+		return TemplateData{}, newCodedError(errCodeEmpty, "", noErrorPosition, "appendChunk で入力を渡してから finishChunks を呼んでください.")
+	}
+	chunkedPayload = pendingChunks
+	var data TemplateData
+	data.Chunk = ChunkData{
+		Chunks:   pendingChunkCount,
+		Length:   len(chunkedPayload),
+		Finished: true,
+		Capacity: byteModeCapacity,
+		Fits:     len(chunkedPayload) <= byteModeCapacity,
+	}
+	preview := chunkedPayload
+	if len(preview) > 16 {
+		preview = preview[:16]
+	}
+	data.Chunk.Preview = formatBytesToHex(preview)
+	pendingChunks, pendingChunkCount = nil, 0
+	return data, nil
+}
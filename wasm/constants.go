@@ -0,0 +1,54 @@
+package main
+
+import "fmt"
+
+// --- 符号化に使う定数 ---
+
+// ConstantsData は Go の実装が使っている定数. UI の説明文はこれを参照し, 実装と食い違わないようにする.
+type ConstantsData struct {
+	ModeIndicators         map[string]string `json:"ModeIndicators"`      // モード指示子 (4ビット. 実装しているのは kanji だけ)
+	CharCountBits          map[string]int    `json:"CharCountBits"`       // 型番1の文字数指示子のビット数
+	Terminator             string            `json:"Terminator"`          // 終端パターン
+	PadCodewords           []string          `json:"PadCodewords"`        // 埋め草コード語 (16進数, 交互に繰り返す)
+	PrimitivePolynomial    int               `json:"PrimitivePolynomial"` // QRコードの GF(2^8) の原始多項式
+	PrimitivePolynomialHex string            `json:"PrimitivePolynomialHex"`
+	FieldPolynomial        int               `json:"FieldPolynomial"`       // 現在のテーブルを作った原始多項式 (setPrimitivePolynomial で変わる)
+	SymbolSize             int               `json:"SymbolSize"`            // 型番1の1辺のモジュール数
+	DataCodewords          int               `json:"DataCodewords"`         // 型番1-L のデータコード語の数
+	ECCodewords            int               `json:"ECCodewords"`           // 型番1-L の誤り訂正コード語の数
+	TotalCodewords         int               `json:"TotalCodewords"`        // 型番1の符号語の総数
+	DataBits               int               `json:"DataBits"`              // 型番1-L のデータのビット数
+	MaxKanji               int               `json:"MaxKanji"`              // 型番1-L の漢字モードの最大文字数
+	MaxBytes               int               `json:"MaxBytes"`              // 型番1-L のバイトモードの最大バイト数
+	CorrectableErrors      int               `json:"CorrectableErrors"`     // 訂正できる誤りのバイト数
+	ECCLevelBits           string            `json:"ECCLevelBits"`          // 形式情報の誤り訂正レベルLの2ビット
+	FormatInformationMask  string            `json:"FormatInformationMask"` // 形式情報に掛けるマスク (15ビット)
+}
+
+// processConstants は符号化に使う定数を返す
+func (c *call) processConstants() TemplateData {
+	var data TemplateData
+	data.MaxCharCount = maxCharCount
+	data.Constants = ConstantsData{
+		ModeIndicators:         map[string]string{"numeric": "0001", "alphanumeric": "0010", "byte": "0100", "kanji": modeIndicatorKanji},
+		CharCountBits:          map[string]int{"numeric": 10, "alphanumeric": 9, "byte": 8, "kanji": 8},
+		Terminator:             terminatorPattern,
+		PrimitivePolynomial:    primitivePolynomial,
+		PrimitivePolynomialHex: fmt.Sprintf("0x%X", primitivePolynomial),
+		FieldPolynomial:        c.polynomial,
+		SymbolSize:             symbolSize,
+		DataCodewords:          dataCodewordCount,
+		ECCodewords:            eccCodewordCount,
+		TotalCodewords:         totalCodewordCount,
+		DataBits:               dataCodewordCount * 8,
+		MaxKanji:               maxCharCount,
+		MaxBytes:               byteModeCapacity,
+		CorrectableErrors:      eccCodewordCount / 2,
+		ECCLevelBits:           fmt.Sprintf("%02b", eccLevelBits),
+		FormatInformationMask:  fmt.Sprintf("%015b", formatInformationMask),
+	}
+	for _, b := range padCodewords {
+		data.Constants.PadCodewords = append(data.Constants.PadCodewords, fmt.Sprintf("%02X", b))
+	}
+	return data
+}
@@ -0,0 +1,498 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/rs"
+)
+
+// --- 誤り訂正 (復号) ---
+
+// DecodingData は受信語の復号の過程
+type DecodingData struct {
+	Syndromes              []SyndromeData        `json:"Syndromes"`
+	SyndromesZero          bool                  `json:"SyndromesZero"` // 全てのシンドロームが0なら誤りは検出されない
+	SyndromeTableLatex     string                `json:"SyndromeTableLatex"`
+	Method                 string                `json:"Method"` // 誤り位置多項式の求め方
+	BerlekampMassey        []BerlekampMasseyStep `json:"BerlekampMassey"`
+	Euclid                 []EuclideanStep       `json:"Euclid"`
+	ErrorLocator           []int                 `json:"ErrorLocator"` // 誤り位置多項式 Λ(x) の係数 (最高次から)
+	ErrorLocatorLatex      string                `json:"ErrorLocatorLatex"`
+	ErasurePositions       []int                 `json:"ErasurePositions"` // 呼び出し側が指定した消失の位置
+	ErasureLocator         []int                 `json:"ErasureLocator"`   // 消失位置多項式 Γ(x) の係数 (最高次から)
+	ErasureLocatorLatex    string                `json:"ErasureLocatorLatex"`
+	ChienSearch            []ChienSearchStep     `json:"ChienSearch"`
+	ErrorEvaluator         []int                 `json:"ErrorEvaluator"` // 誤り評価多項式 Ω(x) の係数 (最高次から)
+	ErrorEvaluatorLatex    string                `json:"ErrorEvaluatorLatex"`
+	LocatorDerivative      []int                 `json:"LocatorDerivative"` // Λ(x) の形式微分 Λ'(x) の係数 (最高次から)
+	LocatorDerivativeLatex string                `json:"LocatorDerivativeLatex"`
+	Forney                 []ForneyStep          `json:"Forney"`
+	ErrorPositions         []int                 `json:"ErrorPositions"` // 誤りのあるバイトの位置 (0始まり, 先頭のバイトが x^25 の係数)
+	ErrorValues            []int                 `json:"ErrorValues"`    // 各位置の誤りの大きさ
+	ReceivedHex            string                `json:"ReceivedHex"`
+	ReceivedBinary         string                `json:"ReceivedBinary"`
+	ErrorVector            []int                 `json:"ErrorVector"` // 各バイトに加えた誤り (誤りのない位置は0)
+	CorrectedHex           string                `json:"CorrectedHex"`
+	CorrectedBinary        string                `json:"CorrectedBinary"`
+	Diff                   []ByteDiff            `json:"Diff"`      // 受信語と訂正後の符号語のバイトごとの比較
+	XORBinary              string                `json:"XORBinary"` // 受信語と訂正後の符号語の排他的論理和 (2進数)
+	Budget                 DecodingBudget        `json:"Budget"`
+	Verification           VerificationData      `json:"Verification"`
+	Capacity               CodeCapacity          `json:"Capacity"`
+	Repair                 RepairData            `json:"Repair"`
+	Success                bool                  `json:"Success"`
+	Failure                *DecodingFailure      `json:"Failure"` // 訂正できなかった場合の診断情報 (成功時は null)
+}
+
+// DecodingFailure は訂正できなかったときの診断情報.
+// 4個以上の誤りがあると, 多くの場合はこのいずれかで検出できるが, 別の正しい符号語に誤訂正されて検出できないこともある.
+type DecodingFailure struct {
+	Reason               string `json:"Reason"`
+	Message              string `json:"Message"`
+	LocatorDegree        int    `json:"LocatorDegree"`
+	RootCount            int    `json:"RootCount"`            // 符号語の中にある根の数
+	RootsOutsideCodeword int    `json:"RootsOutsideCodeword"` // 符号語の外 (p ≧ 26) にある根の数
+	ResidualSyndromes    []int  `json:"ResidualSyndromes"`    // 訂正後のシンドローム
+}
+
+// DecodingBudget は訂正能力 (2e+f ≦ n-k) のうち, どれだけを誤りと消失に使ったか
+type DecodingBudget struct {
+	Errors   int `json:"Errors"`   // 誤りの個数 e
+	Erasures int `json:"Erasures"` // 消失の個数 f
+	Used     int `json:"Used"`     // 2e+f
+	Capacity int `json:"Capacity"` // n-k (誤り訂正コード語の数)
+}
+
+// CodeCapacity は (n, k) RS符号の訂正能力
+type CodeCapacity struct {
+	N               int    `json:"N"`               // 符号長 (バイト)
+	K               int    `json:"K"`               // データ長 (バイト)
+	T               int    `json:"T"`               // 訂正できる誤りの個数 ⌊(n-k)/2⌋
+	MinimumDistance int    `json:"MinimumDistance"` // 最小距離 n-k+1
+	ErasureCapacity int    `json:"ErasureCapacity"` // 訂正できる消失の個数 n-k
+	Description     string `json:"Description"`
+}
+
+// ByteDiff は受信語と訂正後の符号語の1バイト分の比較
+type ByteDiff struct {
+	Position  int    `json:"Position"`
+	Received  string `json:"Received"`  // 受信したバイト (16進数)
+	Corrected string `json:"Corrected"` // 訂正後のバイト (16進数)
+	Magnitude int    `json:"Magnitude"` // 誤りの大きさ (受信 XOR 訂正後)
+	XOR       string `json:"XOR"`       // 誤りの大きさ (2進数8桁)
+	Changed   bool   `json:"Changed"`
+}
+
+// VerificationData は符号語の検査結果 (訂正は行わない)
+type VerificationData struct {
+	Valid           bool   `json:"Valid"`
+	ExpectedECCHex  string `json:"ExpectedECCHex"`  // データコード語から計算し直した誤り訂正コード語
+	ReceivedECCHex  string `json:"ReceivedECCHex"`  // 入力された誤り訂正コード語
+	MismatchedECC   []int  `json:"MismatchedECC"`   // 一致しない誤り訂正コード語の位置 (0〜6)
+	RemainderIsZero bool   `json:"RemainderIsZero"` // 符号語全体を生成多項式で割った余りが0か
+}
+
+// SyndromeData は S_i = r(α^i) の値
+type SyndromeData struct {
+	Index int    `json:"Index"`
+	Value int    `json:"Value"`
+	Alpha string `json:"Alpha"` // α^k の形 (0の場合は "0")
+	Latex string `json:"Latex"` // S_i = r(α^i) = α^k のLaTeX表記
+}
+
+// BerlekampMasseyStep は Berlekamp-Massey 法の1回の反復
+type BerlekampMasseyStep struct {
+	Step         int    `json:"Step"`        // 反復の番号 r (0始まり. S_r までを使う)
+	Discrepancy  int    `json:"Discrepancy"` // 食い違い Δ_r
+	Locator      []int  `json:"Locator"`     // この反復の後の Λ(x) の係数 (最高次から)
+	LocatorLatex string `json:"LocatorLatex"`
+	L            int    `json:"L"` // この反復の後の線形帰還シフトレジスタの長さ
+}
+
+// EuclideanStep はユークリッド互除法の1回の割り算
+type EuclideanStep struct {
+	Step      int   `json:"Step"`
+	Quotient  []int `json:"Quotient"`  // 商 q_i(x)
+	Remainder []int `json:"Remainder"` // 剰余 r_i(x)
+	Locator   []int `json:"Locator"`   // t_i(x) = t_{i-2}(x) - q_i(x) t_{i-1}(x)
+}
+
+// ChienSearchStep は Chien 探索で1つの体の要素 α^{-p} を代入した結果
+type ChienSearchStep struct {
+	Degree   int    `json:"Degree"`   // p (X = α^p が誤り位置の候補)
+	Position int    `json:"Position"` // 対応するバイトの位置 (符号語の外なら -1)
+	Element  string `json:"Element"`  // 代入した要素 α^{-p} を α^k の形で表したもの
+	Value    int    `json:"Value"`    // Λ(α^{-p})
+	IsRoot   bool   `json:"IsRoot"`
+}
+
+// ForneyStep は Forney のアルゴリズムで1つの誤りの大きさを求める計算
+type ForneyStep struct {
+	Position        int    `json:"Position"`        // 誤りのあるバイトの位置
+	Locator         string `json:"Locator"`         // X_k = α^p
+	OmegaValue      int    `json:"OmegaValue"`      // Ω(X_k^{-1})
+	DerivativeValue int    `json:"DerivativeValue"` // Λ'(X_k^{-1})
+	Value           int    `json:"Value"`           // e_k = X_k Ω(X_k^{-1}) / Λ'(X_k^{-1})
+}
+
+// 誤り位置多項式の求め方
+const (
+	decodeMethodBerlekampMassey = "berlekamp-massey"
+	decodeMethodEuclid          = "euclid"
+	decodeMethodErasure         = "erasure" // 消失だけを訂正する (processCorrectErasures)
+)
+
+// decodeMethods は復号法の名前ごとの internal/rs の Method (processCorrect で選べるもの)
+var decodeMethods = map[string]rs.Method{
+	decodeMethodBerlekampMassey: rs.BerlekampMassey,
+	decodeMethodEuclid:          rs.Euclid,
+}
+
+// computeSyndromes は受信多項式 r(x) (最高次の係数から順) に α^0〜α^(count-1) を代入した値を返す
+func (c *call) computeSyndromes(received []int, count int) []int {
+	return c.codec.Syndromes(intsToBytes(received), count)
+}
+
+// processSyndromes は受信した26バイトの符号語(2進数)のシンドロームを計算する.
+// firstRoot は代入する α^b の最初の指数 (QRコードは0).
+func (c *call) processSyndromes(receivedBinary string, firstRoot int) (TemplateData, error) {
+	receivedBytes, err := c.parseCodewordBinary(receivedBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	if firstRoot < 0 || firstRoot > 254 {
+		return TemplateData{}, errorf("最初の根の指数 b は0〜254で指定してください: %d", firstRoot)
+	}
+	codec, err := c.codecFrom(firstRoot)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	var data TemplateData
+	data.Intermediate.CodewordHex = formatBytesToHex(receivedBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(receivedBytes)
+	data.Decoding.Syndromes, data.Decoding.SyndromesZero = c.syndromeTableFrom(codec.Syndromes(receivedBytes, eccCodewordCount), firstRoot)
+	data.Decoding.SyndromeTableLatex = c.syndromeTableLatexFrom(bytesToInts(receivedBytes), eccCodewordCount, firstRoot)
+	return data, nil
+}
+
+// latexElement は体の要素をLaTeXの α^{k} の形で表す (0 は "0")
+func (c *call) latexElement(v int) string {
+	if v == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("\\alpha^{%d}", c.log[v])
+}
+
+// syndromeTable はシンドロームを表示用に整え, 全て0かどうかを返す
+func (c *call) syndromeTable(syndromes []int) ([]SyndromeData, bool) {
+	return c.syndromeTableFrom(syndromes, 0)
+}
+
+// syndromeTableFrom は S_b, S_(b+1), ... (b = firstRoot) のシンドロームを表示用に整える
+func (c *call) syndromeTableFrom(syndromes []int, firstRoot int) ([]SyndromeData, bool) {
+	table := make([]SyndromeData, len(syndromes))
+	allZero := true
+	for i, s := range syndromes {
+		j := firstRoot + i
+		table[i] = SyndromeData{Index: j, Value: s, Alpha: c.alphaNotation(s), Latex: fmt.Sprintf("S_{%d} = r(\\alpha^{%d}) = %s", j, j, c.latexElement(s))}
+		if s != 0 {
+			allZero = false
+		}
+	}
+	return table, allZero
+}
+
+// syndromeTableLatex はシンドロームの計算をLaTeXの aligned 環境にまとめる.
+// 各行は S_i = r(α^i) に代入した各項 r_j (α^i)^{n-1-j} を α の指数でまとめたものとその和を示す (0の項は省く).
+func (c *call) syndromeTableLatex(received []int, count int) string {
+	return c.syndromeTableLatexFrom(received, count, 0)
+}
+
+// syndromeTableLatexFrom は α^b (b = firstRoot) から代入する場合の syndromeTableLatex
+func (c *call) syndromeTableLatexFrom(received []int, count, firstRoot int) string {
+	n := len(received)
+	var b strings.Builder
+	b.WriteString("\\begin{aligned}\n")
+	for i := 0; i < count; i++ {
+		e := (firstRoot + i) % 255
+		var terms []string
+		for j, r := range received {
+			if r == 0 {
+				continue
+			}
+			terms = append(terms, fmt.Sprintf("\\alpha^{%d}", (c.log[r]+e*(n-1-j))%255))
+		}
+		substituted := "0"
+		if len(terms) > 0 {
+			substituted = strings.Join(terms, " + ")
+		}
+		fmt.Fprintf(&b, "S_{%d} &= r(\\alpha^{%d}) = %s = %s", firstRoot+i, e, substituted, c.latexElement(c.polyEval(received, c.exp[e])))
+		if i < count-1 {
+			b.WriteString(" \\\\")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\\end{aligned}")
+	return b.String()
+}
+
+// processCorrect は受信した26バイトの符号語(2進数)の誤りと消失を訂正する.
+// method で誤り位置多項式を Berlekamp-Massey 法とユークリッド互除法のどちらで求めるかを選ぶ.
+// erasures には位置が分かっている消失を指定する (なければ空). e 個の誤りと f 個の消失は 2e+f ≦ n-k (誤り訂正コード語の数) なら訂正できる.
+func (c *call) processCorrect(receivedBinary, method string, erasures []int) (TemplateData, error) {
+	receivedBytes, err := c.parseCodewordBinary(receivedBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	var data TemplateData
+	data.Decoding, err = c.decodeReceived(bytesToInts(receivedBytes), method, erasures)
+	return data, err
+}
+
+// decodeReceived は受信語 (最高次の係数から順) の誤りと消失を method の復号法で訂正し, その過程を返す
+func (c *call) decodeReceived(received []int, method string, erasures []int) (DecodingData, error) {
+	m, ok := decodeMethods[method]
+	if !ok {
+		return DecodingData{}, errorf("不明な復号法です: %s (berlekamp-massey または euclid を指定してください).", method)
+	}
+	return c.decodeWith(received, method, m, erasures)
+}
+
+// decodeWith は internal/rs の DecodeWith で受信語を復号し, Trace で受け取った途中の値を表示用に整える.
+// 訂正できなかった場合もエラーにはせず, Success = false と Failure の診断情報を返す (エラーは入力が不正な場合だけ).
+func (c *call) decodeWith(received []int, name string, method rs.Method, erasures []int) (DecodingData, error) {
+	var decoding DecodingData
+	n := len(received)
+	if err := validateErasures(erasures, n); err != nil {
+		return decoding, err
+	}
+	if len(erasures) > eccCodewordCount {
+		return decoding, errorf("消失が多すぎます (%d個). 訂正できるのは%d個までです.", len(erasures), eccCodewordCount)
+	}
+
+	receivedBytes := intsToBytes(received)
+	decoding.Method = name
+	decoding.ReceivedHex = formatBytesToHex(receivedBytes)
+	decoding.ErasurePositions = erasures
+	decoding.Budget = DecodingBudget{Erasures: len(erasures), Used: len(erasures), Capacity: eccCodewordCount}
+	decoding.SyndromeTableLatex = c.syndromeTableLatex(received, eccCodewordCount)
+	trace := &rs.Trace{
+		Syndromes: func(syndromes []int) {
+			decoding.Syndromes, decoding.SyndromesZero = c.syndromeTable(syndromes)
+		},
+		ErasureLocator: func(gamma []int) {
+			decoding.ErasureLocator = gamma
+			decoding.ErasureLocatorLatex = c.formatPolynomial(gamma, "x")
+		},
+		BerlekampMassey: func(step rs.BerlekampMasseyStep) {
+			decoding.BerlekampMassey = append(decoding.BerlekampMassey, BerlekampMasseyStep{Step: step.R, Discrepancy: step.Discrepancy, Locator: step.Locator, LocatorLatex: c.formatPolynomial(step.Locator, "x"), L: step.L})
+		},
+		Euclid: func(step rs.EuclidStep) {
+			decoding.Euclid = append(decoding.Euclid, EuclideanStep{Step: len(decoding.Euclid) + 1, Quotient: step.Quotient, Remainder: step.Remainder, Locator: step.Locator})
+		},
+		Locator: func(locator []int) {
+			decoding.ErrorLocator = locator
+			decoding.ErrorLocatorLatex = c.formatPolynomial(locator, "x")
+		},
+		ChienSearch: func(step rs.ChienStep) {
+			decoding.ChienSearch = append(decoding.ChienSearch, ChienSearchStep{Degree: step.Degree, Position: step.Position, Element: c.alphaNotation(step.Element), Value: step.Value, IsRoot: step.Value == 0})
+		},
+		Evaluator: func(omega, derivative []int) {
+			decoding.ErrorEvaluator = omega
+			decoding.ErrorEvaluatorLatex = c.formatPolynomial(omega, "x")
+			decoding.LocatorDerivative = derivative
+			decoding.LocatorDerivativeLatex = c.formatPolynomial(derivative, "x")
+		},
+		Forney: func(step rs.ForneyStep) {
+			decoding.Forney = append(decoding.Forney, ForneyStep{Position: step.Position, Locator: c.alphaNotation(step.Locator), OmegaValue: step.OmegaValue, DerivativeValue: step.DerivativeValue, Value: step.Value})
+		},
+	}
+
+	result, err := c.codec.DecodeWith(receivedBytes, eccCodewordCount, rs.DecodeOptions{Method: method, Erasures: erasures, Trace: trace})
+	var failure *rs.DecodeError
+	if errors.As(err, &failure) {
+		decoding.Budget.Errors = failure.Errors
+		decoding.Budget.Used = 2*failure.Errors + failure.Erasures
+		decoding.Failure = c.decodingFailure(failure, decoding.Budget.Capacity)
+		return decoding, nil
+	}
+	if err != nil {
+		return decoding, fieldError(err)
+	}
+
+	if decoding.SyndromesZero {
+		decoding.ErrorLocator = []int{1}
+		decoding.ErrorLocatorLatex = "1"
+	}
+	decoding.Budget.Errors = result.Errors
+	decoding.Budget.Used = 2*result.Errors + len(erasures)
+	decoding.ErrorPositions = result.Positions
+	decoding.ErrorValues = result.Values
+	decoding.CorrectedHex = formatBytesToHex(result.Corrected)
+	decoding.CorrectedBinary = formatBytesToBinary(result.Corrected)
+	decoding.Diff, decoding.XORBinary = codewordDiff(received, bytesToInts(result.Corrected))
+	decoding.Success = true
+	return decoding, nil
+}
+
+// decodingFailure は訂正できなかった理由を現在の言語のメッセージと共に表示用に整える
+func (c *call) decodingFailure(e *rs.DecodeError, capacity int) *DecodingFailure {
+	var message string
+	switch e.Reason {
+	case rs.ReasonTooManyErrors:
+		message = c.localize("誤りが多すぎるため訂正できません (2×誤り%d個 + 消失%d個 > %d).", e.Errors, e.Erasures, capacity)
+	case rs.ReasonRootCountMismatch:
+		message = c.localize("誤り位置多項式の根の数(%d)が次数(%d)と一致しないため訂正できません.", e.RootCount, e.LocatorDegree)
+	default:
+		message = c.localize("訂正後のシンドロームが0にならないため訂正できません.")
+	}
+	return &DecodingFailure{
+		Reason:               string(e.Reason),
+		Message:              message,
+		LocatorDegree:        e.LocatorDegree,
+		RootCount:            e.RootCount,
+		RootsOutsideCodeword: e.RootsOutsideCodeword,
+		ResidualSyndromes:    e.ResidualSyndromes,
+	}
+}
+
+// codewordDiff は受信語と訂正後の符号語をバイトごとに比べ, 排他的論理和を2進数で返す
+func codewordDiff(received, corrected []int) ([]ByteDiff, string) {
+	diff := make([]ByteDiff, len(received))
+	xor := make([]byte, len(received))
+	for i := range received {
+		magnitude := received[i] ^ corrected[i]
+		xor[i] = byte(magnitude)
+		diff[i] = ByteDiff{
+			Position:  i,
+			Received:  fmt.Sprintf("%02X", received[i]),
+			Corrected: fmt.Sprintf("%02X", corrected[i]),
+			Magnitude: magnitude,
+			XOR:       fmt.Sprintf("%08b", magnitude),
+			Changed:   magnitude != 0,
+		}
+	}
+	return diff, formatBytesToBinary(xor)
+}
+
+// validateErasures は消失位置が符号語の範囲内で重複していないことを確かめる
+func validateErasures(erasures []int, n int) error {
+	seen := make(map[int]bool)
+	for _, pos := range erasures {
+		if pos < 0 || pos >= n {
+			return errorf("消失位置 %d が範囲外です (0〜%d).", pos, n-1)
+		}
+		if seen[pos] {
+			return errorf("消失位置 %d が重複しています.", pos)
+		}
+		seen[pos] = true
+	}
+	return nil
+}
+
+// processCorrectErasures は位置が分かっている消失だけを訂正する.
+// 消失位置多項式 Γ(x) を誤り位置多項式として Forney のアルゴリズムで値を求めるので, 誤り訂正コード語の数 (n-k) までの消失を訂正できる.
+func (c *call) processCorrectErasures(receivedBinary string, erasures []int) (TemplateData, error) {
+	receivedBytes, err := c.parseCodewordBinary(receivedBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	var data TemplateData
+	data.Decoding, err = c.decodeWith(bytesToInts(receivedBytes), decodeMethodErasure, rs.ErasuresOnly, erasures)
+	return data, err
+}
+
+// processInjectErrors は符号語(2進数)のうち count 個のバイトを乱数で選んで壊す.
+// 同じ seed なら同じ位置と値になるので, 演習問題を再現できる.
+func (c *call) processInjectErrors(codewordBinary string, count int, seed int64) (TemplateData, error) {
+	codewordBytes, err := c.parseCodewordBinary(codewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	n := len(codewordBytes)
+	if count < 0 || count > n {
+		return TemplateData{}, errorf("誤りの個数は0〜%dで指定してください: %d", n, count)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	errorVector := make([]int, n)
+	positions := rng.Perm(n)[:count]
+	received := make([]byte, n)
+	copy(received, codewordBytes)
+	for _, pos := range positions {
+		errorVector[pos] = rng.Intn(255) + 1 // 0 では誤りにならない
+		received[pos] ^= byte(errorVector[pos])
+	}
+
+	var data TemplateData
+	data.Intermediate.CodewordHex = formatBytesToHex(codewordBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(codewordBytes)
+	data.Decoding.ReceivedHex = formatBytesToHex(received)
+	data.Decoding.ReceivedBinary = formatBytesToBinary(received)
+	data.Decoding.ErrorVector = errorVector
+	for pos, e := range errorVector {
+		if e != 0 {
+			data.Decoding.ErrorPositions = append(data.Decoding.ErrorPositions, pos)
+			data.Decoding.ErrorValues = append(data.Decoding.ErrorValues, e)
+		}
+	}
+	return data, nil
+}
+
+// processVerifyCodeword は26バイトの符号語(2進数)が正しい符号語かどうかを調べる.
+// データコード語から誤り訂正コード語を計算し直して比べ, あわせてシンドロームも求める.
+func (c *call) processVerifyCodeword(codewordBinary string) (TemplateData, error) {
+	codewordBytes, err := c.parseCodewordBinary(codewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	codeword := bytesToInts(codewordBytes)
+	generator := c.lookupGeneratorPolynomial(eccCodewordCount)
+	expected, err := c.polyDiv(polyLeftShift(codeword[:dataCodewordCount], eccCodewordCount), generator)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	var data TemplateData
+	data.Intermediate.CodewordHex = formatBytesToHex(codewordBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(codewordBytes)
+	data.Decoding.ReceivedHex = data.Intermediate.CodewordHex
+	data.Decoding.Syndromes, data.Decoding.SyndromesZero = c.syndromeTable(c.computeSyndromes(codeword, eccCodewordCount))
+
+	verification := &data.Decoding.Verification
+	verification.ExpectedECCHex = formatBytesToHex(intsToBytes(expected))
+	verification.ReceivedECCHex = formatBytesToHex(codewordBytes[dataCodewordCount:])
+	for i, v := range expected {
+		if int(codewordBytes[dataCodewordCount+i]) != v {
+			verification.MismatchedECC = append(verification.MismatchedECC, i)
+		}
+	}
+	remainder, err := c.polyDiv(codeword, generator)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	verification.RemainderIsZero = polyTrim(remainder)[0] == 0
+	verification.Valid = len(verification.MismatchedECC) == 0
+	return data, nil
+}
+
+// processCapacity は型番1-Lの (26, 19) RS符号の訂正能力を返す
+func (c *call) processCapacity() TemplateData {
+	n, k := totalCodewordCount, dataCodewordCount
+	var data TemplateData
+	data.Decoding.Capacity = CodeCapacity{
+		N:               n,
+		K:               k,
+		T:               (n - k) / 2,
+		MinimumDistance: n - k + 1,
+		ErasureCapacity: n - k,
+		Description:     c.localize("最大%dバイトの誤り, または最大%dバイトの消失を訂正できます (2×誤り + 消失 ≦ %d).", (n-k)/2, n-k, n-k),
+	}
+	return data
+}
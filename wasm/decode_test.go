@@ -0,0 +1,157 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// 訂正の結果を TemplateData に詰める形を確かめる. 復号のアルゴリズム自体は internal/rs のテストで確かめる.
+
+// testCodeword は19バイトのデータに誤り訂正コード語を付けた26バイトの符号語を返す
+func testCodeword(t *testing.T) []byte {
+	t.Helper()
+	data := make([]byte, dataCodewordCount)
+	for i := range data {
+		data[i] = byte(i*37 + 11)
+	}
+	codeword, err := defaultEncoder.Encode(data, eccCodewordCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return codeword
+}
+
+// corrupt は符号語の各位置に誤りの大きさを加えた受信語を返す
+func corrupt(codeword []byte, errs map[int]int) []int {
+	received := bytesToInts(codeword)
+	for pos, value := range errs {
+		received[pos] ^= value
+	}
+	return received
+}
+
+func TestDecodeReceived(t *testing.T) {
+	codeword := testCodeword(t)
+	tests := []struct {
+		name     string
+		method   string
+		errs     map[int]int
+		erasures []int
+		errors   int
+	}{
+		{name: "誤りなし", method: decodeMethodBerlekampMassey},
+		{name: "誤り2個と消失3個", method: decodeMethodBerlekampMassey, errs: map[int]int{2: 0x0F, 11: 0xF0, 13: 0x3C, 14: 0xC3, 25: 0x99}, erasures: []int{25, 13, 14}, errors: 2},
+		{name: "誤り3個と消失1個", method: decodeMethodEuclid, errs: map[int]int{0: 0x42, 10: 0x24, 19: 0x81, 23: 0x18}, erasures: []int{23}, errors: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+"/"+tt.name, func(t *testing.T) {
+			decoding, err := defaultCall().decodeReceived(corrupt(codeword, tt.errs), tt.method, tt.erasures)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !decoding.Success || decoding.Failure != nil || decoding.Method != tt.method {
+				t.Fatalf("Success = %v, Method = %s, Failure = %+v", decoding.Success, decoding.Method, decoding.Failure)
+			}
+			if decoding.CorrectedHex != formatBytesToHex(codeword) {
+				t.Errorf("CorrectedHex = %s, want %s", decoding.CorrectedHex, formatBytesToHex(codeword))
+			}
+			if len(decoding.ErrorPositions) != len(tt.errs) || len(decoding.ErrorValues) != len(tt.errs) {
+				t.Errorf("位置 %v, 大きさ %v", decoding.ErrorPositions, decoding.ErrorValues)
+			}
+			want := DecodingBudget{Errors: tt.errors, Erasures: len(tt.erasures), Used: 2*tt.errors + len(tt.erasures), Capacity: eccCodewordCount}
+			if decoding.Budget != want {
+				t.Errorf("Budget = %+v, want %+v", decoding.Budget, want)
+			}
+		})
+	}
+
+	// 訂正できる数を超えた消失は復号する前に入力の誤りにする
+	tooMany := make([]int, eccCodewordCount+1)
+	for i := range tooMany {
+		tooMany[i] = i
+	}
+	if _, err := defaultCall().decodeReceived(bytesToInts(codeword), decodeMethodBerlekampMassey, tooMany); err == nil {
+		t.Error("消失が多すぎてもエラーになりません")
+	}
+}
+
+func TestDecodeReceivedTrace(t *testing.T) {
+	received := corrupt(testCodeword(t), map[int]int{3: 0x10, 12: 0x80})
+
+	bm, err := defaultCall().decodeReceived(received, decodeMethodBerlekampMassey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bm.BerlekampMassey) != eccCodewordCount || bm.BerlekampMassey[eccCodewordCount-1].L != 2 {
+		t.Errorf("Berlekamp-Massey 法の反復 %+v", bm.BerlekampMassey)
+	}
+	if len(bm.ChienSearch) != 255 || len(bm.Forney) != 2 || len(bm.Euclid) != 0 {
+		t.Errorf("Chien 探索 %d 回, Forney %d 個, 割り算 %d 回", len(bm.ChienSearch), len(bm.Forney), len(bm.Euclid))
+	}
+
+	euclid, err := defaultCall().decodeReceived(received, decodeMethodEuclid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 割り算の表は1から番号を振る
+	if len(euclid.Euclid) == 0 || euclid.Euclid[0].Step != 1 || len(euclid.BerlekampMassey) != 0 {
+		t.Errorf("ユークリッド互除法の割り算 %+v", euclid.Euclid)
+	}
+	if !reflect.DeepEqual(bm.ErrorLocator, euclid.ErrorLocator) {
+		t.Errorf("Λ(x) = %v (Berlekamp-Massey), %v (Euclid)", bm.ErrorLocator, euclid.ErrorLocator)
+	}
+}
+
+func TestProcessCorrectErasures(t *testing.T) {
+	codeword := testCodeword(t)
+	erasures := []int{24, 2, 17, 9}
+	received := corrupt(codeword, map[int]int{24: 0x01, 2: 0x02, 17: 0x03, 9: 0x04})
+	data, err := defaultCall().processCorrectErasures(formatBytesToBinary(intsToBytes(received)), erasures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoding := data.Decoding
+	if !decoding.Success || decoding.CorrectedHex != formatBytesToHex(codeword) {
+		t.Fatalf("訂正できませんでした: %s, %+v", decoding.CorrectedHex, decoding.Failure)
+	}
+	if !reflect.DeepEqual(decoding.ErrorPositions, erasures) || len(decoding.ErasureLocator) != len(erasures)+1 || decoding.Method != decodeMethodErasure {
+		t.Errorf("位置 %v, Γ(x) = %v, Method = %s", decoding.ErrorPositions, decoding.ErasureLocator, decoding.Method)
+	}
+}
+
+func TestDecodeReceivedFailure(t *testing.T) {
+	codeword := testCodeword(t)
+	// rs.DecodeError の理由ごとに1つずつ, 訂正できなかったことを Failure に詰める形を確かめる
+	tests := []struct {
+		name     string
+		method   string
+		errs     map[int]int
+		erasures []int
+		reason   string
+	}{
+		{name: "誤り4個", method: decodeMethodBerlekampMassey, errs: map[int]int{0: 0x11, 5: 0x22, 10: 0x33, 15: 0x44}, reason: "too-many-errors"},
+		{name: "誤り4個", method: decodeMethodEuclid, errs: map[int]int{0: 0x11, 5: 0x22, 10: 0x33, 15: 0x44}, reason: "root-count-mismatch"},
+		{name: "誤り1個と消失6個", method: decodeMethodEuclid, errs: map[int]int{25: 0x80, 0: 1, 1: 2, 2: 3, 3: 4, 4: 5, 5: 6}, erasures: []int{0, 1, 2, 3, 4, 5}, reason: "residual-syndrome"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+"/"+tt.name, func(t *testing.T) {
+			decoding, err := defaultCall().decodeReceived(corrupt(codeword, tt.errs), tt.method, tt.erasures)
+			if err != nil {
+				t.Fatal(err)
+			}
+			failure := decoding.Failure
+			if decoding.Success || failure == nil {
+				t.Fatalf("訂正能力を超えた誤りを訂正しました: %s", decoding.CorrectedHex)
+			}
+			if failure.Reason != tt.reason || failure.Message == "" || decoding.CorrectedHex != "" {
+				t.Fatalf("Failure = %+v, CorrectedHex = %q, want Reason %s", failure, decoding.CorrectedHex, tt.reason)
+			}
+			if decoding.Budget.Erasures != len(tt.erasures) || decoding.Budget.Capacity != eccCodewordCount {
+				t.Errorf("Budget = %+v", decoding.Budget)
+			}
+			if tt.reason == "residual-syndrome" && len(failure.ResidualSyndromes) != eccCodewordCount {
+				t.Errorf("訂正後のシンドローム %v", failure.ResidualSyndromes)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"sync/atomic"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/gf"
+	"github.com/mocho271828/rs_coding-compresser/internal/rs"
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// --- 符号化の設定 ---
+
+// Encoder は1つの設定 (GF(2^8) の原始多項式とマスクパターン) で符号化するための表をまとめたもの.
+// 設定ごとに別の Encoder を作れば, 違う体やマスクの符号化を同時に扱える.
+// WASM から呼ぶ関数は, 呼び出しの始めに currentEncoder (現在の設定) を call に持たせて使う. 体の演算と RS 符号は internal/gf, internal/rs に任せる.
+type Encoder struct {
+	polynomial  int       // 体を作った原始多項式
+	field       *gf.Field // 体 (processFieldOperation などで使う)
+	codec       *rs.Codec // 最初の根 α^0 の RS 符号 (定数倍の表と生成多項式を持つ)
+	exp         [256]int  // exp[k] = α^k (exp[255] = 1)
+	log         [256]int  // log[α^k] = k
+	maskPattern int       // マスクパターン参照子
+	maskBytes   []byte    // マスクパターンを符号語の配置順に並べたバイト列 (99 99 99 66 66 66 ...)
+}
+
+// NewEncoder は原始多項式 polynomial の体とマスクパターン maskPattern の Encoder を作る
+func NewEncoder(polynomial, maskPattern int) (*Encoder, error) {
+	if maskPattern < 0 || maskPattern >= len(qr.MaskConditions) {
+		return nil, errorf("マスクパターン番号は0から%dの範囲で指定してください.", len(qr.MaskConditions)-1)
+	}
+	f, err := newGaloisField(8, polynomial)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := rs.NewCodec(f, 0)
+	if err != nil {
+		return nil, err
+	}
+	e := &Encoder{polynomial: f.Polynomial(), field: f, codec: codec, maskPattern: maskPattern}
+	for k := range e.exp {
+		e.exp[k] = f.Exp(k)
+	}
+	for v := 1; v < 256; v++ {
+		e.log[v] = f.Log(v)
+	}
+	for _, degree := range qrGeneratorDegrees {
+		codec.Generator(degree)
+	}
+	e.maskBytes, err = qr.MaskBytes(maskPattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.maskBytes) != totalCodewordCount {
+		return nil, errorf("マスクパターンの導出に失敗しました: %dバイトでした.", len(e.maskBytes))
+	}
+	return e, nil
+}
+
+// defaultEncoder は既定の設定 (原始多項式 0x11D, マスク000) の Encoder.
+// パッケージの初期化で作るので, main の前に呼ばれても表が空になることはない.
+// 既定の設定で作れなかった場合 (プログラムの誤り) は panic せずに理由を encoderError に残し,
+// 呼び出しの入口 (recovered, callAPI) で checkEncoder が ERR_INTERNAL のエラーとして返す.
+var defaultEncoder, encoderError = NewEncoder(primitivePolynomial, defaultMaskPattern)
+
+// currentEncoder は新しい呼び出し (newCall) が使う現在の設定. setFieldPolynomial で差し替える.
+// HTTP の API などでは呼び出しが同時に読むので, 読み書きは currentEncoder.Load と Store だけで行う.
+var currentEncoder atomic.Pointer[Encoder]
+
+func init() {
+	currentEncoder.Store(defaultEncoder)
+}
+
+// checkEncoder は既定の設定の Encoder を作れていなければ ERR_INTERNAL のエラーを返す
+func checkEncoder() error {
+	if encoderError != nil {
+		return newCodedError(errCodeInternal, encoderError.Error(), noErrorPosition, "既定の設定で符号化の表を作れませんでした: %v", encoderError)
+	}
+	return nil
+}
+
+func (e *Encoder) gfMul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return e.exp[(e.log[a]+e.log[b])%255]
+}
+
+// gfDiv は a ÷ b を返す. b = 0 や体の要素でない値にはエラーを返す.
+func (e *Encoder) gfDiv(a, b int) (int, error) {
+	v, err := e.field.Div(a, b)
+	return v, fieldError(err)
+}
+
+// gfInv は a の逆元を返す. a = 0 や体の要素でない値にはエラーを返す.
+func (e *Encoder) gfInv(a int) (int, error) {
+	v, err := e.field.Inv(a)
+	return v, fieldError(err)
+}
+
+func (e *Encoder) gfPow(a, n int) int {
+	if a == 0 {
+		if n == 0 {
+			return 1
+		}
+		return 0
+	}
+	k := (e.log[a] * n) % 255
+	if k < 0 {
+		k += 255
+	}
+	return e.exp[k]
+}
+
+// polyMul は2つの多項式の積を返す
+func (e *Encoder) polyMul(p1, p2 []int) []int {
+	return e.codec.PolyMul(p1, p2)
+}
+
+// polyScaleInto は p の各係数に定数 c を掛けた値を dst に書き込む
+func (e *Encoder) polyScaleInto(dst, p []int, c int) {
+	e.codec.ScaleInto(dst, p, c)
+}
+
+// polyDivInPlace は buf に入れた被除数を除数 (最高次係数は1) で割り, 剰余を buf の末尾の部分スライスとして返す.
+// buf は書き換えられる. 割り当てをしないので, 呼び出し側で buf を使い回せば多数の符号化を速く行える.
+func (e *Encoder) polyDivInPlace(buf []int, divisor []int) ([]int, error) {
+	remainder, err := e.codec.DivInPlace(buf, divisor)
+	return remainder, fieldError(err)
+}
+
+// lookupGeneratorPolynomial は求めておいた生成多項式を返す. QRコードで使わない次数の場合はその場で求め,
+// 次からはそれを使う. 返す配列は共有しているので書き換えないこと.
+func (e *Encoder) lookupGeneratorPolynomial(degree int) []int {
+	return e.codec.Generator(degree)
+}
+
+// Encode はデータ data に nParity バイトの誤り訂正コード語を付けた符号語を返す (組織符号, 最初の根は α^0)
+func (e *Encoder) Encode(data []byte, nParity int) ([]byte, error) {
+	return e.codec.Encode(data, nParity)
+}
+
+// codecFrom は生成多項式の最初の根が α^firstRoot の RS 符号の Codec を返す.
+// QRコードと同じ b = 0 なら表を作っておいたものを使い, それ以外はその場で作る.
+func (e *Encoder) codecFrom(firstRoot int) (*rs.Codec, error) {
+	if firstRoot == 0 {
+		return e.codec, nil
+	}
+	codec, err := rs.NewCodec(e.field, firstRoot)
+	if err != nil {
+		return nil, newCodedError(errCodeInternal, err.Error(), noErrorPosition, "RS 符号の表を作れませんでした: %v", err)
+	}
+	return codec, nil
+}
+
+// Mask は型番1の符号語 (26バイト) にこの Encoder のマスクパターンを掛ける
+func (e *Encoder) Mask(codeword []byte) []byte {
+	return qr.Mask(codeword, e.maskBytes)
+}
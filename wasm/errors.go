@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// --- エラーコード ---
+
+// 応答の Error には, 表示用のメッセージに加えて UI が翻訳や入力欄の強調に使える決まったコードを入れる
+const (
+	errCodeArguments = "ERR_ARGUMENTS"     // 引数の数が合わない
+	errCodeEmpty     = "ERR_EMPTY"         // 入力が空
+	errCodeTooLong   = "ERR_TOO_LONG"      // 入力が長すぎる
+	errCodeSJISRange = "ERR_SJIS_RANGE"    // 漢字モードで表せない文字
+	errCodeBadLength = "ERR_BAD_LENGTH"    // バイト数が合わない
+	errCodeBadBinary = "ERR_BAD_BINARY"    // 2進数文字列として読めない
+	errCodeBadInput  = "ERR_INVALID_INPUT" // その他の入力の誤り
+	errCodeInternal  = "ERR_INTERNAL"      // 内部の誤り (panic)
+	errCodeCancelled = "ERR_CANCELLED"     // 呼び出しが取り消された (signal, HTTP の要求の取り消し)
+)
+
+const noErrorPosition = -1 // 位置を持たないエラーの Position
+
+// ErrorInfo は応答に含めるエラーの情報 (エラーがなければ null)
+type ErrorInfo struct {
+	Code     string `json:"Code"`
+	Message  string `json:"Message"`
+	Value    string `json:"Value"`    // 問題のあった値 (文字, バイト数など)
+	Position int    `json:"Position"` // 問題のあった位置 (文字や2進数の桁の番号. 位置がない場合は -1)
+}
+
+// codedError はエラーコードと問題のあった値・位置を持つエラー
+type codedError struct {
+	code     string
+	value    string
+	position int
+	format   string
+	args     []interface{}
+}
+
+func (e *codedError) Error() string {
+	return fmt.Sprintf(e.format, e.args...)
+}
+
+// localized はメッセージを言語 l で返す
+func (e *codedError) localized(l string) string {
+	return localize(l, e.format, e.args...)
+}
+
+// newCodedError はエラーコード付きのエラーを作る. メッセージは応答を作るときに呼び出しの言語にする.
+func newCodedError(code, value string, position int, format string, a ...interface{}) error {
+	return &codedError{code: code, value: value, position: position, format: format, args: a}
+}
+
+// errArgumentCount は引数の数が合わないときのエラー
+var errArgumentCount = newCodedError(errCodeArguments, "", noErrorPosition, "Invalid number of arguments")
+
+// errCancelled は呼び出しが取り消されたときのエラー
+var errCancelled = newCodedError(errCodeCancelled, "", noErrorPosition, "処理が取り消されました.")
+
+// hasErrorCode は err が code のエラー (codedError) を包んでいるかどうかを返す
+func hasErrorCode(err error, code string) bool {
+	var coded *codedError
+	return errors.As(err, &coded) && coded.code == code
+}
+
+// errorInfo は err を応答用の ErrorInfo にする. メッセージは err 全体 (包んだ説明を含む) を呼び出しの言語で使い,
+// コードは包まれた codedError から取る (ない場合は ERR_INVALID_INPUT).
+func (c *call) errorInfo(err error) *ErrorInfo {
+	info := &ErrorInfo{Code: errCodeBadInput, Message: localizedMessage(c.opts.Locale, err), Position: noErrorPosition}
+	var coded *codedError
+	if errors.As(err, &coded) {
+		info.Code, info.Value, info.Position = coded.code, coded.value, coded.position
+	}
+	return info
+}
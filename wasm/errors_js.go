@@ -0,0 +1,29 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// recovered は fn の中で panic が起きても WASM のインスタンスを止めず, ERR_INTERNAL のエラー応答を返す関数にする.
+// 既定の設定の Encoder を作れていない場合も fn を呼ばずに ERR_INTERNAL を返す.
+func recovered(name string, fn func(c *call, args []js.Value) interface{}) func(c *call, args []js.Value) interface{} {
+	return func(c *call, args []js.Value) (response interface{}) {
+		if err := checkEncoder(); err != nil {
+			return c.errorResponse(err)
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				response = c.errorResponse(newCodedError(errCodeInternal, fmt.Sprint(r), noErrorPosition, "%s の処理中に内部エラーが発生しました: %v", name, r))
+			}
+		}()
+		return fn(c, args)
+	}
+}
+
+// errorResponse はエラー情報を含む応答を作成する
+func (c *call) errorResponse(err error) interface{} {
+	return c.respond(TemplateData{Error: c.errorInfo(err)})
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// --- 行列からの符号語の読み取り (復号の前半) ---
+
+// parseMatrixInput は行列の入力を解析する.
+// JSON配列 ([[0,1,...],...]) と, 0/1 を行優先で並べた文字列 (空白・改行は無視) の両方を受け付ける.
+func parseMatrixInput(input string, size int) ([][]int, error) {
+	trimmed := strings.TrimSpace(input)
+	var modules [][]int
+
+	if strings.HasPrefix(trimmed, "[") {
+		var err error
+		if modules, err = parseJSONIntMatrix(trimmed); err != nil {
+			return nil, errorf("行列のJSONの解析に失敗しました: %v", err)
+		}
+		if len(modules) != size {
+			return nil, errorf("行列は%d行である必要がありますが, %d行でした.", size, len(modules))
+		}
+		for r, row := range modules {
+			if len(row) != size {
+				return nil, errorf("行列の%d行目は%d列である必要がありますが, %d列でした.", r, size, len(row))
+			}
+		}
+	} else {
+		cleaned := strings.Map(func(r rune) rune {
+			if r == ' ' || r == '\n' || r == '\r' || r == '\t' {
+				return -1
+			}
+			return r
+		}, trimmed)
+		if len(cleaned) != size*size {
+			return nil, errorf("行列は%dモジュールである必要がありますが, %dモジュールでした.", size*size, len(cleaned))
+		}
+		modules = make([][]int, size)
+		for r := 0; r < size; r++ {
+			modules[r] = make([]int, size)
+			for c := 0; c < size; c++ {
+				switch cleaned[r*size+c] {
+				case '0':
+					modules[r][c] = qr.ModuleLight
+				case '1':
+					modules[r][c] = qr.ModuleDark
+				default:
+					modules[r][c] = -1 // 下の値の検査でエラーにする
+				}
+			}
+		}
+	}
+
+	for r, row := range modules {
+		for c, v := range row {
+			if v != qr.ModuleLight && v != qr.ModuleDark {
+				return nil, errorf("(%d, %d) のモジュールの値は0か1である必要があります.", r, c)
+			}
+		}
+	}
+	return modules, nil
+}
+
+// decodeFormatInformation は読み取った15ビットに最も近い正しい形式情報を探し, 誤り訂正レベルとマスクパターン番号を返す.
+// 形式情報のBCH符号は3ビットまでの誤りを訂正できる.
+func decodeFormatInformation(readBits int) (eccBits, mask, distance int) {
+	distance = 16
+	for candidate := 0; candidate < 32; candidate++ {
+		d := bits.OnesCount(uint(qr.FormatInformation(candidate>>3, candidate&7) ^ readBits))
+		if d < distance {
+			eccBits, mask, distance = candidate>>3, candidate&7, d
+		}
+	}
+	return eccBits, mask, distance
+}
+
+// processExtractCodewords は行列から符号語を読み取り, マスクを解除する
+func (c *call) processExtractCodewords(matrixInput string) (TemplateData, error) {
+	modules, err := parseMatrixInput(matrixInput, symbolSize)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	// 機能パターンの識別: 3つの位置検出パターンが正しい位置にあるか確認する
+	reference := qr.NewMatrix(symbolSize)
+	reference.PlaceFunctionPatterns()
+	for _, corner := range []qr.Position{{Row: 0, Col: 0}, {Row: 0, Col: symbolSize - 7}, {Row: symbolSize - 7, Col: 0}} {
+		for r := corner.Row; r < corner.Row+7; r++ {
+			for c := corner.Col; c < corner.Col+7; c++ {
+				if modules[r][c] != reference.Modules[r][c] {
+					return TemplateData{}, errorf("(%d, %d) が位置検出パターンと一致しません. 行列の向きを確認してください.", r, c)
+				}
+			}
+		}
+	}
+
+	// 形式情報の読み取り (2か所のうち, より正しい形式情報に近い方を採用する)
+	first, second := qr.FormatInformationPositions(symbolSize)
+	eccBits, mask, distance := -1, -1, 16
+	readFormatBits := 0
+	for _, positions := range [][15]qr.Position{first, second} {
+		read := 0
+		for i, pos := range positions {
+			read |= modules[pos.Row][pos.Col] << i
+		}
+		e, m, d := decodeFormatInformation(read)
+		if d < distance {
+			eccBits, mask, distance, readFormatBits = e, m, d, read
+		}
+	}
+	if distance > 3 {
+		return TemplateData{}, errorf("形式情報を読み取れませんでした (誤りが多すぎます).")
+	}
+	if eccBits != eccLevelBits {
+		return TemplateData{}, errorf("誤り訂正レベルL以外には対応していません (形式情報の誤り訂正レベル: %02b).", eccBits)
+	}
+
+	// データモジュールを配置順に読み取る
+	positions := reference.DataModulePositions()
+	maskedBytes := make([]byte, len(positions)/8)
+	for i := range maskedBytes {
+		for bit := 0; bit < 8; bit++ {
+			pos := positions[i*8+bit]
+			maskedBytes[i] |= byte(modules[pos.Row][pos.Col]) << (7 - bit)
+		}
+	}
+
+	maskBytes, _ := deriveMaskPatternBytes(mask)
+	codewordBytes := make([]byte, len(maskedBytes))
+	for i := range maskedBytes {
+		codewordBytes[i] = maskedBytes[i] ^ maskBytes[i]
+	}
+
+	var data TemplateData
+	data.Matrix.Size = symbolSize
+	data.Matrix.MaskedMatrix = modules
+	data.Matrix.FormatInformation = fmt.Sprintf("%015b", readFormatBits)
+	data.Mask.PatternNumber = mask
+	data.Mask.Condition = qr.MaskConditionTexts[mask]
+	data.Mask.MaskHex = formatBytesToHex(maskBytes)
+	data.Intermediate.MaskedCodewordHex = formatBytesToHex(maskedBytes)
+	data.Intermediate.MaskedCodewordBinary = formatBytesToBinary(maskedBytes)
+	data.Intermediate.MaskPatternHex = data.Mask.MaskHex
+	data.Intermediate.CodewordHex = formatBytesToHex(codewordBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(codewordBytes)
+
+	return data, nil
+}
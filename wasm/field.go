@@ -0,0 +1,338 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/gf"
+)
+
+// --- 有限体の計算 (フロントエンドの電卓用) ---
+
+// FieldData は体の演算の結果
+type FieldData struct {
+	M           int    `json:"M"`         // 体 GF(2^m) の次数
+	Operation   string `json:"Operation"` // "add", "mul", "div", "inv", "pow", "order", "map"
+	Operands    []int  `json:"Operands"`
+	Result      int    `json:"Result"`
+	ResultHex   string `json:"ResultHex"`
+	ResultAlpha string `json:"ResultAlpha"` // α^k の形 (0の場合は "0")
+	Expression  string `json:"Expression"`  // 計算の過程 (α の指数での計算)
+
+	Polynomial     int    `json:"Polynomial"`     // 体を作る原始多項式 (例: 285 = 0x11D)
+	PolynomialText string `json:"PolynomialText"` // 原始多項式を x^8 + ... の形で表したもの
+
+	ExpTable []int      `json:"ExpTable"` // ExpTable[k] = α^k (k = 0〜2^m-2)
+	LogTable []int      `json:"LogTable"` // LogTable[v] = log_α v (v = 0 は定義されないので -1)
+	ExpGrid  [][]string `json:"ExpGrid"`  // ExpTable を16列に並べたもの (GF(2^8) では行が上位4ビット, 列が下位4ビット. 16進数)
+	LogGrid  [][]string `json:"LogGrid"`  // LogTable を16列に並べたもの (10進数. v = 0 は "-")
+
+	ConjugacyClasses []ConjugacyClass `json:"ConjugacyClasses"`
+
+	ElementOrder   int  `json:"ElementOrder"`   // 元の乗法的な位数
+	IsPrimitive    bool `json:"IsPrimitive"`    // 原始元かどうか
+	PrimitiveCount int  `json:"PrimitiveCount"` // 体の原始元の個数 (オイラーの φ(2^m-1))
+
+	TargetPolynomial     int    `json:"TargetPolynomial"` // 変換先の体を作る原始多項式
+	TargetPolynomialText string `json:"TargetPolynomialText"`
+	IsomorphismRoot      int    `json:"IsomorphismRoot"`      // 変換元の α に対応する変換先の元 β (変換元の原始多項式の根)
+	IsomorphismRootAlpha string `json:"IsomorphismRootAlpha"` // β を変換先の α^k で表したもの
+	Mapping              []int  `json:"Mapping"`              // Mapping[v] = 変換元の元 v に対応する変換先の元
+}
+
+// ConjugacyClass は共役な元の組 {α^k, α^(2k), α^(4k), ...} とその最小多項式.
+// 生成多項式 g(x) の根 α^i を GF(2) 係数で表そうとすると, 共役元をまとめた最小多項式の積になる.
+type ConjugacyClass struct {
+	Exponents             []int  `json:"Exponents"`         // 共役元の α の指数 (最初が代表元)
+	Elements              []int  `json:"Elements"`          // 共役元の値
+	MinimalPolynomial     int    `json:"MinimalPolynomial"` // 最小多項式のビット列 (例: 0x11D)
+	MinimalPolynomialText string `json:"MinimalPolynomialText"`
+	Degree                int    `json:"Degree"` // 最小多項式の次数 (= 共役元の個数)
+}
+
+// processFieldOperation は体 f で演算を1つ行う. pow の場合, 2番目の値は指数 (負でもよい) とする.
+func (c *call) processFieldOperation(f *gf.Field, operation string, operands ...int) (TemplateData, error) {
+	elementCount := len(operands)
+	if operation == "pow" {
+		elementCount = 1
+	}
+	for _, v := range operands[:elementCount] {
+		if !f.Contains(v) {
+			return TemplateData{}, errorf("GF(2^%d) の要素は0〜%dで指定してください: %d", f.M(), f.Order(), v)
+		}
+	}
+
+	var result int
+	var expression string
+	switch operation {
+	case "add":
+		a, b := operands[0], operands[1]
+		result = f.Add(a, b)
+		expression = fmt.Sprintf("%0*b XOR %0*b = %0*b", f.M(), a, f.M(), b, f.M(), result)
+	case "mul":
+		a, b := operands[0], operands[1]
+		result = f.Mul(a, b)
+		if a == 0 || b == 0 {
+			expression = "0を含む積は0"
+		} else {
+			expression = fmt.Sprintf("α^%d × α^%d = α^((%d+%d) mod %d) = α^%d", f.Log(a), f.Log(b), f.Log(a), f.Log(b), f.Order(), f.Log(result))
+		}
+	case "div":
+		a, b := operands[0], operands[1]
+		var err error
+		if result, err = f.Div(a, b); err != nil {
+			return TemplateData{}, fieldError(err)
+		}
+		if a == 0 {
+			expression = "0を割った商は0"
+		} else {
+			expression = fmt.Sprintf("α^%d ÷ α^%d = α^((%d-%d) mod %d) = α^%d", f.Log(a), f.Log(b), f.Log(a), f.Log(b), f.Order(), f.Log(result))
+		}
+	case "inv":
+		a := operands[0]
+		if a == 0 {
+			return TemplateData{}, errorf("0には逆元がありません.")
+		}
+		var err error
+		if result, err = f.Inv(a); err != nil {
+			return TemplateData{}, fieldError(err)
+		}
+		expression = fmt.Sprintf("(α^%d)^(-1) = α^((%d-%d) mod %d) = α^%d", f.Log(a), f.Order(), f.Log(a), f.Order(), f.Log(result))
+	case "pow":
+		a, n := operands[0], operands[1]
+		if a == 0 && n < 0 {
+			return TemplateData{}, errorf("0の負の累乗は定義されません.")
+		}
+		result = f.Pow(a, n)
+		if a == 0 {
+			expression = fmt.Sprintf("0^%d = %d", n, result)
+		} else {
+			expression = fmt.Sprintf("(α^%d)^%d = α^((%d×%d) mod %d) = α^%d", f.Log(a), n, f.Log(a), n, f.Order(), f.Log(result))
+		}
+	default:
+		return TemplateData{}, errorf("不明な演算です: %s", operation)
+	}
+
+	var data TemplateData
+	data.Field = FieldData{
+		M:           f.M(),
+		Polynomial:  f.Polynomial(),
+		Operation:   operation,
+		Operands:    operands,
+		Result:      result,
+		ResultHex:   fmt.Sprintf("%0*X", (f.M()+3)/4, result),
+		ResultAlpha: f.Alpha(result),
+		Expression:  expression,
+	}
+	return data, nil
+}
+
+// setFieldPolynomial は8次の原始多項式 poly の体で現在の設定 currentEncoder を作り直し, 新しい Encoder を返す.
+// 以降の呼び出しから新しい体を使う (実行中の呼び出しは始めに受け取った Encoder のまま).
+// poly が原始多項式でない場合は設定を変えずにエラーを返す.
+func setFieldPolynomial(poly int) (*Encoder, error) {
+	e, err := NewEncoder(poly, currentEncoder.Load().maskPattern)
+	if err != nil {
+		return nil, err
+	}
+	currentEncoder.Store(e)
+	return e, nil
+}
+
+// processSetPrimitivePolynomial は体を作る原始多項式を poly に変え, 新しい体の指数表と対数表を返す
+func (c *call) processSetPrimitivePolynomial(poly int) (TemplateData, error) {
+	e, err := setFieldPolynomial(poly)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	return c.processGFTables(e.field, false), nil
+}
+
+// polynomialText は係数が0か1の多項式 (ビット列) を x^8 + x^4 + ... + 1 の形で表す
+func polynomialText(poly int) string {
+	var terms []string
+	for k := 16; k >= 0; k-- {
+		if poly&(1<<k) == 0 {
+			continue
+		}
+		switch k {
+		case 0:
+			terms = append(terms, "1")
+		case 1:
+			terms = append(terms, "x")
+		default:
+			terms = append(terms, fmt.Sprintf("x^%d", k))
+		}
+	}
+	if len(terms) == 0 {
+		return "0"
+	}
+	return strings.Join(terms, " + ")
+}
+
+// processGFTables は体 f の指数表 (α^k) と対数表を返す. withGrid が true なら16列の表も作る (m ≦ 8 の場合だけ).
+func (c *call) processGFTables(f *gf.Field, withGrid bool) TemplateData {
+	var data TemplateData
+	data.Field.M = f.M()
+	data.Field.Polynomial = f.Polynomial()
+	data.Field.PolynomialText = polynomialText(f.Polynomial())
+	data.Field.ExpTable = make([]int, f.Order())
+	for k := range data.Field.ExpTable {
+		data.Field.ExpTable[k] = f.Exp(k)
+	}
+	data.Field.LogTable = make([]int, f.Order()+1)
+	for v := range data.Field.LogTable {
+		data.Field.LogTable[v] = f.Log(v) // v = 0 は -1
+	}
+
+	if withGrid && f.M() <= 8 {
+		columns := 16
+		if f.Order()+1 < columns {
+			columns = f.Order() + 1
+		}
+		rows := (f.Order() + 1) / columns
+		digits := (f.M() + 3) / 4
+		data.Field.ExpGrid = make([][]string, rows)
+		data.Field.LogGrid = make([][]string, rows)
+		for r := 0; r < rows; r++ {
+			data.Field.ExpGrid[r] = make([]string, columns)
+			data.Field.LogGrid[r] = make([]string, columns)
+			for c := 0; c < columns; c++ {
+				k := r*columns + c
+				if k < f.Order() {
+					data.Field.ExpGrid[r][c] = fmt.Sprintf("%0*X", digits, f.Exp(k))
+				}
+				if k == 0 {
+					data.Field.LogGrid[r][c] = "-"
+				} else {
+					data.Field.LogGrid[r][c] = fmt.Sprintf("%d", f.Log(k))
+				}
+			}
+		}
+	}
+	return data
+}
+
+// processConjugacyClasses は体 f の元を共役類に分け, それぞれの最小多項式を求める.
+// element が0以上ならその元を含む共役類だけを返す (0 の最小多項式は x).
+func (c *call) processConjugacyClasses(f *gf.Field, element int) (TemplateData, error) {
+	if element >= 0 && !f.Contains(element) {
+		return TemplateData{}, errorf("GF(2^%d) の要素は0〜%dで指定してください: %d", f.M(), f.Order(), element)
+	}
+
+	var data TemplateData
+	data.Field.M = f.M()
+	data.Field.Polynomial = f.Polynomial()
+	data.Field.PolynomialText = polynomialText(f.Polynomial())
+	if element == 0 {
+		data.Field.ConjugacyClasses = []ConjugacyClass{{Exponents: []int{}, Elements: []int{0}, MinimalPolynomial: 2, MinimalPolynomialText: polynomialText(2), Degree: 1}}
+		return data, nil
+	}
+
+	representatives := []int{}
+	if element > 0 {
+		representatives = append(representatives, f.Log(element))
+	} else {
+		seen := make([]bool, f.Order())
+		for k := 0; k < f.Order(); k++ {
+			if seen[k] {
+				continue
+			}
+			for _, e := range f.Conjugates(k) {
+				seen[e] = true
+			}
+			representatives = append(representatives, k)
+		}
+	}
+	for _, k := range representatives {
+		exponents := f.Conjugates(k)
+		elements := make([]int, len(exponents))
+		for i, e := range exponents {
+			elements[i] = f.Exp(e)
+		}
+		minimal := f.MinimalPolynomial(k)
+		data.Field.ConjugacyClasses = append(data.Field.ConjugacyClasses, ConjugacyClass{
+			Exponents:             exponents,
+			Elements:              elements,
+			MinimalPolynomial:     minimal,
+			MinimalPolynomialText: polynomialText(minimal),
+			Degree:                len(exponents),
+		})
+	}
+	return data, nil
+}
+
+// processElementOrder は体 f の元 a の位数を求め, 原始元かどうかを調べる
+func (c *call) processElementOrder(f *gf.Field, a int) (TemplateData, error) {
+	if !f.Contains(a) {
+		return TemplateData{}, errorf("GF(2^%d) の要素は0〜%dで指定してください: %d", f.M(), f.Order(), a)
+	}
+	if a == 0 {
+		return TemplateData{}, errorf("0は乗法群の元ではないので位数がありません.")
+	}
+
+	primitiveCount := 0
+	for k := 0; k < f.Order(); k++ {
+		if gf.GCD(k, f.Order()) == 1 {
+			primitiveCount++
+		}
+	}
+
+	order := f.ElementOrder(a)
+	k := f.Log(a)
+	var data TemplateData
+	data.Field = FieldData{
+		M:              f.M(),
+		Polynomial:     f.Polynomial(),
+		PolynomialText: polynomialText(f.Polynomial()),
+		Operation:      "order",
+		Operands:       []int{a},
+		Result:         order,
+		ResultAlpha:    f.Alpha(a),
+		Expression:     fmt.Sprintf("ord(α^%d) = %d / gcd(%d, %d) = %d / %d = %d", k, f.Order(), k, f.Order(), f.Order(), gf.GCD(k, f.Order()), order),
+		ElementOrder:   order,
+		IsPrimitive:    f.IsPrimitive(a),
+		PrimitiveCount: primitiveCount,
+	}
+	return data, nil
+}
+
+// processFieldIsomorphism は体 from の元を, 別の原始多項式で作った体 to の元に変換する.
+// 例えば QRコード (0x11D) とデータマトリックス (0x12D) の GF(2^8) の間で値を読み替えるのに使う.
+// value が0以上ならその元の変換結果も返す.
+func (c *call) processFieldIsomorphism(from, to *gf.Field, value int) (TemplateData, error) {
+	mapping, root, err := isomorphism(from, to)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	var data TemplateData
+	data.Field = FieldData{
+		M:                    from.M(),
+		Operation:            "map",
+		Polynomial:           from.Polynomial(),
+		PolynomialText:       polynomialText(from.Polynomial()),
+		TargetPolynomial:     to.Polynomial(),
+		TargetPolynomialText: polynomialText(to.Polynomial()),
+		IsomorphismRoot:      root,
+		IsomorphismRootAlpha: to.Alpha(root),
+		Mapping:              mapping,
+	}
+	if value < 0 {
+		return data, nil
+	}
+	if !from.Contains(value) {
+		return TemplateData{}, errorf("GF(2^%d) の要素は0〜%dで指定してください: %d", from.M(), from.Order(), value)
+	}
+	data.Field.Operands = []int{value}
+	data.Field.Result = mapping[value]
+	data.Field.ResultHex = fmt.Sprintf("%0*X", (to.M()+3)/4, mapping[value])
+	data.Field.ResultAlpha = to.Alpha(mapping[value])
+	if value == 0 {
+		data.Field.Expression = "0は0に移る"
+	} else {
+		k := from.Log(value)
+		data.Field.Expression = fmt.Sprintf("α^%d ↦ β^%d = (%s)^%d = %s", k, k, to.Alpha(root), k, to.Alpha(mapping[value]))
+	}
+	return data, nil
+}
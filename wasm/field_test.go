@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+// setPrimitivePolynomial が新しい体の表を返し, 以降の呼び出しだけが新しい体を使うことを確かめる
+
+func TestProcessSetPrimitivePolynomial(t *testing.T) {
+	t.Cleanup(func() { currentEncoder.Store(defaultEncoder) })
+	const poly = 0x12D
+	c := defaultCall()
+	data, err := c.processSetPrimitivePolynomial(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Field.Polynomial != poly {
+		t.Fatalf("Polynomial = %#X, want %#X", data.Field.Polynomial, poly)
+	}
+	// α^(k+1) = α^k · x を poly で割った余り
+	v := 1
+	for k, got := range data.Field.ExpTable {
+		if got != v {
+			t.Fatalf("ExpTable[%d] = %d, want %d", k, got, v)
+		}
+		if data.Field.LogTable[v] != k {
+			t.Fatalf("LogTable[%d] = %d, want %d", v, data.Field.LogTable[v], k)
+		}
+		v <<= 1
+		if v&0x100 != 0 {
+			v ^= poly
+		}
+	}
+
+	// 実行中の呼び出しは始めの体のまま, 新しい呼び出しから新しい体を使う
+	if got := c.processConstants().Constants.FieldPolynomial; got != primitivePolynomial {
+		t.Errorf("実行中の呼び出しの FieldPolynomial = %#X, want %#X", got, primitivePolynomial)
+	}
+	if got := defaultCall().processConstants().Constants.FieldPolynomial; got != poly {
+		t.Errorf("新しい呼び出しの FieldPolynomial = %#X, want %#X", got, poly)
+	}
+
+	if _, err := c.processSetPrimitivePolynomial(0x100); err == nil {
+		t.Error("原始多項式でない 0x100 を受け付けました")
+	}
+	if currentEncoder.Load().polynomial != poly {
+		t.Error("エラーのあとに設定が変わりました")
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// --- ヘルパー関数 ---
+
+// bitStreamToBytes は '0' と '1' の文字列を8ビットずつバイトにする.
+// 長さが8の倍数でないか, 0 と 1 以外の文字があればエラーを返す (位置は s の中の位置).
+func bitStreamToBytes(s string) ([]byte, error) {
+	if len(s)%8 != 0 {
+		return nil, newCodedError(errCodeBadBinary, fmt.Sprint(len(s)), noErrorPosition, "2進数文字列の長さが8の倍数ではありません")
+	}
+	var b []byte
+	for i := 0; i < len(s); i += 8 {
+		val, err := strconv.ParseUint(s[i:i+8], 2, 8)
+		if err != nil {
+			return nil, newCodedError(errCodeBadBinary, s[i:i+8], i, "2進数文字列のパースに失敗しました: %v", err)
+		}
+		b = append(b, byte(val))
+	}
+	return b, nil
+}
+
+// binaryStringToBytes は2進数文字列をバイト列にする. 読み取り方は呼び出しのオプション parsing に従う.
+func (c *call) binaryStringToBytes(binaryStr string) ([]byte, error) {
+	switch c.opts.Parsing {
+	case parsingStrict:
+		return strictBinaryToBytes(binaryStr)
+	case parsingLenient:
+		return lenientBinaryToBytes(binaryStr)
+	}
+
+	cleanedBinary := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\n' || r == '\r' || r == '\t' {
+			return -1
+		}
+		return r
+	}, binaryStr)
+
+	return bitStreamToBytes(cleanedBinary)
+}
+
+func hexStringToBytes(hexStr string) ([]byte, error) {
+	cleanedHex := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\n' || r == '\r' || r == '\t' {
+			return -1
+		}
+		return r
+	}, hexStr)
+
+	if len(cleanedHex)%2 != 0 {
+		return nil, errorf("16進数文字列の長さが奇数です")
+	}
+	decoded, err := hex.DecodeString(cleanedHex)
+	if err != nil {
+		return nil, errorf("16進数文字列のデコードに失敗しました: %v", err)
+	}
+	return decoded, nil
+}
+func formatBytesToHex(data []byte) string {
+	var hexParts []string
+	for _, b := range data {
+		hexParts = append(hexParts, fmt.Sprintf("%02X", b))
+	}
+	return strings.Join(hexParts, " ")
+}
+func formatBytesToBinary(data []byte) string {
+	var binParts []string
+	for _, b := range data {
+		binParts = append(binParts, fmt.Sprintf("%08b", b))
+	}
+	return strings.Join(binParts, " ")
+}
+
+func bytesToInts(b []byte) []int {
+	ints := make([]int, len(b))
+	for i, v := range b {
+		ints[i] = int(v)
+	}
+	return ints
+}
+
+func intsToBytes(i []int) []byte {
+	bytes := make([]byte, len(i))
+	for j, v := range i {
+		bytes[j] = byte(v)
+	}
+	return bytes
+}
+
+// 多項式の係数の表し方 (setCoefficientFormat で切り替える)
+const (
+	coefficientAlpha   = "alpha"   // α^k
+	coefficientDecimal = "decimal" // 10進数
+	coefficientHex     = "hex"     // 16進数
+)
+
+var coefficientFormat = coefficientAlpha
+
+// setCoefficientFormat は formatPolynomial が出力する係数の表し方を切り替える
+func setCoefficientFormat(format string) error {
+	switch format {
+	case coefficientAlpha, coefficientDecimal, coefficientHex:
+		coefficientFormat = format
+		return nil
+	}
+	return errorf("不明な係数の表し方です: %s (alpha, decimal, hex のいずれかを指定してください).", format)
+}
+
+// formatCoefficient は係数 (1より大きい体の要素) を現在の表し方でLaTeXにする
+func (c *call) formatCoefficient(coeff int) string {
+	switch c.coefficientFormat {
+	case coefficientDecimal:
+		return fmt.Sprintf("%d", coeff)
+	case coefficientHex:
+		return fmt.Sprintf("\\mathtt{%02X}", coeff)
+	}
+	return fmt.Sprintf("\\alpha^{%d}", c.log[coeff])
+}
+
+// polynomialCoefficients は多項式の係数を10進数, 16進数, α の指数の配列にする
+func (c *call) polynomialCoefficients(p []int) PolynomialCoefficients {
+	coeffs := PolynomialCoefficients{
+		Decimal:   append([]int{}, p...),
+		Hex:       make([]string, len(p)),
+		Exponents: make([]int, len(p)),
+	}
+	for i, v := range p {
+		coeffs.Hex[i] = fmt.Sprintf("%02X", v)
+		if v == 0 {
+			coeffs.Exponents[i] = -1
+		} else {
+			coeffs.Exponents[i] = c.log[v]
+		}
+	}
+	return coeffs
+}
+
+func (c *call) formatPolynomial(p []int, varName string) string {
+	var b strings.Builder
+	isFirstTerm := true
+	for i := 0; i < len(p); i++ {
+		coeff := p[i]
+		power := len(p) - 1 - i
+		if coeff == 0 {
+			continue
+		}
+
+		if !isFirstTerm {
+			b.WriteString(" + ")
+		}
+		isFirstTerm = false
+
+		// 係数が1の場合は表記を省略 (ただし定数項を除く)
+		if coeff > 1 {
+			b.WriteString(c.formatCoefficient(coeff))
+		}
+
+		if power > 0 {
+			if coeff > 1 {
+				b.WriteString(" \\cdot ") // 係数と変数の間にドットを追加
+			}
+			b.WriteString(fmt.Sprintf("%s", varName))
+			if power > 1 {
+				b.WriteString(fmt.Sprintf("^{%d}", power))
+			}
+		} else { // 定数項
+			if coeff == 1 {
+				b.WriteString("1")
+			}
+		}
+	}
+	if isFirstTerm {
+		return "0"
+	}
+	return b.String()
+}
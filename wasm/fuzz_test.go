@@ -0,0 +1,75 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// API の入口 (apiHandlers) と入力の文字列の読み取りに任意の入力を与えても, panic (ERR_INTERNAL) にならないことを確かめる.
+// go test -fuzz=FuzzAPI ./wasm のように1つずつ実行する.
+
+func FuzzAPI(f *testing.F) {
+	f.Add("encode", "漢字")
+	f.Add("ecc", "10000000 00101100 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111 11111111")
+	f.Add("mask", "")
+	f.Add("decode", "00 01 02")
+	f.Fuzz(func(t *testing.T, method, input string) {
+		fn, ok := apiHandlers[method]
+		if !ok {
+			return
+		}
+		if _, err := callAPI(context.Background(), method, apiRequest{Input: input}, fn); hasErrorCode(err, errCodeInternal) {
+			t.Fatalf("%s(%q): %v", method, input, err)
+		}
+	})
+}
+
+func FuzzBinaryStringToBytes(f *testing.F) {
+	for _, parsing := range []string{"", parsingStrict, parsingLenient} {
+		f.Add(parsing, "10000000 00101100")
+		f.Add(parsing, "0b1000_0000, 0x2C; 1")
+		f.Add(parsing, "1000000")
+	}
+	f.Fuzz(func(t *testing.T, parsing, input string) {
+		opts := defaultQROptions()
+		opts.Parsing = parsing
+		c := newCall(defaultEncoder, opts)
+		b, err := c.binaryStringToBytes(input)
+		if err != nil {
+			return
+		}
+		// 読めたバイト列は2進数で書き直すと, どの読み取り方でも同じバイト列に戻る
+		again, err := c.binaryStringToBytes(formatBytesToBinary(b))
+		if err != nil || !bytes.Equal(again, b) {
+			t.Fatalf("binaryStringToBytes(%q) = % X を書き直して読むと % X, %v", input, b, again, err)
+		}
+	})
+}
+
+func FuzzParsePolynomial(f *testing.F) {
+	for _, seed := range []string{"a^5 x^3 + a^2 x + 1", "\\alpha^{25} x^{2} + \\mathtt{1D}", "0x1D x + 29", "x^2+x^2", "0", "a^300x^255"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		p, err := defaultEncoder.parsePolynomial(input)
+		if err != nil {
+			return
+		}
+		if err := validatePolynomial(p); err != nil {
+			t.Fatalf("parsePolynomial(%q) = %v: %v", input, p, err)
+		}
+		// formatPolynomial の LaTeX も読めるので, 書き直して読むと同じ多項式に戻る
+		again, err := defaultEncoder.parsePolynomial(defaultCall().formatPolynomial(p, "x"))
+		if err != nil || len(again) != len(p) {
+			t.Fatalf("parsePolynomial(%q) = %v を書き直して読むと %v, %v", input, p, again, err)
+		}
+		for i := range p {
+			if again[i] != p[i] {
+				t.Fatalf("parsePolynomial(%q) = %v を書き直して読むと %v", input, p, again)
+			}
+		}
+	})
+}
@@ -0,0 +1,177 @@
+//go:build ignore
+
+// gen_dts.go は TemplateData などの構造体の json タグから, フロントエンド用の TypeScript の型定義 (docs/qrcode.d.ts) と
+// 応答を JSON.parse して返すだけの薄い JS のラッパー (docs/qrcode.js) を作る.
+// response.go の go:generate から wasm ディレクトリで `go run gen_dts.go` として実行する.
+// go:embed はパッケージの外を参照できないので, 型定義は wasm/qrcode.d.ts にも書き出す.
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// responseTypes は TemplateData 以外を返す関数の応答の型
+var responseTypes = map[string]string{
+	"checkAnswer":        "AnswerCheckData",
+	"getTypeDefinitions": "string",
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return strings.HasSuffix(fi.Name(), ".go") && !strings.HasSuffix(fi.Name(), "_test.go") && !strings.HasPrefix(fi.Name(), "gen_")
+	}, parser.ParseComments)
+	if err != nil {
+		fail(err)
+	}
+	pkg, ok := pkgs["main"]
+	if !ok {
+		fail(fmt.Errorf("package main が見つかりません"))
+	}
+
+	structs := map[string]*ast.StructType{}
+	var functions []string
+	for _, file := range pkg.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				if st, ok := n.Type.(*ast.StructType); ok && n.Name.IsExported() && hasJSONTags(st) {
+					structs[n.Name.Name] = st
+				}
+			case *ast.CallExpr:
+				// exportFunction("name", wrapper) で登録している関数の名前を集める
+				if ident, ok := n.Fun.(*ast.Ident); ok && ident.Name == "exportFunction" && len(n.Args) == 2 {
+					if lit, ok := n.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						name, _ := strconv.Unquote(lit.Value)
+						functions = append(functions, name)
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	names := make([]string, 0, len(structs))
+	for name := range structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var dts strings.Builder
+	dts.WriteString("// Code generated by gen_dts.go; DO NOT EDIT.\n\n")
+	for _, name := range names {
+		fmt.Fprintf(&dts, "export interface %s {\n", name)
+		for _, field := range structs[name].Fields.List {
+			key := jsonKey(field)
+			if key == "" {
+				continue
+			}
+			fmt.Fprintf(&dts, "  %s: %s;\n", key, tsType(field.Type, structs))
+		}
+		dts.WriteString("}\n\n")
+	}
+	for _, name := range functions {
+		fmt.Fprintf(&dts, "export declare function %s(...args: unknown[]): %s;\n", name, responseType(name))
+		fmt.Fprintf(&dts, "export declare function %sAsync(...args: unknown[]): Promise<%s>;\n", name, responseType(name))
+	}
+
+	var js strings.Builder
+	js.WriteString("// Code generated by gen_dts.go; DO NOT EDIT.\n")
+	js.WriteString("// Wasm が登録したグローバル関数を呼び, JSON 文字列の応答をオブジェクトにして返す.\n\n")
+	js.WriteString("const parse = (response) => (typeof response === 'string' ? JSON.parse(response) : response);\n\n")
+	for _, name := range functions {
+		if responseType(name) == "string" {
+			fmt.Fprintf(&js, "export const %s = (...args) => globalThis.%s(...args);\n", name, name)
+			fmt.Fprintf(&js, "export const %sAsync = (...args) => globalThis.%sAsync(...args);\n", name, name)
+			continue
+		}
+		fmt.Fprintf(&js, "export const %s = (...args) => parse(globalThis.%s(...args));\n", name, name)
+		fmt.Fprintf(&js, "export const %sAsync = (...args) => globalThis.%sAsync(...args).then(parse);\n", name, name)
+	}
+
+	for _, path := range []string{"../docs/qrcode.d.ts", "qrcode.d.ts"} {
+		if err := os.WriteFile(path, []byte(dts.String()), 0o644); err != nil {
+			fail(err)
+		}
+	}
+	if err := os.WriteFile("../docs/qrcode.js", []byte(js.String()), 0o644); err != nil {
+		fail(err)
+	}
+}
+
+// responseType は関数 name の応答の型を返す
+func responseType(name string) string {
+	if t, ok := responseTypes[name]; ok {
+		return t
+	}
+	return "TemplateData"
+}
+
+// hasJSONTags は構造体に json タグの付いたフィールドがあるか (応答に使う構造体か) を返す
+func hasJSONTags(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if jsonKey(field) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonKey はフィールドの json タグの名前を返す (タグがない, または "-" の場合は空文字列)
+func jsonKey(field *ast.Field) string {
+	if field.Tag == nil || len(field.Names) == 0 {
+		return ""
+	}
+	tag, _ := strconv.Unquote(field.Tag.Value)
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// tsType は Go の型を TypeScript の型にする (nil になりうるスライスとポインタは null も許す)
+func tsType(expr ast.Expr, structs map[string]*ast.StructType) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return "string"
+		case "bool":
+			return "boolean"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "byte", "float32", "float64":
+			return "number"
+		}
+		if _, ok := structs[t.Name]; ok {
+			return t.Name
+		}
+		return "unknown"
+	case *ast.StarExpr:
+		return tsType(t.X, structs) + " | null"
+	case *ast.ArrayType:
+		element := tsType(t.Elt, structs)
+		if strings.Contains(element, "|") {
+			element = "(" + element + ")"
+		}
+		if t.Len != nil {
+			return element + "[]"
+		}
+		return element + "[] | null"
+	case *ast.MapType:
+		return "Record<string, " + tsType(t.Value, structs) + "> | null"
+	}
+	return "unknown"
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "gen_dts:", err)
+	os.Exit(1)
+}
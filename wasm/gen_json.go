@@ -0,0 +1,203 @@
+//go:build ignore
+
+// gen_json.go は応答の構造体 (TemplateData から辿れるもの) を JSON にする appendJSON メソッドを
+// json_gen.go に書き出す. encoding/json の Marshal はリフレクションで構造体を調べるので, TinyGo では
+// 遅く, 大きくなる. 生成したメソッドはフィールドを順に書くだけで, 結果は Marshal と同じバイト列になる.
+// json_tinygo.go の go:generate から wasm ディレクトリで `go run gen_json.go` として実行する.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// rootTypes は応答として respond に渡す型
+var rootTypes = []string{"TemplateData", "templateDataV1", "AnswerCheckData"}
+
+// sliceHelpers は要素が構造体でないスライスと map を書く関数 (json_append.go)
+var sliceHelpers = map[string]string{
+	"[]int":             "appendJSONInts",
+	"[][]int":           "appendJSONIntMatrix",
+	"[]string":          "appendJSONStrings",
+	"[][]string":        "appendJSONStringMatrix",
+	"[]float64":         "appendJSONFloats",
+	"map[string]string": "appendJSONStringMap",
+	"map[string]int":    "appendJSONIntMap",
+}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return strings.HasSuffix(fi.Name(), ".go") && !strings.HasSuffix(fi.Name(), "_test.go") && !strings.HasPrefix(fi.Name(), "gen_")
+	}, 0)
+	if err != nil {
+		fail(err)
+	}
+	pkg, ok := pkgs["main"]
+	if !ok {
+		fail(fmt.Errorf("package main が見つかりません"))
+	}
+	structs := map[string]*ast.StructType{}
+	for _, file := range pkg.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					structs[spec.Name.Name] = st
+				}
+			}
+			return true
+		})
+	}
+
+	g := &generator{structs: structs, done: map[string]bool{}}
+	g.buf.WriteString("// Code generated by gen_json.go; DO NOT EDIT.\n\npackage main\n\nimport \"strconv\"\n")
+	for _, name := range rootTypes {
+		g.generate(name)
+	}
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		fail(err)
+	}
+	if err := os.WriteFile("json_gen.go", src, 0o644); err != nil {
+		fail(err)
+	}
+}
+
+type generator struct {
+	buf     bytes.Buffer
+	structs map[string]*ast.StructType
+	done    map[string]bool
+	pending []string
+}
+
+// generate は構造体 name と, そこから辿れる構造体の appendJSON を書く
+func (g *generator) generate(name string) {
+	if g.done[name] {
+		return
+	}
+	g.done[name] = true
+	st, ok := g.structs[name]
+	if !ok {
+		fail(fmt.Errorf("構造体 %s が見つかりません", name))
+	}
+	fmt.Fprintf(&g.buf, "\nfunc (v %s) appendJSON(b []byte) []byte {\n", name)
+	sep := "{"
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			fail(fmt.Errorf("%s: 埋め込みのフィールドには対応していません", name))
+		}
+		for _, ident := range field.Names {
+			key, ok := jsonKey(ident, field)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&g.buf, "b = append(b, %s...)\n", strconv.Quote(sep+strconv.Quote(key)+":"))
+			g.value("v."+ident.Name, field.Type)
+			sep = ","
+		}
+	}
+	if sep == "{" {
+		g.buf.WriteString("b = append(b, '{')\n")
+	}
+	g.buf.WriteString("return append(b, '}')\n}\n")
+
+	pending := g.pending
+	g.pending = nil
+	for _, name := range pending {
+		g.generate(name)
+	}
+}
+
+// value は式 expr (型 t) の値を書くコードを出す
+func (g *generator) value(expr string, t ast.Expr) {
+	switch t := t.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			fmt.Fprintf(&g.buf, "b = appendJSONString(b, %s)\n", expr)
+		case "bool":
+			fmt.Fprintf(&g.buf, "b = strconv.AppendBool(b, %s)\n", expr)
+		case "int", "int8", "int16", "int32", "int64":
+			fmt.Fprintf(&g.buf, "b = strconv.AppendInt(b, int64(%s), 10)\n", expr)
+		case "uint", "uint8", "uint16", "uint32", "uint64":
+			fmt.Fprintf(&g.buf, "b = strconv.AppendUint(b, uint64(%s), 10)\n", expr)
+		case "float64":
+			fmt.Fprintf(&g.buf, "b = appendJSONFloat(b, %s)\n", expr)
+		default:
+			g.pending = append(g.pending, t.Name)
+			fmt.Fprintf(&g.buf, "b = %s.appendJSON(b)\n", expr)
+		}
+		return
+	case *ast.StarExpr:
+		fmt.Fprintf(&g.buf, "if %s == nil {\nb = append(b, \"null\"...)\n} else {\n", expr)
+		g.value("(*"+expr+")", t.X)
+		g.buf.WriteString("}\n")
+		return
+	case *ast.ArrayType:
+		if elem, ok := t.Elt.(*ast.Ident); ok && t.Len == nil && g.structs[elem.Name] != nil {
+			g.pending = append(g.pending, elem.Name)
+			fmt.Fprintf(&g.buf, "if %s == nil {\nb = append(b, \"null\"...)\n} else {\nb = append(b, '[')\n", expr)
+			fmt.Fprintf(&g.buf, "for i, e := range %s {\nif i > 0 {\nb = append(b, ',')\n}\nb = e.appendJSON(b)\n}\n", expr)
+			g.buf.WriteString("b = append(b, ']')\n}\n")
+			return
+		}
+	}
+	helper, ok := sliceHelpers[typeString(t)]
+	if !ok {
+		fail(fmt.Errorf("%s: 型 %s には対応していません", expr, typeString(t)))
+	}
+	fmt.Fprintf(&g.buf, "b = %s(b, %s)\n", helper, expr)
+}
+
+// jsonKey はフィールドの JSON のキーを返す. encoding/json と同じく, 小文字で始まるフィールドと "-" は書かない.
+func jsonKey(ident *ast.Ident, field *ast.Field) (string, bool) {
+	if !ident.IsExported() {
+		return "", false
+	}
+	if field.Tag == nil {
+		return ident.Name, true
+	}
+	tag, _ := strconv.Unquote(field.Tag.Value)
+	name, options, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	if options != "" {
+		fail(fmt.Errorf("%s: json タグのオプション %q には対応していません", ident.Name, options))
+	}
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return ident.Name, true
+	}
+	return name, true
+}
+
+// typeString は型の式を Go の書き方の文字列にする
+func typeString(t ast.Expr) string {
+	switch t := t.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return "[...]" + typeString(t.Elt)
+		}
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	}
+	return fmt.Sprintf("%T", t)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "gen_json:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/gf"
+)
+
+// --- 一般の有限体 GF(2^m) (m = 3〜16) ---
+
+// 体の表と演算は internal/gf の gf.Field を使う. QRコードの符号化は GF(2^8) の Encoder (currentEncoder) の表を使い,
+// ここでは他の大きさの体での実験用に, 入力を確かめてからエラーを現在の言語で返す.
+
+// newGaloisField は m 次の原始多項式 polynomial から GF(2^m) を作る. polynomial が0なら既定の原始多項式を使う.
+// α (= x) の位数が 2^m - 1 でない場合, polynomial は原始多項式ではないのでエラーを返す.
+func newGaloisField(m, polynomial int) (*gf.Field, error) {
+	if m < gf.MinDegree || m > gf.MaxDegree {
+		return nil, errorf("体の次数 m は%d〜%dで指定してください: %d", gf.MinDegree, gf.MaxDegree, m)
+	}
+	if polynomial == 0 {
+		polynomial = gf.DefaultPolynomial(m)
+	}
+	size := 1 << m
+	if polynomial < size || polynomial >= 2*size {
+		return nil, errorf("%d次の多項式 (0x%X〜0x%X) を指定してください: 0x%X", m, size, 2*size-1, polynomial)
+	}
+	f, err := gf.New(m, polynomial)
+	var notPrimitive *gf.NotPrimitiveError
+	if errors.As(err, &notPrimitive) {
+		return nil, errorf("0x%X (%s) は原始多項式ではありません (α の位数が %d です).", polynomial, polynomialText(polynomial), notPrimitive.Order)
+	}
+	return f, err
+}
+
+// isomorphism は体 from から体 to への同型写像を返す (mapping[v] は from の元 v に対応する to の元).
+// from の原始多項式の to での根 β を1つ選び, from の α^k を β^k に移す. root は選んだ β.
+func isomorphism(from, to *gf.Field) (mapping []int, root int, err error) {
+	if from.M() != to.M() {
+		return nil, 0, errorf("次数の異なる体 GF(2^%d) と GF(2^%d) は同型ではありません.", from.M(), to.M())
+	}
+	mapping, root, err = gf.Isomorphism(from, to)
+	if err != nil {
+		return nil, 0, errorf("0x%X の根が GF(2^%d) (0x%X) に見つかりません.", from.Polynomial(), to.M(), to.Polynomial())
+	}
+	return mapping, root, nil
+}
+
+// bitLength は v を2進数で表したときの桁数を返す
+func bitLength(v int) int {
+	n := 0
+	for ; v > 0; v >>= 1 {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- メッセージの言語 ---
+
+// エラーや説明の文は日本語で書き, 英語の表示が選ばれたときは englishMessages で同じ書式の英語に置き換える.
+// エラーコード (ErrorInfo.Code) は言語によらず同じ.
+const (
+	localeJA = "ja"
+	localeEN = "en"
+)
+
+var locale = localeJA // setLocale で変えられる. 呼び出しのオプション { locale } が優先する.
+
+// setLocale は以降のメッセージの言語を切り替える
+func setLocale(l string) error {
+	if !isLocale(l) {
+		return errorf("不明な言語です: %s (ja または en を指定してください).", l)
+	}
+	locale = l
+	return nil
+}
+
+func isLocale(l string) bool {
+	return l == localeJA || l == localeEN
+}
+
+// translate は日本語の書式 format を言語 l の書式にする (訳がなければそのまま返す)
+func translate(l, format string) string {
+	if l == localeEN {
+		if english, ok := englishMessages[format]; ok {
+			return english
+		}
+	}
+	return format
+}
+
+// localize は書式 format を言語 l に訳してから値を埋め込む. 値のエラーも言語 l のメッセージにする.
+func localize(l, format string, a ...interface{}) string {
+	args := make([]interface{}, len(a))
+	for i, v := range a {
+		if err, ok := v.(error); ok {
+			args[i] = localizedMessage(l, err)
+		} else {
+			args[i] = v
+		}
+	}
+	return fmt.Sprintf(strings.ReplaceAll(translate(l, format), "%w", "%v"), args...)
+}
+
+// localize は書式 format を呼び出しの言語に訳してから値を埋め込む
+func (c *call) localize(format string, a ...interface{}) string {
+	return localize(c.opts.Locale, format, a...)
+}
+
+// localizedError は書式と値を持っておき, 応答を作るときに呼び出しの言語で組み立てるエラー
+type localizedError struct {
+	format string
+	args   []interface{}
+}
+
+// errorf は fmt.Errorf と同じように使えるが, メッセージを応答の言語に訳せるエラーを作る. %w で包んだエラーは errors.As で取り出せる.
+func errorf(format string, a ...interface{}) error {
+	return &localizedError{format: format, args: a}
+}
+
+func (e *localizedError) Error() string {
+	return fmt.Sprintf(strings.ReplaceAll(e.format, "%w", "%v"), e.args...)
+}
+
+func (e *localizedError) Unwrap() error {
+	if !strings.Contains(e.format, "%w") {
+		return nil
+	}
+	for _, v := range e.args {
+		if err, ok := v.(error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *localizedError) localized(l string) string {
+	return localize(l, e.format, e.args...)
+}
+
+// localizedMessage は err のメッセージを言語 l で返す
+func localizedMessage(l string, err error) string {
+	if e, ok := err.(interface{ localized(string) string }); ok {
+		return e.localized(l)
+	}
+	return err.Error()
+}
+
+// englishMessages は日本語の書式から英語の書式への対応表
+var englishMessages = map[string]string{
+	// 入力と引数
+	"漢字が入力されていません.":                                   "No kanji input was given.",
+	"文字数が多すぎます. %d文字以下で入力してください.":                     "Too many characters. Enter at most %d characters.",
+	"圧縮処理中にエラーが発生しました: %w":                            "Compression failed: %w",
+	"Shift-JISへの変換に失敗しました: %v":                        "Conversion to Shift-JIS failed: %v",
+	"'%s' (%04X) はサポート外のShift-JISコード範囲です":             "'%s' (%04X) is outside the supported Shift-JIS code ranges",
+	"データコード語の2進数文字列の解析に失敗しました: %w":                    "Failed to parse the data codewords binary string: %w",
+	"データコード語は19バイトである必要がありますが, %dバイトでした.":             "The data codewords must be 19 bytes, but got %d bytes.",
+	"符号語の2進数文字列の解析に失敗しました: %w":                        "Failed to parse the codeword binary string: %w",
+	"符号語は26バイトである必要がありますが, %dバイトでした.":                 "The codeword must be 26 bytes, but got %d bytes.",
+	"符号語の16進数文字列の解析に失敗しました: %v":                       "Failed to parse the codeword hex string: %v",
+	"データコード語の変換に失敗しました: %w":                           "Failed to convert the data codewords: %w",
+	"符号語の変換に失敗しました: %w":                               "Failed to convert the codeword: %w",
+	"入力された2進数文字列の解析に失敗しました: %w":                       "Failed to parse the given binary string: %w",
+	"データの2進数文字列の解析に失敗しました: %w":                        "Failed to parse the data binary string: %w",
+	"数値の配列として解釈できません: %v":                             "Cannot be read as an array of numbers: %v",
+	"数値の配列を指定してください.":                                 "Specify an array of numbers.",
+	"配列の%d番目が数値ではありません.":                              "Element %d of the array is not a number.",
+	"2進数文字列の長さが8の倍数ではありません":                           "The length of the binary string is not a multiple of 8",
+	"2進数文字列のパースに失敗しました: %v":                           "Failed to parse the binary string: %v",
+	"16進数文字列の長さが奇数です":                                 "The hex string has an odd length",
+	"16進数文字列のデコードに失敗しました: %v":                         "Failed to decode the hex string: %v",
+	"%d文字目の空白はバイトの区切りの位置にありません (strict).":             "The space at character %d is not on a byte boundary (strict).",
+	"%d文字目の '%c' は使えない文字です (strict: 0, 1 と区切りの空白だけ).": "The character '%[2]c' at position %[1]d is not allowed (strict: only 0, 1 and separating spaces).",
+	"末尾に空白があります (strict).":                            "Trailing space (strict).",
+	"%d文字目からの '%s' を読めません: %v":                        "Cannot read '%[2]s' starting at character %[1]d: %[3]v",
+	"数字がありません":                                        "no digits",
+	"'%c' は2進数の数字ではありません":                             "'%c' is not a binary digit",
+	"'%c' は16進数の数字ではありません":                            "'%c' is not a hex digit",
+
+	// 呼び出しのオプションと設定
+	"型番は現在1だけに対応しています: %d":                                       "Only version 1 is supported: %d",
+	"誤り訂正レベルは現在 L だけに対応しています: %s":                                "Only error correction level L is supported: %s",
+	"マスクパターン参照子は0〜7で指定してください: %d":                                "The mask pattern reference must be 0-7: %d",
+	"不明な応答の形式です: %s (json, object, transferable のどれかを指定してください).": "Unknown response format: %s (use json, object or transferable).",
+	"不明な読み取り方です: %s (strict または lenient を指定してください).":             "Unknown parsing mode: %s (use strict or lenient).",
+	"不明な言語です: %s (ja または en を指定してください).":                         "Unknown locale: %s (use ja or en).",
+	"不明な詳しさです: %s (full または compact を指定してください).":                 "Unknown verbosity: %s (use full or compact).",
+	"不明な係数の表し方です: %s (alpha, decimal, hex のいずれかを指定してください).":      "Unknown coefficient format: %s (use alpha, decimal or hex).",
+	"すでに終了しています.":                                                "The module has already been shut down.",
+	"%s の処理中に内部エラーが発生しました: %v":                                   "Internal error in %s: %v",
+	"マスクパターンの導出に失敗しました: %dバイトでした.":                               "Failed to derive the mask pattern: got %d bytes.",
+	"対応していない応答の版です: %d (%d または %d を指定してください).":                   "Unsupported schema version: %d (use %d or %d).",
+
+	// 行列とマスク
+	"マスクパターン番号は0から%dの範囲で指定してください.":                            "The mask pattern number must be between 0 and %d.",
+	"符号語番号は0から%dの範囲で指定してください.":                                "The codeword index must be between 0 and %d.",
+	"ビット位置は0から7の範囲で指定してください.":                                 "The bit index must be between 0 and 7.",
+	"行と列は0から%dの範囲で指定してください.":                                  "The row and column must be between 0 and %d.",
+	"(%d, %d) は機能パターンのモジュールであり, 符号語のビットは配置されません.":             "(%d, %d) is a function pattern module; no codeword bit is placed there.",
+	"行列のJSONの解析に失敗しました: %v":                                   "Failed to parse the matrix JSON: %v",
+	"行列は%d行である必要がありますが, %d行でした.":                              "The matrix must have %d rows, but had %d.",
+	"行列の%d行目は%d列である必要がありますが, %d列でした.":                         "Row %d of the matrix must have %d columns, but had %d.",
+	"行列は%dモジュールである必要がありますが, %dモジュールでした.":                      "The matrix must have %d modules, but had %d.",
+	"(%d, %d) のモジュールの値は0か1である必要があります.":                        "The module at (%d, %d) must be 0 or 1.",
+	"(%d, %d) が位置検出パターンと一致しません. 行列の向きを確認してください.":              "(%d, %d) does not match the finder pattern. Check the orientation of the matrix.",
+	"形式情報を読み取れませんでした (誤りが多すぎます).":                             "Could not read the format information (too many errors).",
+	"誤り訂正レベルL以外には対応していません (形式情報の誤り訂正レベル: %02b).":              "Only error correction level L is supported (level in format information: %02b).",
+	"モジュールのサイズは1から%dの範囲で指定してください.":                            "The module size must be between 1 and %d.",
+	"クワイエットゾーンの幅は0から%dの範囲で指定してください.":                          "The quiet zone width must be between 0 and %d.",
+	"'%s' はサポート外の色分け方法です (character または codeword を指定してください).": "'%s' is not a supported coloring (use character or codeword).",
+	"色 '%s' は #RRGGBB の形式で指定してください.":                          "Specify the color '%s' as #RRGGBB.",
+	"PNGの生成に失敗しました: %v":                                       "Failed to generate the PNG: %v",
+	"'%s' はサポート外の描画形式です.":                                     "'%s' is not a supported render format.",
+
+	// 復号
+	"モード指示子が漢字モード(1000)ではありません: %s":                         "The mode indicator is not kanji mode (1000): %s",
+	"文字数指示子が不正です: %s (%d文字)":                                "Invalid character count indicator: %s (%d characters)",
+	"Shift-JISからの変換に失敗しました: %v":                             "Conversion from Shift-JIS failed: %v",
+	"復元した文字数(%d)が文字数指示子(%d)と一致しません.":                        "The number of recovered characters (%d) does not match the character count indicator (%d).",
+	"終端パターンが0000ではありません: %s":                                "The terminator is not 0000: %s",
+	"バイト境界までの埋め草ビットが0ではありません: %s":                           "The padding bits up to the byte boundary are not 0: %s",
+	"埋め草コード語が EC 11 の繰り返しではありません: %02X":                     "The pad codewords do not repeat EC 11: %02X",
+	"13ビットの値 %04X はサポート外のShift-JISコード範囲に対応します":              "The 13-bit value %04X maps to an unsupported Shift-JIS code range",
+	"最初の根の指数 b は0〜254で指定してください: %d":                         "The exponent b of the first root must be 0-254: %d",
+	"不明な復号法です: %s (berlekamp-massey または euclid を指定してください).": "Unknown decoding method: %s (use berlekamp-massey or euclid).",
+	"消失が多すぎます (%d個). 訂正できるのは%d個までです.":                       "Too many erasures (%d). At most %d can be corrected.",
+	"消失位置 %d が範囲外です (0〜%d).":                                "Erasure position %d is out of range (0-%d).",
+	"消失位置 %d が重複しています.":                                     "Erasure position %d is duplicated.",
+	"誤りの個数は0〜%dで指定してください: %d":                               "The number of errors must be 0-%d: %d",
+	"ブロックの数は1〜%dで指定してください: %d":                              "The number of blocks must be 1-%d: %d",
+	"誤りの範囲が送信列 (%dバイト) の外にあります: 位置%dから%dバイト":                "The error range is outside the transmitted stream (%d bytes): %[3]d bytes from position %[2]d",
+	"誤りが多すぎるため訂正できません (2×誤り%d個 + 消失%d個 > %d).":              "Too many errors to correct (2×%d errors + %d erasures > %d).",
+	"誤り位置多項式の根の数(%d)が次数(%d)と一致しないため訂正できません.":                "Cannot correct: the number of roots of the error locator (%d) does not match its degree (%d).",
+	"訂正後のシンドロームが0にならないため訂正できません.":                           "Cannot correct: the syndromes are not zero after correction.",
+	"最大%dバイトの誤り, または最大%dバイトの消失を訂正できます (2×誤り + 消失 ≦ %d).":    "Corrects up to %d byte errors or up to %d byte erasures (2×errors + erasures ≤ %d).",
+
+	// 有限体と多項式
+	"GF(2^%d) の要素は0〜%dで指定してください: %d":          "Elements of GF(2^%d) must be 0-%d: %d",
+	"0で割ることはできません.":                           "Cannot divide by 0.",
+	"0には逆元がありません.":                            "0 has no inverse.",
+	"0の負の累乗は定義されません.":                         "Negative powers of 0 are undefined.",
+	"不明な演算です: %s":                             "Unknown operation: %s",
+	"0は乗法群の元ではないので位数がありません.":                  "0 is not in the multiplicative group, so it has no order.",
+	"体の次数 m は%d〜%dで指定してください: %d":              "The field degree m must be %d-%d: %d",
+	"%d次の多項式 (0x%X〜0x%X) を指定してください: 0x%X":     "Specify a polynomial of degree %d (0x%X-0x%X): 0x%X",
+	"0x%X (%s) は原始多項式ではありません (α の位数が %d です).": "0x%X (%s) is not primitive (the order of α is %d).",
+	"次数の異なる体 GF(2^%d) と GF(2^%d) は同型ではありません.": "GF(2^%d) and GF(2^%d) have different degrees and are not isomorphic.",
+	"0x%X の根が GF(2^%d) (0x%X) に見つかりません.":      "No root of 0x%X was found in GF(2^%d) (0x%X).",
+	"多項式の係数を1つ以上指定してください.":                    "Specify at least one polynomial coefficient.",
+	"係数は0〜255で指定してください (%d番目: %d).":           "Coefficients must be 0-255 (index %d: %d).",
+	"多項式を入力してください.":                           "Enter a polynomial.",
+	"空の項があります.":                               "There is an empty term.",
+	"16進数の係数を読み取れません: %s":                     "Cannot read the hex coefficient: %s",
+	"α の指数を読み取れません: %s":                       "Cannot read the exponent of α: %s",
+	"係数は0〜255で指定してください: %s":                   "Coefficients must be 0-255: %s",
+	"項を読み取れません: %s":                           "Cannot read the term: %s",
+	"x の次数を読み取れません: %s":                       "Cannot read the degree of x: %s",
+	"次数が大きすぎます (%dまで): %s":                    "The degree is too large (at most %d): %s",
+	"代入する値は0〜255で指定してください: %d":                "The value to substitute must be 0-255: %d",
+	"0多項式で割ることはできません.":                        "Cannot divide by the zero polynomial.",
+	"生成多項式の次数は1〜254で指定してください: %d":             "The generator polynomial degree must be 1-254: %d",
+	"問題の数は1〜%dで指定してください: %d":                  "The number of problems must be 1-%d: %d",
+
+	// RS 符号
+	"不明な符号化の方法です: %s (systematic または non-systematic を指定してください).": "Unknown encoding mode: %s (use systematic or non-systematic).",
+	"データを1バイト以上指定してください.":                                        "Specify at least one data byte.",
+	"誤り訂正コード語の数は1以上で指定してください: %d":                                "The number of error correction codewords must be at least 1: %d",
+	"符号長 (データ%dバイト + 誤り訂正%dバイト) が%dを超えています.":                     "The code length (%d data bytes + %d error correction bytes) exceeds %d.",
+
+	// セッションと答え合わせ
+	"開いているセッションが多すぎます (%d個まで). 使い終わったセッションを closeSession で閉じてください.": "Too many open sessions (at most %d). Close finished sessions with closeSession.",
+	"セッション %d はありません.":                             "Session %d does not exist.",
+	"セッション %d は全ての段階を終えています.":                      "Session %d has completed every step.",
+	"STEP3 の前に STEP1-2 を実行してください.":                 "Run STEP1-2 before STEP3.",
+	"STEP4 の前に STEP3 を実行してください.":                   "Run STEP3 before STEP4.",
+	"段階は %d〜%d で指定してください: %d":                      "The step must be %d-%d: %d",
+	"不明な段階です: %s":                                  "Unknown stage: %s",
+	"分割した入力が大きすぎます (%dバイトまで).":                     "The chunked input is too large (at most %d bytes).",
+	"appendChunk で入力を渡してから finishChunks を呼んでください.": "Pass input with appendChunk before calling finishChunks.",
+	"正解です.": "Correct.",
+	"長さが違います. %dバイトである必要がありますが, %dバイトでした.": "Wrong length. Expected %d bytes, but got %d bytes.",
+	"%dバイト目の%dビット目 (全体で%dビット目) が違います.":     "Bit %[2]d of byte %[1]d (bit %[3]d overall) is wrong.",
+
+	// HTTP と標準入出力の API
+	"%s には対応していません. POST で要求してください.":                           "%s is not supported. Send a POST request.",
+	"要求の本文を JSON として読めませんでした: %v":                              "Could not read the request body as JSON: %v",
+	"bytes の%d番目が0〜255の範囲にありません: %d":                           "Element %d of bytes is not in 0-255: %d",
+	"要求を JSON として読めませんでした: %v":                                 "Could not read the request as JSON: %v",
+	"不明なコマンドです: %s (encode, ecc, mask, decode のどれかを指定してください).": "Unknown command: %s (specify one of encode, ecc, mask, decode).",
+	"オプションを JSON として読めませんでした: %v":                              "Could not read the options as JSON: %v",
+
+	// 整数の配列の JSON
+	"%dバイト目に整数が必要です.":      "Expected an integer at byte %d.",
+	"%dバイト目に '%c' が必要です.":  "Expected '%[2]c' at byte %[1]d.",
+	"%dバイト目以降に余分な文字があります.": "Unexpected characters from byte %d.",
+
+	// 取り消し
+	"処理が取り消されました.": "The operation was cancelled.",
+
+	// JSON-RPC
+	"要求を JSON として読めませんでした.":                                    "Could not read the request as JSON.",
+	"要求の配列が空です.":                                               "The batch of requests is empty.",
+	"JSON-RPC 2.0 の要求ではありません.":                                 "Not a JSON-RPC 2.0 request.",
+	"不明なメソッドです: %s (encode, ecc, mask, decode のどれかを指定してください).": "Method not found: %s (specify one of encode, ecc, mask, decode).",
+	"params を読めませんでした: %v":                                     "Could not read params: %v",
+	"params の配列は [input] か [input, オプション] で指定してください.":          "Specify params as [input] or [input, options].",
+
+	// 体と多項式の演算
+	"多項式の演算に失敗しました: %v":        "Polynomial arithmetic failed: %v",
+	"既定の設定で符号化の表を作れませんでした: %v": "Could not build the encoding tables for the default settings: %v",
+
+	// シンボルの組み立て
+	"シンボルを組み立てられませんでした: %v": "Could not build the symbol: %v",
+}
@@ -0,0 +1,106 @@
+package main
+
+// --- バースト誤りとインターリーブ ---
+
+// 型番1-Lのシンボルは1ブロックだけなので, 同じ符号語を depth 個のブロックとして並べて送る場合を考える.
+// インターリーブしない場合はブロックを順に連結し, インターリーブする場合は各ブロックから1バイトずつ交互に取り出して送る.
+const maxInterleaveDepth = 8
+
+// BurstData はバースト誤りを加えたときの, インターリーブの有無による違い
+type BurstData struct {
+	Start       int           `json:"Start"`  // 誤りの始まる位置 (送信するバイト列の中の位置)
+	Length      int           `json:"Length"` // 連続して壊すバイト数
+	Depth       int           `json:"Depth"`  // ブロックの数
+	Sequential  BurstAnalysis `json:"Sequential"`
+	Interleaved BurstAnalysis `json:"Interleaved"`
+}
+
+// BurstAnalysis は1通りの送り方について, 壊れた送信列と各ブロックの復号結果をまとめたもの
+type BurstAnalysis struct {
+	StreamHex      string       `json:"StreamHex"` // 誤りを加えた後の送信列
+	Blocks         []BurstBlock `json:"Blocks"`
+	AllCorrectable bool         `json:"AllCorrectable"`
+}
+
+// BurstBlock は受信側で組み立て直した1ブロックの様子
+type BurstBlock struct {
+	Index          int    `json:"Index"`
+	ReceivedHex    string `json:"ReceivedHex"`
+	ErrorPositions []int  `json:"ErrorPositions"` // このブロックの中で壊れたバイトの位置
+	Correctable    bool   `json:"Correctable"`
+	CorrectedHex   string `json:"CorrectedHex"`
+	Message        string `json:"Message"` // 訂正できなかった場合の理由
+}
+
+// sequentialIndex はインターリーブしない送信列の k 番目のバイトが, どのブロックの何番目かを返す
+func sequentialIndex(k, n int) (int, int) {
+	return k / n, k % n
+}
+
+// interleavedIndex はインターリーブした送信列の k 番目のバイトが, どのブロックの何番目かを返す
+func interleavedIndex(k, depth int) (int, int) {
+	return k % depth, k / depth
+}
+
+// processBurst は符号語(2進数)を depth 個のブロックとして送り, 送信列の start から length バイトを壊したときに
+// 各ブロックの誤りがどう分かれ, 訂正できるかを, インターリーブしない場合とする場合で比べる.
+// 壊れたバイトは全てのビットが反転したものとする.
+func (c *call) processBurst(codewordBinary string, start, length, depth int) (TemplateData, error) {
+	codewordBytes, err := c.parseCodewordBinary(codewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	if depth < 1 || depth > maxInterleaveDepth {
+		return TemplateData{}, errorf("ブロックの数は1〜%dで指定してください: %d", maxInterleaveDepth, depth)
+	}
+	n := len(codewordBytes)
+	total := n * depth
+	if length < 1 || start < 0 || start+length > total {
+		return TemplateData{}, errorf("誤りの範囲が送信列 (%dバイト) の外にあります: 位置%dから%dバイト", total, start, length)
+	}
+
+	var data TemplateData
+	data.Intermediate.CodewordHex = formatBytesToHex(codewordBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(codewordBytes)
+	data.Burst = BurstData{Start: start, Length: length, Depth: depth}
+	data.Burst.Sequential = c.analyzeBurst(codewordBytes, start, length, depth, func(k int) (int, int) { return sequentialIndex(k, n) })
+	data.Burst.Interleaved = c.analyzeBurst(codewordBytes, start, length, depth, func(k int) (int, int) { return interleavedIndex(k, depth) })
+	return data, nil
+}
+
+// analyzeBurst は送信列の並べ方 (locate) に従って送信列を作り, バースト誤りを加えてからブロックごとに復号する
+func (c *call) analyzeBurst(codewordBytes []byte, start, length, depth int, locate func(k int) (int, int)) BurstAnalysis {
+	n := len(codewordBytes)
+	blocks := make([][]int, depth)
+	for b := range blocks {
+		blocks[b] = bytesToInts(codewordBytes)
+	}
+
+	stream := make([]byte, n*depth)
+	var analysis BurstAnalysis
+	errorPositions := make([][]int, depth)
+	for k := range stream {
+		b, i := locate(k)
+		if k >= start && k < start+length {
+			blocks[b][i] ^= 0xFF
+			errorPositions[b] = append(errorPositions[b], i)
+		}
+		stream[k] = byte(blocks[b][i])
+	}
+	analysis.StreamHex = formatBytesToHex(stream)
+
+	analysis.AllCorrectable = true
+	for b, received := range blocks {
+		block := BurstBlock{Index: b, ReceivedHex: formatBytesToHex(intsToBytes(received)), ErrorPositions: errorPositions[b]}
+		decoding, _ := c.decodeReceived(received, decodeMethodBerlekampMassey, nil)
+		if decoding.Success {
+			block.Correctable = true
+			block.CorrectedHex = decoding.CorrectedHex
+		} else {
+			block.Message = decoding.Failure.Message
+			analysis.AllCorrectable = false
+		}
+		analysis.Blocks = append(analysis.Blocks, block)
+	}
+	return analysis
+}
@@ -0,0 +1,1238 @@
+// Code generated by gen_json.go; DO NOT EDIT.
+
+package main
+
+import "strconv"
+
+func (v TemplateData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"SchemaVersion\":"...)
+	b = strconv.AppendInt(b, int64(v.SchemaVersion), 10)
+	b = append(b, ",\"KanjiInput\":"...)
+	b = appendJSONString(b, v.KanjiInput)
+	b = append(b, ",\"Results\":"...)
+	if v.Results == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Results {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"Intermediate\":"...)
+	b = v.Intermediate.appendJSON(b)
+	b = append(b, ",\"Matrix\":"...)
+	b = v.Matrix.appendJSON(b)
+	b = append(b, ",\"BitPosition\":"...)
+	b = v.BitPosition.appendJSON(b)
+	b = append(b, ",\"Mask\":"...)
+	b = v.Mask.appendJSON(b)
+	b = append(b, ",\"MaskComparisons\":"...)
+	if v.MaskComparisons == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.MaskComparisons {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"Render\":"...)
+	b = v.Render.appendJSON(b)
+	b = append(b, ",\"Worksheet\":"...)
+	b = v.Worksheet.appendJSON(b)
+	b = append(b, ",\"Decoding\":"...)
+	b = v.Decoding.appendJSON(b)
+	b = append(b, ",\"Burst\":"...)
+	b = v.Burst.appendJSON(b)
+	b = append(b, ",\"Field\":"...)
+	b = v.Field.appendJSON(b)
+	b = append(b, ",\"Polynomial\":"...)
+	b = v.Polynomial.appendJSON(b)
+	b = append(b, ",\"RS\":"...)
+	b = v.RS.appendJSON(b)
+	b = append(b, ",\"Quiz\":"...)
+	b = v.Quiz.appendJSON(b)
+	b = append(b, ",\"CodeMatrix\":"...)
+	b = v.CodeMatrix.appendJSON(b)
+	b = append(b, ",\"Session\":"...)
+	b = v.Session.appendJSON(b)
+	b = append(b, ",\"Capabilities\":"...)
+	b = v.Capabilities.appendJSON(b)
+	b = append(b, ",\"Stats\":"...)
+	b = v.Stats.appendJSON(b)
+	b = append(b, ",\"Validation\":"...)
+	b = v.Validation.appendJSON(b)
+	b = append(b, ",\"Chunk\":"...)
+	b = v.Chunk.appendJSON(b)
+	b = append(b, ",\"Constants\":"...)
+	b = v.Constants.appendJSON(b)
+	b = append(b, ",\"Error\":"...)
+	if v.Error == nil {
+		b = append(b, "null"...)
+	} else {
+		b = (*v.Error).appendJSON(b)
+	}
+	b = append(b, ",\"MaxCharCount\":"...)
+	b = strconv.AppendInt(b, int64(v.MaxCharCount), 10)
+	return append(b, '}')
+}
+
+func (v KanjiCompressionResult) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Kanji\":"...)
+	b = appendJSONString(b, v.Kanji)
+	b = append(b, ",\"ShiftJISCode\":"...)
+	b = appendJSONString(b, v.ShiftJISCode)
+	b = append(b, ",\"SubtractedCode\":"...)
+	b = appendJSONString(b, v.SubtractedCode)
+	b = append(b, ",\"CompressedHex\":"...)
+	b = appendJSONString(b, v.CompressedHex)
+	b = append(b, ",\"Binary13Bit\":"...)
+	b = appendJSONString(b, v.Binary13Bit)
+	return append(b, '}')
+}
+
+func (v QRCodeIntermediateData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"ModeIndicator\":"...)
+	b = appendJSONString(b, v.ModeIndicator)
+	b = append(b, ",\"CharCountIndicator\":"...)
+	b = appendJSONString(b, v.CharCountIndicator)
+	b = append(b, ",\"ConcatenatedBinary\":"...)
+	b = appendJSONString(b, v.ConcatenatedBinary)
+	b = append(b, ",\"TerminatedBinary\":"...)
+	b = appendJSONString(b, v.TerminatedBinary)
+	b = append(b, ",\"PaddedBinaryBlocks\":"...)
+	b = appendJSONString(b, v.PaddedBinaryBlocks)
+	b = append(b, ",\"PaddedHex\":"...)
+	b = appendJSONString(b, v.PaddedHex)
+	b = append(b, ",\"PaddedBinary\":"...)
+	b = appendJSONString(b, v.PaddedBinary)
+	b = append(b, ",\"DataPolynomial\":"...)
+	b = appendJSONString(b, v.DataPolynomial)
+	b = append(b, ",\"ErrorCorrectionPolynomial\":"...)
+	b = appendJSONString(b, v.ErrorCorrectionPolynomial)
+	b = append(b, ",\"CodewordPolynomial\":"...)
+	b = appendJSONString(b, v.CodewordPolynomial)
+	b = append(b, ",\"DataCoefficients\":"...)
+	b = v.DataCoefficients.appendJSON(b)
+	b = append(b, ",\"ErrorCorrectionCoefficients\":"...)
+	b = v.ErrorCorrectionCoefficients.appendJSON(b)
+	b = append(b, ",\"CodewordCoefficients\":"...)
+	b = v.CodewordCoefficients.appendJSON(b)
+	b = append(b, ",\"CodewordHex\":"...)
+	b = appendJSONString(b, v.CodewordHex)
+	b = append(b, ",\"CodewordBinary\":"...)
+	b = appendJSONString(b, v.CodewordBinary)
+	b = append(b, ",\"MaskPatternHex\":"...)
+	b = appendJSONString(b, v.MaskPatternHex)
+	b = append(b, ",\"MaskedCodewordHex\":"...)
+	b = appendJSONString(b, v.MaskedCodewordHex)
+	b = append(b, ",\"MaskedCodewordBinary\":"...)
+	b = appendJSONString(b, v.MaskedCodewordBinary)
+	return append(b, '}')
+}
+
+func (v PolynomialCoefficients) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Decimal\":"...)
+	b = appendJSONInts(b, v.Decimal)
+	b = append(b, ",\"Hex\":"...)
+	b = appendJSONStrings(b, v.Hex)
+	b = append(b, ",\"Exponents\":"...)
+	b = appendJSONInts(b, v.Exponents)
+	return append(b, '}')
+}
+
+func (v QRMatrixData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Size\":"...)
+	b = strconv.AppendInt(b, int64(v.Size), 10)
+	b = append(b, ",\"QuietZone\":"...)
+	b = strconv.AppendInt(b, int64(v.QuietZone), 10)
+	b = append(b, ",\"FunctionPatternMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.FunctionPatternMatrix)
+	b = append(b, ",\"DataPlacedMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.DataPlacedMatrix)
+	b = append(b, ",\"MaskedMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.MaskedMatrix)
+	b = append(b, ",\"FormatInformation\":"...)
+	b = appendJSONString(b, v.FormatInformation)
+	b = append(b, ",\"RegionMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.RegionMatrix)
+	b = append(b, ",\"RegionNames\":"...)
+	b = appendJSONStrings(b, v.RegionNames)
+	b = append(b, ",\"CodewordMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.CodewordMatrix)
+	b = append(b, ",\"SourceMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.SourceMatrix)
+	b = append(b, ",\"MaskPatternMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.MaskPatternMatrix)
+	return append(b, '}')
+}
+
+func (v BitPositionData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"CodewordIndex\":"...)
+	b = strconv.AppendInt(b, int64(v.CodewordIndex), 10)
+	b = append(b, ",\"BitIndex\":"...)
+	b = strconv.AppendInt(b, int64(v.BitIndex), 10)
+	b = append(b, ",\"Row\":"...)
+	b = strconv.AppendInt(b, int64(v.Row), 10)
+	b = append(b, ",\"Column\":"...)
+	b = strconv.AppendInt(b, int64(v.Column), 10)
+	return append(b, '}')
+}
+
+func (v MaskDerivationData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"PatternNumber\":"...)
+	b = strconv.AppendInt(b, int64(v.PatternNumber), 10)
+	b = append(b, ",\"Condition\":"...)
+	b = appendJSONString(b, v.Condition)
+	b = append(b, ",\"MaskHex\":"...)
+	b = appendJSONString(b, v.MaskHex)
+	b = append(b, ",\"Bytes\":"...)
+	if v.Bytes == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Bytes {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	return append(b, '}')
+}
+
+func (v MaskByteDerivation) appendJSON(b []byte) []byte {
+	b = append(b, "{\"CodewordIndex\":"...)
+	b = strconv.AppendInt(b, int64(v.CodewordIndex), 10)
+	b = append(b, ",\"Positions\":"...)
+	b = appendJSONIntMatrix(b, v.Positions)
+	b = append(b, ",\"Binary\":"...)
+	b = appendJSONString(b, v.Binary)
+	b = append(b, ",\"Hex\":"...)
+	b = appendJSONString(b, v.Hex)
+	return append(b, '}')
+}
+
+func (v MaskComparisonData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"PatternNumber\":"...)
+	b = strconv.AppendInt(b, int64(v.PatternNumber), 10)
+	b = append(b, ",\"Condition\":"...)
+	b = appendJSONString(b, v.Condition)
+	b = append(b, ",\"MaskedMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.MaskedMatrix)
+	b = append(b, ",\"FormatInformation\":"...)
+	b = appendJSONString(b, v.FormatInformation)
+	b = append(b, ",\"PenaltyN1\":"...)
+	b = strconv.AppendInt(b, int64(v.PenaltyN1), 10)
+	b = append(b, ",\"PenaltyN2\":"...)
+	b = strconv.AppendInt(b, int64(v.PenaltyN2), 10)
+	b = append(b, ",\"PenaltyN3\":"...)
+	b = strconv.AppendInt(b, int64(v.PenaltyN3), 10)
+	b = append(b, ",\"PenaltyN4\":"...)
+	b = strconv.AppendInt(b, int64(v.PenaltyN4), 10)
+	b = append(b, ",\"PenaltyTotal\":"...)
+	b = strconv.AppendInt(b, int64(v.PenaltyTotal), 10)
+	b = append(b, ",\"DarkModuleCount\":"...)
+	b = strconv.AppendInt(b, int64(v.DarkModuleCount), 10)
+	b = append(b, ",\"Rank\":"...)
+	b = strconv.AppendInt(b, int64(v.Rank), 10)
+	return append(b, '}')
+}
+
+func (v RenderData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Format\":"...)
+	b = appendJSONString(b, v.Format)
+	b = append(b, ",\"Content\":"...)
+	b = appendJSONString(b, v.Content)
+	b = append(b, ",\"Width\":"...)
+	b = strconv.AppendInt(b, int64(v.Width), 10)
+	b = append(b, ",\"Height\":"...)
+	b = strconv.AppendInt(b, int64(v.Height), 10)
+	b = append(b, ",\"DataURI\":"...)
+	b = appendJSONString(b, v.DataURI)
+	return append(b, '}')
+}
+
+func (v WorksheetData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Worksheet\":"...)
+	b = appendJSONString(b, v.Worksheet)
+	b = append(b, ",\"AnswerKey\":"...)
+	b = appendJSONString(b, v.AnswerKey)
+	return append(b, '}')
+}
+
+func (v DecodingData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Syndromes\":"...)
+	if v.Syndromes == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Syndromes {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"SyndromesZero\":"...)
+	b = strconv.AppendBool(b, v.SyndromesZero)
+	b = append(b, ",\"SyndromeTableLatex\":"...)
+	b = appendJSONString(b, v.SyndromeTableLatex)
+	b = append(b, ",\"Method\":"...)
+	b = appendJSONString(b, v.Method)
+	b = append(b, ",\"BerlekampMassey\":"...)
+	if v.BerlekampMassey == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.BerlekampMassey {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"Euclid\":"...)
+	if v.Euclid == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Euclid {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"ErrorLocator\":"...)
+	b = appendJSONInts(b, v.ErrorLocator)
+	b = append(b, ",\"ErrorLocatorLatex\":"...)
+	b = appendJSONString(b, v.ErrorLocatorLatex)
+	b = append(b, ",\"ErasurePositions\":"...)
+	b = appendJSONInts(b, v.ErasurePositions)
+	b = append(b, ",\"ErasureLocator\":"...)
+	b = appendJSONInts(b, v.ErasureLocator)
+	b = append(b, ",\"ErasureLocatorLatex\":"...)
+	b = appendJSONString(b, v.ErasureLocatorLatex)
+	b = append(b, ",\"ChienSearch\":"...)
+	if v.ChienSearch == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.ChienSearch {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"ErrorEvaluator\":"...)
+	b = appendJSONInts(b, v.ErrorEvaluator)
+	b = append(b, ",\"ErrorEvaluatorLatex\":"...)
+	b = appendJSONString(b, v.ErrorEvaluatorLatex)
+	b = append(b, ",\"LocatorDerivative\":"...)
+	b = appendJSONInts(b, v.LocatorDerivative)
+	b = append(b, ",\"LocatorDerivativeLatex\":"...)
+	b = appendJSONString(b, v.LocatorDerivativeLatex)
+	b = append(b, ",\"Forney\":"...)
+	if v.Forney == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Forney {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"ErrorPositions\":"...)
+	b = appendJSONInts(b, v.ErrorPositions)
+	b = append(b, ",\"ErrorValues\":"...)
+	b = appendJSONInts(b, v.ErrorValues)
+	b = append(b, ",\"ReceivedHex\":"...)
+	b = appendJSONString(b, v.ReceivedHex)
+	b = append(b, ",\"ReceivedBinary\":"...)
+	b = appendJSONString(b, v.ReceivedBinary)
+	b = append(b, ",\"ErrorVector\":"...)
+	b = appendJSONInts(b, v.ErrorVector)
+	b = append(b, ",\"CorrectedHex\":"...)
+	b = appendJSONString(b, v.CorrectedHex)
+	b = append(b, ",\"CorrectedBinary\":"...)
+	b = appendJSONString(b, v.CorrectedBinary)
+	b = append(b, ",\"Diff\":"...)
+	if v.Diff == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Diff {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"XORBinary\":"...)
+	b = appendJSONString(b, v.XORBinary)
+	b = append(b, ",\"Budget\":"...)
+	b = v.Budget.appendJSON(b)
+	b = append(b, ",\"Verification\":"...)
+	b = v.Verification.appendJSON(b)
+	b = append(b, ",\"Capacity\":"...)
+	b = v.Capacity.appendJSON(b)
+	b = append(b, ",\"Repair\":"...)
+	b = v.Repair.appendJSON(b)
+	b = append(b, ",\"Success\":"...)
+	b = strconv.AppendBool(b, v.Success)
+	b = append(b, ",\"Failure\":"...)
+	if v.Failure == nil {
+		b = append(b, "null"...)
+	} else {
+		b = (*v.Failure).appendJSON(b)
+	}
+	return append(b, '}')
+}
+
+func (v SyndromeData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Index\":"...)
+	b = strconv.AppendInt(b, int64(v.Index), 10)
+	b = append(b, ",\"Value\":"...)
+	b = strconv.AppendInt(b, int64(v.Value), 10)
+	b = append(b, ",\"Alpha\":"...)
+	b = appendJSONString(b, v.Alpha)
+	b = append(b, ",\"Latex\":"...)
+	b = appendJSONString(b, v.Latex)
+	return append(b, '}')
+}
+
+func (v BerlekampMasseyStep) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Step\":"...)
+	b = strconv.AppendInt(b, int64(v.Step), 10)
+	b = append(b, ",\"Discrepancy\":"...)
+	b = strconv.AppendInt(b, int64(v.Discrepancy), 10)
+	b = append(b, ",\"Locator\":"...)
+	b = appendJSONInts(b, v.Locator)
+	b = append(b, ",\"LocatorLatex\":"...)
+	b = appendJSONString(b, v.LocatorLatex)
+	b = append(b, ",\"L\":"...)
+	b = strconv.AppendInt(b, int64(v.L), 10)
+	return append(b, '}')
+}
+
+func (v EuclideanStep) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Step\":"...)
+	b = strconv.AppendInt(b, int64(v.Step), 10)
+	b = append(b, ",\"Quotient\":"...)
+	b = appendJSONInts(b, v.Quotient)
+	b = append(b, ",\"Remainder\":"...)
+	b = appendJSONInts(b, v.Remainder)
+	b = append(b, ",\"Locator\":"...)
+	b = appendJSONInts(b, v.Locator)
+	return append(b, '}')
+}
+
+func (v ChienSearchStep) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Degree\":"...)
+	b = strconv.AppendInt(b, int64(v.Degree), 10)
+	b = append(b, ",\"Position\":"...)
+	b = strconv.AppendInt(b, int64(v.Position), 10)
+	b = append(b, ",\"Element\":"...)
+	b = appendJSONString(b, v.Element)
+	b = append(b, ",\"Value\":"...)
+	b = strconv.AppendInt(b, int64(v.Value), 10)
+	b = append(b, ",\"IsRoot\":"...)
+	b = strconv.AppendBool(b, v.IsRoot)
+	return append(b, '}')
+}
+
+func (v ForneyStep) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Position\":"...)
+	b = strconv.AppendInt(b, int64(v.Position), 10)
+	b = append(b, ",\"Locator\":"...)
+	b = appendJSONString(b, v.Locator)
+	b = append(b, ",\"OmegaValue\":"...)
+	b = strconv.AppendInt(b, int64(v.OmegaValue), 10)
+	b = append(b, ",\"DerivativeValue\":"...)
+	b = strconv.AppendInt(b, int64(v.DerivativeValue), 10)
+	b = append(b, ",\"Value\":"...)
+	b = strconv.AppendInt(b, int64(v.Value), 10)
+	return append(b, '}')
+}
+
+func (v ByteDiff) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Position\":"...)
+	b = strconv.AppendInt(b, int64(v.Position), 10)
+	b = append(b, ",\"Received\":"...)
+	b = appendJSONString(b, v.Received)
+	b = append(b, ",\"Corrected\":"...)
+	b = appendJSONString(b, v.Corrected)
+	b = append(b, ",\"Magnitude\":"...)
+	b = strconv.AppendInt(b, int64(v.Magnitude), 10)
+	b = append(b, ",\"XOR\":"...)
+	b = appendJSONString(b, v.XOR)
+	b = append(b, ",\"Changed\":"...)
+	b = strconv.AppendBool(b, v.Changed)
+	return append(b, '}')
+}
+
+func (v DecodingBudget) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Errors\":"...)
+	b = strconv.AppendInt(b, int64(v.Errors), 10)
+	b = append(b, ",\"Erasures\":"...)
+	b = strconv.AppendInt(b, int64(v.Erasures), 10)
+	b = append(b, ",\"Used\":"...)
+	b = strconv.AppendInt(b, int64(v.Used), 10)
+	b = append(b, ",\"Capacity\":"...)
+	b = strconv.AppendInt(b, int64(v.Capacity), 10)
+	return append(b, '}')
+}
+
+func (v VerificationData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Valid\":"...)
+	b = strconv.AppendBool(b, v.Valid)
+	b = append(b, ",\"ExpectedECCHex\":"...)
+	b = appendJSONString(b, v.ExpectedECCHex)
+	b = append(b, ",\"ReceivedECCHex\":"...)
+	b = appendJSONString(b, v.ReceivedECCHex)
+	b = append(b, ",\"MismatchedECC\":"...)
+	b = appendJSONInts(b, v.MismatchedECC)
+	b = append(b, ",\"RemainderIsZero\":"...)
+	b = strconv.AppendBool(b, v.RemainderIsZero)
+	return append(b, '}')
+}
+
+func (v CodeCapacity) appendJSON(b []byte) []byte {
+	b = append(b, "{\"N\":"...)
+	b = strconv.AppendInt(b, int64(v.N), 10)
+	b = append(b, ",\"K\":"...)
+	b = strconv.AppendInt(b, int64(v.K), 10)
+	b = append(b, ",\"T\":"...)
+	b = strconv.AppendInt(b, int64(v.T), 10)
+	b = append(b, ",\"MinimumDistance\":"...)
+	b = strconv.AppendInt(b, int64(v.MinimumDistance), 10)
+	b = append(b, ",\"ErasureCapacity\":"...)
+	b = strconv.AppendInt(b, int64(v.ErasureCapacity), 10)
+	b = append(b, ",\"Description\":"...)
+	b = appendJSONString(b, v.Description)
+	return append(b, '}')
+}
+
+func (v RepairData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Corrected\":"...)
+	b = strconv.AppendBool(b, v.Corrected)
+	b = append(b, ",\"ChangedPositions\":"...)
+	b = appendJSONInts(b, v.ChangedPositions)
+	b = append(b, ",\"ValidKanjiData\":"...)
+	b = strconv.AppendBool(b, v.ValidKanjiData)
+	b = append(b, ",\"Text\":"...)
+	b = appendJSONString(b, v.Text)
+	b = append(b, ",\"Message\":"...)
+	b = appendJSONString(b, v.Message)
+	return append(b, '}')
+}
+
+func (v DecodingFailure) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Reason\":"...)
+	b = appendJSONString(b, v.Reason)
+	b = append(b, ",\"Message\":"...)
+	b = appendJSONString(b, v.Message)
+	b = append(b, ",\"LocatorDegree\":"...)
+	b = strconv.AppendInt(b, int64(v.LocatorDegree), 10)
+	b = append(b, ",\"RootCount\":"...)
+	b = strconv.AppendInt(b, int64(v.RootCount), 10)
+	b = append(b, ",\"RootsOutsideCodeword\":"...)
+	b = strconv.AppendInt(b, int64(v.RootsOutsideCodeword), 10)
+	b = append(b, ",\"ResidualSyndromes\":"...)
+	b = appendJSONInts(b, v.ResidualSyndromes)
+	return append(b, '}')
+}
+
+func (v BurstData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Start\":"...)
+	b = strconv.AppendInt(b, int64(v.Start), 10)
+	b = append(b, ",\"Length\":"...)
+	b = strconv.AppendInt(b, int64(v.Length), 10)
+	b = append(b, ",\"Depth\":"...)
+	b = strconv.AppendInt(b, int64(v.Depth), 10)
+	b = append(b, ",\"Sequential\":"...)
+	b = v.Sequential.appendJSON(b)
+	b = append(b, ",\"Interleaved\":"...)
+	b = v.Interleaved.appendJSON(b)
+	return append(b, '}')
+}
+
+func (v BurstAnalysis) appendJSON(b []byte) []byte {
+	b = append(b, "{\"StreamHex\":"...)
+	b = appendJSONString(b, v.StreamHex)
+	b = append(b, ",\"Blocks\":"...)
+	if v.Blocks == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Blocks {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"AllCorrectable\":"...)
+	b = strconv.AppendBool(b, v.AllCorrectable)
+	return append(b, '}')
+}
+
+func (v BurstBlock) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Index\":"...)
+	b = strconv.AppendInt(b, int64(v.Index), 10)
+	b = append(b, ",\"ReceivedHex\":"...)
+	b = appendJSONString(b, v.ReceivedHex)
+	b = append(b, ",\"ErrorPositions\":"...)
+	b = appendJSONInts(b, v.ErrorPositions)
+	b = append(b, ",\"Correctable\":"...)
+	b = strconv.AppendBool(b, v.Correctable)
+	b = append(b, ",\"CorrectedHex\":"...)
+	b = appendJSONString(b, v.CorrectedHex)
+	b = append(b, ",\"Message\":"...)
+	b = appendJSONString(b, v.Message)
+	return append(b, '}')
+}
+
+func (v FieldData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"M\":"...)
+	b = strconv.AppendInt(b, int64(v.M), 10)
+	b = append(b, ",\"Operation\":"...)
+	b = appendJSONString(b, v.Operation)
+	b = append(b, ",\"Operands\":"...)
+	b = appendJSONInts(b, v.Operands)
+	b = append(b, ",\"Result\":"...)
+	b = strconv.AppendInt(b, int64(v.Result), 10)
+	b = append(b, ",\"ResultHex\":"...)
+	b = appendJSONString(b, v.ResultHex)
+	b = append(b, ",\"ResultAlpha\":"...)
+	b = appendJSONString(b, v.ResultAlpha)
+	b = append(b, ",\"Expression\":"...)
+	b = appendJSONString(b, v.Expression)
+	b = append(b, ",\"Polynomial\":"...)
+	b = strconv.AppendInt(b, int64(v.Polynomial), 10)
+	b = append(b, ",\"PolynomialText\":"...)
+	b = appendJSONString(b, v.PolynomialText)
+	b = append(b, ",\"ExpTable\":"...)
+	b = appendJSONInts(b, v.ExpTable)
+	b = append(b, ",\"LogTable\":"...)
+	b = appendJSONInts(b, v.LogTable)
+	b = append(b, ",\"ExpGrid\":"...)
+	b = appendJSONStringMatrix(b, v.ExpGrid)
+	b = append(b, ",\"LogGrid\":"...)
+	b = appendJSONStringMatrix(b, v.LogGrid)
+	b = append(b, ",\"ConjugacyClasses\":"...)
+	if v.ConjugacyClasses == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.ConjugacyClasses {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"ElementOrder\":"...)
+	b = strconv.AppendInt(b, int64(v.ElementOrder), 10)
+	b = append(b, ",\"IsPrimitive\":"...)
+	b = strconv.AppendBool(b, v.IsPrimitive)
+	b = append(b, ",\"PrimitiveCount\":"...)
+	b = strconv.AppendInt(b, int64(v.PrimitiveCount), 10)
+	b = append(b, ",\"TargetPolynomial\":"...)
+	b = strconv.AppendInt(b, int64(v.TargetPolynomial), 10)
+	b = append(b, ",\"TargetPolynomialText\":"...)
+	b = appendJSONString(b, v.TargetPolynomialText)
+	b = append(b, ",\"IsomorphismRoot\":"...)
+	b = strconv.AppendInt(b, int64(v.IsomorphismRoot), 10)
+	b = append(b, ",\"IsomorphismRootAlpha\":"...)
+	b = appendJSONString(b, v.IsomorphismRootAlpha)
+	b = append(b, ",\"Mapping\":"...)
+	b = appendJSONInts(b, v.Mapping)
+	return append(b, '}')
+}
+
+func (v ConjugacyClass) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Exponents\":"...)
+	b = appendJSONInts(b, v.Exponents)
+	b = append(b, ",\"Elements\":"...)
+	b = appendJSONInts(b, v.Elements)
+	b = append(b, ",\"MinimalPolynomial\":"...)
+	b = strconv.AppendInt(b, int64(v.MinimalPolynomial), 10)
+	b = append(b, ",\"MinimalPolynomialText\":"...)
+	b = appendJSONString(b, v.MinimalPolynomialText)
+	b = append(b, ",\"Degree\":"...)
+	b = strconv.AppendInt(b, int64(v.Degree), 10)
+	return append(b, '}')
+}
+
+func (v PolynomialData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Operands\":"...)
+	b = appendJSONIntMatrix(b, v.Operands)
+	b = append(b, ",\"OperandsTex\":"...)
+	b = appendJSONStrings(b, v.OperandsTex)
+	b = append(b, ",\"X\":"...)
+	b = strconv.AppendInt(b, int64(v.X), 10)
+	b = append(b, ",\"Value\":"...)
+	b = strconv.AppendInt(b, int64(v.Value), 10)
+	b = append(b, ",\"ValueAlpha\":"...)
+	b = appendJSONString(b, v.ValueAlpha)
+	b = append(b, ",\"Horner\":"...)
+	if v.Horner == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Horner {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"Result\":"...)
+	b = appendJSONInts(b, v.Result)
+	b = append(b, ",\"ResultTex\":"...)
+	b = appendJSONString(b, v.ResultTex)
+	b = append(b, ",\"PartialProducts\":"...)
+	if v.PartialProducts == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.PartialProducts {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"GCDSteps\":"...)
+	if v.GCDSteps == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.GCDSteps {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"DivisionRows\":"...)
+	if v.DivisionRows == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.DivisionRows {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"Quotient\":"...)
+	b = appendJSONInts(b, v.Quotient)
+	b = append(b, ",\"QuotientTex\":"...)
+	b = appendJSONString(b, v.QuotientTex)
+	b = append(b, ",\"GeneratorSteps\":"...)
+	if v.GeneratorSteps == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.GeneratorSteps {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"GeneratorTable\":"...)
+	if v.GeneratorTable == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.GeneratorTable {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"CoefficientFormat\":"...)
+	b = appendJSONString(b, v.CoefficientFormat)
+	return append(b, '}')
+}
+
+func (v HornerStep) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Degree\":"...)
+	b = strconv.AppendInt(b, int64(v.Degree), 10)
+	b = append(b, ",\"Coefficient\":"...)
+	b = strconv.AppendInt(b, int64(v.Coefficient), 10)
+	b = append(b, ",\"Product\":"...)
+	b = strconv.AppendInt(b, int64(v.Product), 10)
+	b = append(b, ",\"Accumulator\":"...)
+	b = strconv.AppendInt(b, int64(v.Accumulator), 10)
+	return append(b, '}')
+}
+
+func (v PartialProduct) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Degree\":"...)
+	b = strconv.AppendInt(b, int64(v.Degree), 10)
+	b = append(b, ",\"Coefficient\":"...)
+	b = strconv.AppendInt(b, int64(v.Coefficient), 10)
+	b = append(b, ",\"Product\":"...)
+	b = appendJSONInts(b, v.Product)
+	b = append(b, ",\"ProductTex\":"...)
+	b = appendJSONString(b, v.ProductTex)
+	return append(b, '}')
+}
+
+func (v DivisionStep) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Step\":"...)
+	b = strconv.AppendInt(b, int64(v.Step), 10)
+	b = append(b, ",\"Dividend\":"...)
+	b = appendJSONInts(b, v.Dividend)
+	b = append(b, ",\"Divisor\":"...)
+	b = appendJSONInts(b, v.Divisor)
+	b = append(b, ",\"Quotient\":"...)
+	b = appendJSONInts(b, v.Quotient)
+	b = append(b, ",\"Remainder\":"...)
+	b = appendJSONInts(b, v.Remainder)
+	b = append(b, ",\"DividendTex\":"...)
+	b = appendJSONString(b, v.DividendTex)
+	b = append(b, ",\"DivisorTex\":"...)
+	b = appendJSONString(b, v.DivisorTex)
+	b = append(b, ",\"QuotientTex\":"...)
+	b = appendJSONString(b, v.QuotientTex)
+	b = append(b, ",\"RemainderTex\":"...)
+	b = appendJSONString(b, v.RemainderTex)
+	return append(b, '}')
+}
+
+func (v DivisionRow) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Step\":"...)
+	b = strconv.AppendInt(b, int64(v.Step), 10)
+	b = append(b, ",\"QuotientDegree\":"...)
+	b = strconv.AppendInt(b, int64(v.QuotientDegree), 10)
+	b = append(b, ",\"Factor\":"...)
+	b = strconv.AppendInt(b, int64(v.Factor), 10)
+	b = append(b, ",\"FactorAlpha\":"...)
+	b = appendJSONString(b, v.FactorAlpha)
+	b = append(b, ",\"Subtrahend\":"...)
+	b = appendJSONInts(b, v.Subtrahend)
+	b = append(b, ",\"Result\":"...)
+	b = appendJSONInts(b, v.Result)
+	b = append(b, ",\"SubtrahendTex\":"...)
+	b = appendJSONString(b, v.SubtrahendTex)
+	b = append(b, ",\"ResultTex\":"...)
+	b = appendJSONString(b, v.ResultTex)
+	return append(b, '}')
+}
+
+func (v GeneratorStep) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Index\":"...)
+	b = strconv.AppendInt(b, int64(v.Index), 10)
+	b = append(b, ",\"Factor\":"...)
+	b = appendJSONString(b, v.Factor)
+	b = append(b, ",\"Coefficients\":"...)
+	b = appendJSONInts(b, v.Coefficients)
+	b = append(b, ",\"Latex\":"...)
+	b = appendJSONString(b, v.Latex)
+	return append(b, '}')
+}
+
+func (v GeneratorEntry) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Degree\":"...)
+	b = strconv.AppendInt(b, int64(v.Degree), 10)
+	b = append(b, ",\"Coefficients\":"...)
+	b = appendJSONInts(b, v.Coefficients)
+	b = append(b, ",\"Exponents\":"...)
+	b = appendJSONInts(b, v.Exponents)
+	b = append(b, ",\"Latex\":"...)
+	b = appendJSONString(b, v.Latex)
+	return append(b, '}')
+}
+
+func (v RSCodeData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Mode\":"...)
+	b = appendJSONString(b, v.Mode)
+	b = append(b, ",\"FirstRoot\":"...)
+	b = strconv.AppendInt(b, int64(v.FirstRoot), 10)
+	b = append(b, ",\"N\":"...)
+	b = strconv.AppendInt(b, int64(v.N), 10)
+	b = append(b, ",\"K\":"...)
+	b = strconv.AppendInt(b, int64(v.K), 10)
+	b = append(b, ",\"Parity\":"...)
+	b = strconv.AppendInt(b, int64(v.Parity), 10)
+	b = append(b, ",\"ParityHex\":"...)
+	b = appendJSONString(b, v.ParityHex)
+	b = append(b, ",\"Generator\":"...)
+	b = appendJSONString(b, v.Generator)
+	return append(b, '}')
+}
+
+func (v QuizData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Seed\":"...)
+	b = strconv.AppendInt(b, int64(v.Seed), 10)
+	b = append(b, ",\"Problems\":"...)
+	if v.Problems == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Problems {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	return append(b, '}')
+}
+
+func (v QuizProblem) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Number\":"...)
+	b = strconv.AppendInt(b, int64(v.Number), 10)
+	b = append(b, ",\"Operation\":"...)
+	b = appendJSONString(b, v.Operation)
+	b = append(b, ",\"Operands\":"...)
+	b = appendJSONInts(b, v.Operands)
+	b = append(b, ",\"Question\":"...)
+	b = appendJSONString(b, v.Question)
+	b = append(b, ",\"Answer\":"...)
+	b = strconv.AppendInt(b, int64(v.Answer), 10)
+	b = append(b, ",\"AnswerHex\":"...)
+	b = appendJSONString(b, v.AnswerHex)
+	b = append(b, ",\"AnswerAlpha\":"...)
+	b = appendJSONString(b, v.AnswerAlpha)
+	b = append(b, ",\"Solution\":"...)
+	b = appendJSONStrings(b, v.Solution)
+	return append(b, '}')
+}
+
+func (v CodeMatrixData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"N\":"...)
+	b = strconv.AppendInt(b, int64(v.N), 10)
+	b = append(b, ",\"K\":"...)
+	b = strconv.AppendInt(b, int64(v.K), 10)
+	b = append(b, ",\"GeneratorMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.GeneratorMatrix)
+	b = append(b, ",\"ParityCheckMatrix\":"...)
+	b = appendJSONIntMatrix(b, v.ParityCheckMatrix)
+	b = append(b, ",\"GeneratorMatrixLatex\":"...)
+	b = appendJSONString(b, v.GeneratorMatrixLatex)
+	b = append(b, ",\"ParityCheckMatrixLatex\":"...)
+	b = appendJSONString(b, v.ParityCheckMatrixLatex)
+	b = append(b, ",\"Orthogonal\":"...)
+	b = strconv.AppendBool(b, v.Orthogonal)
+	b = append(b, ",\"Received\":"...)
+	b = appendJSONInts(b, v.Received)
+	b = append(b, ",\"Syndromes\":"...)
+	b = appendJSONInts(b, v.Syndromes)
+	b = append(b, ",\"SyndromeRows\":"...)
+	b = appendJSONStrings(b, v.SyndromeRows)
+	b = append(b, ",\"SyndromeLatex\":"...)
+	b = appendJSONString(b, v.SyndromeLatex)
+	return append(b, '}')
+}
+
+func (v SessionData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"ID\":"...)
+	b = strconv.AppendInt(b, int64(v.ID), 10)
+	b = append(b, ",\"CompletedStep\":"...)
+	b = strconv.AppendInt(b, int64(v.CompletedStep), 10)
+	b = append(b, ",\"NextStep\":"...)
+	b = strconv.AppendInt(b, int64(v.NextStep), 10)
+	return append(b, '}')
+}
+
+func (v CapabilitiesData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Version\":"...)
+	b = appendJSONString(b, v.Version)
+	b = append(b, ",\"SchemaVersions\":"...)
+	b = appendJSONInts(b, v.SchemaVersions)
+	b = append(b, ",\"Modes\":"...)
+	b = appendJSONStrings(b, v.Modes)
+	b = append(b, ",\"Versions\":"...)
+	b = appendJSONInts(b, v.Versions)
+	b = append(b, ",\"ECCLevels\":"...)
+	b = appendJSONStrings(b, v.ECCLevels)
+	b = append(b, ",\"MaskPatterns\":"...)
+	b = appendJSONInts(b, v.MaskPatterns)
+	b = append(b, ",\"OutputFormats\":"...)
+	b = appendJSONStrings(b, v.OutputFormats)
+	b = append(b, ",\"ParsingModes\":"...)
+	b = appendJSONStrings(b, v.ParsingModes)
+	b = append(b, ",\"Locales\":"...)
+	b = appendJSONStrings(b, v.Locales)
+	b = append(b, ",\"Verbosities\":"...)
+	b = appendJSONStrings(b, v.Verbosities)
+	b = append(b, ",\"RenderFormats\":"...)
+	b = appendJSONStrings(b, v.RenderFormats)
+	b = append(b, ",\"CoefficientFormats\":"...)
+	b = appendJSONStrings(b, v.CoefficientFormats)
+	b = append(b, ",\"RSModes\":"...)
+	b = appendJSONStrings(b, v.RSModes)
+	b = append(b, ",\"MaxCharCount\":"...)
+	b = strconv.AppendInt(b, int64(v.MaxCharCount), 10)
+	b = append(b, ",\"Functions\":"...)
+	b = appendJSONStrings(b, v.Functions)
+	return append(b, '}')
+}
+
+func (v StatsData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Mallocs\":"...)
+	b = strconv.AppendUint(b, uint64(v.Mallocs), 10)
+	b = append(b, ",\"Frees\":"...)
+	b = strconv.AppendUint(b, uint64(v.Frees), 10)
+	b = append(b, ",\"HeapAlloc\":"...)
+	b = strconv.AppendUint(b, uint64(v.HeapAlloc), 10)
+	b = append(b, ",\"TotalAlloc\":"...)
+	b = strconv.AppendUint(b, uint64(v.TotalAlloc), 10)
+	b = append(b, ",\"NumGC\":"...)
+	b = strconv.AppendUint(b, uint64(v.NumGC), 10)
+	b = append(b, ",\"PauseTotalMs\":"...)
+	b = appendJSONFloat(b, v.PauseTotalMs)
+	b = append(b, ",\"RecentPausesMs\":"...)
+	b = appendJSONFloats(b, v.RecentPausesMs)
+	b = append(b, ",\"Calls\":"...)
+	if v.Calls == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Calls {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"LastPipeline\":"...)
+	if v.LastPipeline == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.LastPipeline {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	return append(b, '}')
+}
+
+func (v CallStatsData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Name\":"...)
+	b = appendJSONString(b, v.Name)
+	b = append(b, ",\"Count\":"...)
+	b = strconv.AppendInt(b, int64(v.Count), 10)
+	b = append(b, ",\"LastMs\":"...)
+	b = appendJSONFloat(b, v.LastMs)
+	b = append(b, ",\"TotalMs\":"...)
+	b = appendJSONFloat(b, v.TotalMs)
+	return append(b, '}')
+}
+
+func (v StepTiming) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Step\":"...)
+	b = appendJSONString(b, v.Step)
+	b = append(b, ",\"Ms\":"...)
+	b = appendJSONFloat(b, v.Ms)
+	return append(b, '}')
+}
+
+func (v ValidationData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Stage\":"...)
+	b = appendJSONString(b, v.Stage)
+	b = append(b, ",\"Valid\":"...)
+	b = strconv.AppendBool(b, v.Valid)
+	b = append(b, ",\"ExpectedHex\":"...)
+	b = appendJSONString(b, v.ExpectedHex)
+	b = append(b, ",\"ActualHex\":"...)
+	b = appendJSONString(b, v.ActualHex)
+	b = append(b, ",\"Discrepancy\":"...)
+	if v.Discrepancy == nil {
+		b = append(b, "null"...)
+	} else {
+		b = (*v.Discrepancy).appendJSON(b)
+	}
+	b = append(b, ",\"Message\":"...)
+	b = appendJSONString(b, v.Message)
+	return append(b, '}')
+}
+
+func (v DiscrepancyData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"ByteIndex\":"...)
+	b = strconv.AppendInt(b, int64(v.ByteIndex), 10)
+	b = append(b, ",\"BitIndex\":"...)
+	b = strconv.AppendInt(b, int64(v.BitIndex), 10)
+	b = append(b, ",\"Position\":"...)
+	b = strconv.AppendInt(b, int64(v.Position), 10)
+	b = append(b, ",\"Expected\":"...)
+	b = appendJSONString(b, v.Expected)
+	b = append(b, ",\"Actual\":"...)
+	b = appendJSONString(b, v.Actual)
+	return append(b, '}')
+}
+
+func (v ChunkData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Chunks\":"...)
+	b = strconv.AppendInt(b, int64(v.Chunks), 10)
+	b = append(b, ",\"Length\":"...)
+	b = strconv.AppendInt(b, int64(v.Length), 10)
+	b = append(b, ",\"Finished\":"...)
+	b = strconv.AppendBool(b, v.Finished)
+	b = append(b, ",\"Preview\":"...)
+	b = appendJSONString(b, v.Preview)
+	b = append(b, ",\"Capacity\":"...)
+	b = strconv.AppendInt(b, int64(v.Capacity), 10)
+	b = append(b, ",\"Fits\":"...)
+	b = strconv.AppendBool(b, v.Fits)
+	return append(b, '}')
+}
+
+func (v ConstantsData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"ModeIndicators\":"...)
+	b = appendJSONStringMap(b, v.ModeIndicators)
+	b = append(b, ",\"CharCountBits\":"...)
+	b = appendJSONIntMap(b, v.CharCountBits)
+	b = append(b, ",\"Terminator\":"...)
+	b = appendJSONString(b, v.Terminator)
+	b = append(b, ",\"PadCodewords\":"...)
+	b = appendJSONStrings(b, v.PadCodewords)
+	b = append(b, ",\"PrimitivePolynomial\":"...)
+	b = strconv.AppendInt(b, int64(v.PrimitivePolynomial), 10)
+	b = append(b, ",\"PrimitivePolynomialHex\":"...)
+	b = appendJSONString(b, v.PrimitivePolynomialHex)
+	b = append(b, ",\"FieldPolynomial\":"...)
+	b = strconv.AppendInt(b, int64(v.FieldPolynomial), 10)
+	b = append(b, ",\"SymbolSize\":"...)
+	b = strconv.AppendInt(b, int64(v.SymbolSize), 10)
+	b = append(b, ",\"DataCodewords\":"...)
+	b = strconv.AppendInt(b, int64(v.DataCodewords), 10)
+	b = append(b, ",\"ECCodewords\":"...)
+	b = strconv.AppendInt(b, int64(v.ECCodewords), 10)
+	b = append(b, ",\"TotalCodewords\":"...)
+	b = strconv.AppendInt(b, int64(v.TotalCodewords), 10)
+	b = append(b, ",\"DataBits\":"...)
+	b = strconv.AppendInt(b, int64(v.DataBits), 10)
+	b = append(b, ",\"MaxKanji\":"...)
+	b = strconv.AppendInt(b, int64(v.MaxKanji), 10)
+	b = append(b, ",\"MaxBytes\":"...)
+	b = strconv.AppendInt(b, int64(v.MaxBytes), 10)
+	b = append(b, ",\"CorrectableErrors\":"...)
+	b = strconv.AppendInt(b, int64(v.CorrectableErrors), 10)
+	b = append(b, ",\"ECCLevelBits\":"...)
+	b = appendJSONString(b, v.ECCLevelBits)
+	b = append(b, ",\"FormatInformationMask\":"...)
+	b = appendJSONString(b, v.FormatInformationMask)
+	return append(b, '}')
+}
+
+func (v ErrorInfo) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Code\":"...)
+	b = appendJSONString(b, v.Code)
+	b = append(b, ",\"Message\":"...)
+	b = appendJSONString(b, v.Message)
+	b = append(b, ",\"Value\":"...)
+	b = appendJSONString(b, v.Value)
+	b = append(b, ",\"Position\":"...)
+	b = strconv.AppendInt(b, int64(v.Position), 10)
+	return append(b, '}')
+}
+
+func (v templateDataV1) appendJSON(b []byte) []byte {
+	b = append(b, "{\"KanjiInput\":"...)
+	b = appendJSONString(b, v.KanjiInput)
+	b = append(b, ",\"Results\":"...)
+	if v.Results == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i, e := range v.Results {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = e.appendJSON(b)
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ",\"Intermediate\":"...)
+	b = v.Intermediate.appendJSON(b)
+	b = append(b, ",\"Error\":"...)
+	b = appendJSONString(b, v.Error)
+	b = append(b, ",\"MaxCharCount\":"...)
+	b = strconv.AppendInt(b, int64(v.MaxCharCount), 10)
+	return append(b, '}')
+}
+
+func (v intermediateDataV1) appendJSON(b []byte) []byte {
+	b = append(b, "{\"ModeIndicator\":"...)
+	b = appendJSONString(b, v.ModeIndicator)
+	b = append(b, ",\"CharCountIndicator\":"...)
+	b = appendJSONString(b, v.CharCountIndicator)
+	b = append(b, ",\"ConcatenatedBinary\":"...)
+	b = appendJSONString(b, v.ConcatenatedBinary)
+	b = append(b, ",\"TerminatedBinary\":"...)
+	b = appendJSONString(b, v.TerminatedBinary)
+	b = append(b, ",\"PaddedBinaryBlocks\":"...)
+	b = appendJSONString(b, v.PaddedBinaryBlocks)
+	b = append(b, ",\"PaddedHex\":"...)
+	b = appendJSONString(b, v.PaddedHex)
+	b = append(b, ",\"PaddedBinary\":"...)
+	b = appendJSONString(b, v.PaddedBinary)
+	b = append(b, ",\"DataPolynomial\":"...)
+	b = appendJSONString(b, v.DataPolynomial)
+	b = append(b, ",\"ErrorCorrectionPolynomial\":"...)
+	b = appendJSONString(b, v.ErrorCorrectionPolynomial)
+	b = append(b, ",\"CodewordPolynomial\":"...)
+	b = appendJSONString(b, v.CodewordPolynomial)
+	b = append(b, ",\"CodewordHex\":"...)
+	b = appendJSONString(b, v.CodewordHex)
+	b = append(b, ",\"CodewordBinary\":"...)
+	b = appendJSONString(b, v.CodewordBinary)
+	b = append(b, ",\"MaskPatternHex\":"...)
+	b = appendJSONString(b, v.MaskPatternHex)
+	b = append(b, ",\"MaskedCodewordHex\":"...)
+	b = appendJSONString(b, v.MaskedCodewordHex)
+	b = append(b, ",\"MaskedCodewordBinary\":"...)
+	b = appendJSONString(b, v.MaskedCodewordBinary)
+	return append(b, '}')
+}
+
+func (v AnswerCheckData) appendJSON(b []byte) []byte {
+	b = append(b, "{\"Text\":"...)
+	b = appendJSONString(b, v.Text)
+	b = append(b, ",\"Success\":"...)
+	b = strconv.AppendBool(b, v.Success)
+	b = append(b, ",\"Error\":"...)
+	b = appendJSONString(b, v.Error)
+	return append(b, '}')
+}
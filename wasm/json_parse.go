@@ -0,0 +1,126 @@
+package main
+
+import "strconv"
+
+// --- 整数の配列の JSON の読み取り ---
+
+// 引数の JSON は整数の配列 ([1, 2, 3]) と行列 ([[0, 1], [1, 0]]) だけなので, encoding/json の Unmarshal
+// (リフレクションを使い, TinyGo では大きくなる) の代わりにここで読む. null は nil として読む.
+
+// intArrayParser は JSON の文字列 s を先頭から読む
+type intArrayParser struct {
+	s   string
+	pos int
+}
+
+// parseJSONIntArray は整数の配列の JSON を読む
+func parseJSONIntArray(s string) ([]int, error) {
+	p := &intArrayParser{s: s}
+	values, err := p.ints()
+	if err != nil {
+		return nil, err
+	}
+	return values, p.end()
+}
+
+// parseJSONIntMatrix は整数の配列の配列の JSON を読む
+func parseJSONIntMatrix(s string) ([][]int, error) {
+	p := &intArrayParser{s: s}
+	if p.null() {
+		return nil, p.end()
+	}
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+	rows := [][]int{}
+	for p.skipSpace(); !p.consume(']'); {
+		if len(rows) > 0 {
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+		}
+		row, err := p.ints()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+		p.skipSpace()
+	}
+	return rows, p.end()
+}
+
+// ints は null か整数の配列を読む
+func (p *intArrayParser) ints() ([]int, error) {
+	if p.null() {
+		return nil, nil
+	}
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+	values := []int{}
+	for p.skipSpace(); !p.consume(']'); {
+		if len(values) > 0 {
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+			p.skipSpace()
+		}
+		start := p.pos
+		if p.pos < len(p.s) && p.s[p.pos] == '-' {
+			p.pos++
+		}
+		for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		v, err := strconv.Atoi(p.s[start:p.pos])
+		if err != nil {
+			return nil, errorf("%dバイト目に整数が必要です.", start)
+		}
+		values = append(values, v)
+		p.skipSpace()
+	}
+	return values, nil
+}
+
+func (p *intArrayParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+// consume は次の文字が c なら読み進めて true を返す
+func (p *intArrayParser) consume(c byte) bool {
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expect は空白の後に c があることを確かめて読み進める
+func (p *intArrayParser) expect(c byte) error {
+	p.skipSpace()
+	if !p.consume(c) {
+		return errorf("%dバイト目に '%c' が必要です.", p.pos, c)
+	}
+	return nil
+}
+
+// null は空白の後の null を読む
+func (p *intArrayParser) null() bool {
+	p.skipSpace()
+	if len(p.s)-p.pos >= 4 && p.s[p.pos:p.pos+4] == "null" {
+		p.pos += 4
+		return true
+	}
+	return false
+}
+
+// end は残りが空白だけであることを確かめる
+func (p *intArrayParser) end() error {
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return errorf("%dバイト目以降に余分な文字があります.", p.pos)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !tinygo
+
+package main
+
+import "encoding/json"
+
+// jsonResponse は前処理を済ませた data を JSON 文字列にする
+func jsonResponse(data interface{}) string {
+	responseBytes, _ := json.Marshal(data)
+	return string(responseBytes)
+}
@@ -0,0 +1,60 @@
+//go:build !tinygo
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TinyGo で使う生成した appendJSON (json_gen.go) が encoding/json の Marshal と同じバイト列を書くか確かめる
+
+// escapedText はエスケープが必要な文字 (HTML の <, >, &, 引用符, 制御文字, U+2028, U+2029) と不正な UTF-8 を含む文字列
+const escapedText = "<script>\"a\" & 'b'</script>\\\n\t\x00\x1f\u2028\u2029漢字\xff\xfe\xe6\xbc"
+
+func TestAppendJSONMatchesMarshal(t *testing.T) {
+	encoded, err := defaultCall().processEncodeAll("漢字", "svg", defaultRenderOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed, err := defaultCall().processCorrect(formatBytesToBinary(intsToBytes(corrupt(testCodeword(t), map[int]int{0: 0x11, 5: 0x22, 10: 0x33, 15: 0x44}))), decodeMethodEuclid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	escaped := TemplateData{
+		KanjiInput: escapedText,
+		Results:    []KanjiCompressionResult{{Kanji: escapedText}},
+		Error:      &ErrorInfo{Code: escapedText, Message: escapedText, Value: "\xff", Position: -1},
+	}
+	escaped.Decoding.Failure = &DecodingFailure{Message: escapedText}
+	empty := TemplateData{Results: []KanjiCompressionResult{}, MaskComparisons: []MaskComparisonData{}}
+	empty.Decoding.ErrorPositions = []int{}
+
+	tests := []struct {
+		name string
+		v    interface{ appendJSON([]byte) []byte }
+	}{
+		{"TemplateData/ゼロ値", TemplateData{}},
+		{"TemplateData/空のスライス", empty},
+		{"TemplateData/エスケープ", escaped},
+		{"TemplateData/符号化", encoded},
+		{"TemplateData/訂正の失敗", failed},
+		{"templateDataV1/ゼロ値", templateDataV1{}},
+		{"templateDataV1/エスケープ", toSchemaV1(escaped)},
+		{"templateDataV1/符号化", toSchemaV1(encoded)},
+		{"AnswerCheckData/ゼロ値", AnswerCheckData{}},
+		{"AnswerCheckData/エスケープ", AnswerCheckData{Text: escapedText, Success: true, Error: escapedText}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.v.appendJSON(nil); string(got) != string(want) {
+				t.Errorf("appendJSON と json.Marshal が異なります\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+//go:build tinygo
+
+package main
+
+// --- TinyGo での応答の JSON ---
+
+// TinyGo で作る場合 (wasm の大きさを数MBから数百KBにするため) は, encoding/json の代わりに
+// gen_json.go で生成した appendJSON で応答を書く. 結果は json_std.go と同じ文字列になる.
+//
+//	tinygo build -o docs/main.wasm -target wasm -no-debug ./wasm
+//	cp "$(tinygo env TINYGOROOT)/targets/wasm_exec.js" docs/
+//
+// (wasm_exec.js は Go と TinyGo で異なるので, 作った方に合わせて差し替える)
+//go:generate go run gen_json.go
+
+// jsonAppender は生成した appendJSON を持つ応答の型
+type jsonAppender interface {
+	appendJSON(b []byte) []byte
+}
+
+// jsonResponse は前処理を済ませた data を JSON 文字列にする
+func jsonResponse(data interface{}) string {
+	switch d := data.(type) {
+	case jsonAppender:
+		return string(d.appendJSON(nil))
+	case string:
+		return string(appendJSONString(nil, d))
+	}
+	return "null"
+}
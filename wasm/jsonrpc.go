@@ -0,0 +1,206 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// --- JSON-RPC 2.0 の標準入出力の API ---
+
+// エディタの拡張機能やノートブックからサブプロセスとして使うための入口. 1行に1つの JSON-RPC 2.0 の要求
+// (または要求の配列) を読み, 1行に1つの応答を書く. メソッドは apiHandlers の名前 (encode, ecc, mask, decode) で,
+// params は apiRequest と同じ項目のオブジェクトか, [input] または [input, オプション] の配列で渡す.
+//
+//	{"jsonrpc": "2.0", "id": 1, "method": "encode", "params": {"input": "漢字", "maskPattern": 2}}
+//	{"jsonrpc": "2.0", "id": 2, "method": "mask", "params": ["01000000 ...", {"locale": "en"}]}
+//
+// 結果は他の API と同じ応答 (TemplateData) で, 入力の誤りは -32000, 内部の誤りは -32603 のエラーとし,
+// どちらも data に Error を含む応答を入れる. id のない要求 (通知) には応答しない.
+
+// JSON-RPC 2.0 のエラーコード
+const (
+	rpcParseError     = -32700 // JSON として読めない
+	rpcInvalidRequest = -32600 // 要求の形が正しくない
+	rpcMethodNotFound = -32601 // 不明なメソッド
+	rpcInvalidParams  = -32602 // params の形が正しくない
+	rpcInternalError  = -32603 // 内部の誤り (ERR_INTERNAL)
+	rpcInputError     = -32000 // 入力の誤り (ERR_INTERNAL 以外のエラーコード)
+)
+
+// rpcRequest は JSON-RPC 2.0 の要求. ID が nil なら通知 (応答しない).
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// rpcResponse は JSON-RPC 2.0 の応答. Result と Error のどちらか一方を持つ.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// serveJSONRPC は r から JSON-RPC 2.0 の要求を1行ずつ読み, 応答を1行ずつ w に書く. r が終わると nil を返す.
+func serveJSONRPC(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxRequestBytes)
+	out := bufio.NewWriter(w)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		response := jsonRPCResponse(line)
+		if response == nil {
+			continue
+		}
+		out.Write(response)
+		out.WriteByte('\n')
+		if err := out.Flush(); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// jsonRPCResponse は1行の要求 (または要求の配列) を処理して応答の JSON を返す. 応答がなければ nil を返す.
+func jsonRPCResponse(line []byte) []byte {
+	if !json.Valid(line) {
+		return marshalRPC(rpcErrorResponse(nil, rpcParseError, localize(locale, "要求を JSON として読めませんでした."), nil))
+	}
+	if line[0] != '[' {
+		if response, ok := callJSONRPC(line); ok {
+			return marshalRPC(response)
+		}
+		return nil
+	}
+	var batch []json.RawMessage
+	if err := json.Unmarshal(line, &batch); err != nil || len(batch) == 0 {
+		return marshalRPC(rpcErrorResponse(nil, rpcInvalidRequest, localize(locale, "要求の配列が空です."), nil))
+	}
+	var responses []rpcResponse
+	for _, raw := range batch {
+		if response, ok := callJSONRPC(raw); ok {
+			responses = append(responses, response)
+		}
+	}
+	if len(responses) == 0 {
+		return nil
+	}
+	return marshalRPC(responses)
+}
+
+// callJSONRPC は1つの要求を処理する. 通知なら ok が false になる.
+func callJSONRPC(raw json.RawMessage) (response rpcResponse, ok bool) {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil || req.JSONRPC != "2.0" || req.Method == "" || !validRPCID(req.ID) {
+		id := req.ID
+		if !validRPCID(id) {
+			id = nil
+		}
+		return rpcErrorResponse(id, rpcInvalidRequest, localize(locale, "JSON-RPC 2.0 の要求ではありません."), nil), true
+	}
+	notification := req.ID == nil
+
+	fn, found := apiHandlers[req.Method]
+	if !found {
+		return rpcErrorResponse(req.ID, rpcMethodNotFound, localize(locale, "不明なメソッドです: %s (encode, ecc, mask, decode のどれかを指定してください).", req.Method), nil), !notification
+	}
+	params, err := rpcParams(req.Params)
+	if err != nil {
+		return rpcErrorResponse(req.ID, rpcInvalidParams, localizedMessage(locale, err), nil), !notification
+	}
+	data, err := callAPI(context.Background(), req.Method, params, fn)
+	result := json.RawMessage(jsonResponse(data))
+	switch {
+	case err == nil:
+		return rpcResponse{JSONRPC: "2.0", Result: result, ID: req.ID}, !notification
+	case hasErrorCode(err, errCodeInternal):
+		return rpcErrorResponse(req.ID, rpcInternalError, rpcErrorMessage(result, err), result), !notification
+	default:
+		return rpcErrorResponse(req.ID, rpcInputError, rpcErrorMessage(result, err), result), !notification
+	}
+}
+
+// rpcErrorMessage は応答の Error のメッセージ (要求の言語で書いたもの) を返す.
+// 応答の版1では Error は文字列, 版2では { Code, Message, ... } のオブジェクト.
+func rpcErrorMessage(result json.RawMessage, err error) string {
+	var response struct{ Error json.RawMessage }
+	var info ErrorInfo
+	var message string
+	if json.Unmarshal(result, &response) == nil {
+		if json.Unmarshal(response.Error, &info) == nil && info.Message != "" {
+			return info.Message
+		}
+		if json.Unmarshal(response.Error, &message) == nil && message != "" {
+			return message
+		}
+	}
+	return localizedMessage(locale, err)
+}
+
+// rpcParams は params (オブジェクト, [input], [input, オプション] のどれか. 省略も可) を apiRequest にする
+func rpcParams(raw json.RawMessage) (apiRequest, error) {
+	var req apiRequest
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return req, nil
+	}
+	if raw[0] != '[' {
+		if err := json.Unmarshal(raw, &req); err != nil {
+			return req, errorf("params を読めませんでした: %v", err)
+		}
+		return req, nil
+	}
+	var args []json.RawMessage
+	if err := json.Unmarshal(raw, &args); err != nil || len(args) == 0 || len(args) > 2 {
+		return req, errorf("params の配列は [input] か [input, オプション] で指定してください.")
+	}
+	if len(args) == 2 {
+		if err := json.Unmarshal(args[1], &req); err != nil {
+			return req, errorf("params を読めませんでした: %v", err)
+		}
+	}
+	if err := json.Unmarshal(args[0], &req.Input); err != nil {
+		return req, errorf("params の配列は [input] か [input, オプション] で指定してください.")
+	}
+	return req, nil
+}
+
+// validRPCID は id が省略, 文字列, 数値, null のどれかであるかを返す
+func validRPCID(id json.RawMessage) bool {
+	if id == nil {
+		return true
+	}
+	switch c := id[0]; {
+	case c == '"', c == '-', c >= '0' && c <= '9':
+		return true
+	}
+	return bytes.Equal(id, []byte("null"))
+}
+
+func rpcErrorResponse(id json.RawMessage, code int, message string, data json.RawMessage) rpcResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return rpcResponse{JSONRPC: "2.0", Error: &rpcError{Code: code, Message: message, Data: data}, ID: id}
+}
+
+func marshalRPC(v interface{}) []byte {
+	b, _ := json.Marshal(v)
+	return b
+}
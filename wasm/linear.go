@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- 線形符号としての見方 (生成行列・検査行列) ---
+
+// RS(26, 19) 符号は GF(2^8) 上の線形符号なので, 符号化は生成行列 G との積 c = m·G,
+// シンドロームの計算は検査行列 H (ヴァンデルモンド行列) との積 S = H·r^T で表せる.
+// 行列の列は他と同じく符号語の先頭 (x^25 の係数) から順に並べる.
+
+// CodeMatrixData は生成行列と検査行列, シンドロームの行列表示
+type CodeMatrixData struct {
+	N                      int     `json:"N"`
+	K                      int     `json:"K"`
+	GeneratorMatrix        [][]int `json:"GeneratorMatrix"`   // G (K×N). i 行目は x^(N-1-i) だけが1のデータの符号語
+	ParityCheckMatrix      [][]int `json:"ParityCheckMatrix"` // H (N-K×N). H[j][l] = (α^j)^(N-1-l)
+	GeneratorMatrixLatex   string  `json:"GeneratorMatrixLatex"`
+	ParityCheckMatrixLatex string  `json:"ParityCheckMatrixLatex"`
+	Orthogonal             bool    `json:"Orthogonal"` // G·H^T = 0 か (符号の検算)
+
+	Received      []int    `json:"Received"`      // シンドロームを計算した受信語 (指定された場合)
+	Syndromes     []int    `json:"Syndromes"`     // S = H·r^T
+	SyndromeRows  []string `json:"SyndromeRows"`  // S_j = Σ H[j][l]·r_l の各行 (LaTeX, 0の項は省く)
+	SyndromeLatex string   `json:"SyndromeLatex"` // H·r^T = S を行列の形で表したもの
+}
+
+// generatorMatrix は組織符号の生成行列を返す. 左側 K 列は単位行列, 右側は x^(N-1-i) を g(x) で割った余り.
+func (c *call) generatorMatrix(n, k int) ([][]int, error) {
+	g := c.lookupGeneratorPolynomial(n - k)
+	rows := make([][]int, k)
+	for i := range rows {
+		unit := make([]int, k)
+		unit[i] = 1
+		shifted := polyLeftShift(unit, n-k)
+		remainder, err := c.polyDiv(shifted, g)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = polyAdd(shifted, remainder)
+	}
+	return rows, nil
+}
+
+// parityCheckMatrix は検査行列 (ヴァンデルモンド行列) を返す. j 行目は r(x) に α^j を代入する計算に当たる.
+func (c *call) parityCheckMatrix(n, k int) [][]int {
+	rows := make([][]int, n-k)
+	for j := range rows {
+		rows[j] = make([]int, n)
+		for l := 0; l < n; l++ {
+			rows[j][l] = c.exp[(j*(n-1-l))%255]
+		}
+	}
+	return rows
+}
+
+// matrixVectorProduct は GF(2^8) 上の行列とベクトルの積を返す
+func (c *call) matrixVectorProduct(m [][]int, v []int) []int {
+	result := make([]int, len(m))
+	for i, row := range m {
+		for l, h := range row {
+			result[i] ^= c.gfMul(h, v[l])
+		}
+	}
+	return result
+}
+
+// matrixEntryLatex は行列の成分をLaTeXで表す (係数の表し方は formatCoefficient に従う)
+func (c *call) matrixEntryLatex(v int) string {
+	if v == 0 {
+		return "0"
+	}
+	return c.formatCoefficient(v)
+}
+
+// matrixLatex は行列を pmatrix 環境で表す
+func (c *call) matrixLatex(m [][]int) string {
+	var b strings.Builder
+	b.WriteString("\\begin{pmatrix}\n")
+	for i, row := range m {
+		entries := make([]string, len(row))
+		for l, v := range row {
+			entries[l] = c.matrixEntryLatex(v)
+		}
+		b.WriteString(strings.Join(entries, " & "))
+		if i < len(m)-1 {
+			b.WriteString(" \\\\")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\\end{pmatrix}")
+	return b.String()
+}
+
+// columnLatex はベクトルを縦に並べた pmatrix で表す
+func (c *call) columnLatex(v []int) string {
+	m := make([][]int, len(v))
+	for i, x := range v {
+		m[i] = []int{x}
+	}
+	return c.matrixLatex(m)
+}
+
+// processCodeMatrices は RS(26, 19) 符号の生成行列と検査行列を返す.
+// 受信語(2進数)が指定された場合は, シンドロームを行列とベクトルの積として計算する.
+func (c *call) processCodeMatrices(receivedBinary string) (TemplateData, error) {
+	n, k := totalCodewordCount, dataCodewordCount
+	g, err := c.generatorMatrix(n, k)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	h := c.parityCheckMatrix(n, k)
+
+	orthogonal := true
+	for _, row := range g {
+		for _, s := range c.matrixVectorProduct(h, row) {
+			if s != 0 {
+				orthogonal = false
+			}
+		}
+	}
+
+	var data TemplateData
+	data.CodeMatrix = CodeMatrixData{
+		N:                      n,
+		K:                      k,
+		GeneratorMatrix:        g,
+		ParityCheckMatrix:      h,
+		GeneratorMatrixLatex:   "G = " + c.matrixLatex(g),
+		ParityCheckMatrixLatex: "H = " + c.matrixLatex(h),
+		Orthogonal:             orthogonal,
+	}
+	if receivedBinary == "" {
+		return data, nil
+	}
+
+	receivedBytes, err := c.parseCodewordBinary(receivedBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	received := bytesToInts(receivedBytes)
+	syndromes := c.matrixVectorProduct(h, received)
+	rows := make([]string, len(h))
+	for j, row := range h {
+		var terms []string
+		for l, v := range row {
+			if received[l] == 0 {
+				continue
+			}
+			terms = append(terms, fmt.Sprintf("%s \\cdot %s", c.matrixEntryLatex(v), c.matrixEntryLatex(received[l])))
+		}
+		sum := "0"
+		if len(terms) > 0 {
+			sum = strings.Join(terms, " + ")
+		}
+		rows[j] = fmt.Sprintf("S_{%d} = %s = %s", j, sum, c.matrixEntryLatex(syndromes[j]))
+	}
+	data.Intermediate.CodewordHex = formatBytesToHex(receivedBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(receivedBytes)
+	data.CodeMatrix.Received = received
+	data.CodeMatrix.Syndromes = syndromes
+	data.CodeMatrix.SyndromeRows = rows
+	data.CodeMatrix.SyndromeLatex = fmt.Sprintf("H %s = %s", c.columnLatex(received), c.columnLatex(syndromes))
+	return data, nil
+}
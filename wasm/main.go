@@ -0,0 +1,88 @@
+//go:build js && wasm
+
+// wasm は QRコードの符号化の各段階を WebAssembly から呼べる関数として公開する.
+// 計算は internal/gf, internal/rs, internal/kanji と qr に任せ, ここでは途中の値を表示用に整えて JS に返す.
+//
+//	GOOS=js GOARCH=wasm go build -o docs/main.wasm ./wasm
+//
+// docs に埋め込む main.wasm は go generate ./docs で作り直す (wasm_exec.js も同じ Go のものにする).
+//
+// syscall/js を使うファイル (main.go, wrappers.go と *_js.go) は js/wasm のときだけ含めるので,
+// 計算と応答の組み立ての部分は他の環境でも go build, go vet, go test で確かめられる.
+package main
+
+// --- main関数 (Wasmエントリーポイント) ---
+
+func main() {
+	exportFunction("generateDataCodewords", generateDataCodewordsWrapper)
+	exportFunction("applyEcc", applyEccWrapper)
+	exportFunction("encodeAll", encodeAllWrapper)
+	exportFunction("applyMask", applyMaskWrapper)
+	exportFunction("getMatrix", getMatrixWrapper)
+	exportFunction("bitToModule", bitToModuleWrapper)
+	exportFunction("getMaskPattern", getMaskPatternWrapper)
+	exportFunction("renderMaskPattern", renderMaskPatternWrapper)
+	exportFunction("extractCodewords", extractCodewordsWrapper)
+	exportFunction("compareMasks", compareMasksWrapper)
+	exportFunction("renderSVG", renderSVGWrapper)
+	exportFunction("renderAnimatedSVG", renderAnimatedSVGWrapper)
+	exportFunction("renderPNG", renderPNGWrapper)
+	exportFunction("renderPBM", renderPBMWrapper)
+	exportFunction("renderXBM", renderXBMWrapper)
+	exportFunction("renderText", renderTextWrapper)
+	exportFunction("renderHTML", renderHTMLWrapper)
+	exportFunction("renderSourceMap", renderSourceMapWrapper)
+	exportFunction("renderPDF", renderPDFWrapper)
+	exportFunction("generateWorksheet", generateWorksheetWrapper)
+	exportFunction("computeSyndromes", computeSyndromesWrapper)
+	exportFunction("correctCodeword", correctCodewordWrapper)
+	exportFunction("correctErasures", correctErasuresWrapper)
+	exportFunction("injectErrors", injectErrorsWrapper)
+	exportFunction("simulateBurst", simulateBurstWrapper)
+	exportFunction("decodeAll", decodeAllWrapper)
+	exportFunction("verifyCodeword", verifyCodewordWrapper)
+	exportFunction("checkAnswer", checkAnswerWrapper)
+	exportFunction("validateDataCodewords", validateDataCodewordsWrapper)
+	exportFunction("validateCodeword", validateCodewordWrapper)
+	exportFunction("validateMaskedCodeword", validateMaskedCodewordWrapper)
+	exportFunction("getCorrectionCapacity", getCorrectionCapacityWrapper)
+	exportFunction("repairCodeword", repairCodewordWrapper)
+	exportFunction("gfAdd", gfAddWrapper)
+	exportFunction("gfMul", gfMulWrapper)
+	exportFunction("gfDiv", gfDivWrapper)
+	exportFunction("gfInv", gfInvWrapper)
+	exportFunction("gfPow", gfPowWrapper)
+	exportFunction("getGFTables", getGFTablesWrapper)
+	exportFunction("setPrimitivePolynomial", setPrimitivePolynomialWrapper)
+	exportFunction("getFieldTables", getFieldTablesWrapper)
+	exportFunction("fieldOperation", fieldOperationWrapper)
+	exportFunction("getConjugacyClasses", getConjugacyClassesWrapper)
+	exportFunction("getElementOrder", getElementOrderWrapper)
+	exportFunction("mapFieldElement", mapFieldElementWrapper)
+	exportFunction("polyEval", polyEvalWrapper)
+	exportFunction("polyMul", polyMulWrapper)
+	exportFunction("polyGCD", polyGCDWrapper)
+	exportFunction("polyDiv", polyDivWrapper)
+	exportFunction("getGeneratorPolynomial", getGeneratorPolynomialWrapper)
+	exportFunction("getGeneratorTable", getGeneratorTableWrapper)
+	exportFunction("setCoefficientFormat", setCoefficientFormatWrapper)
+	exportFunction("rsEncode", rsEncodeWrapper)
+	exportFunction("generateGFQuiz", generateGFQuizWrapper)
+	exportFunction("getCodeMatrices", getCodeMatricesWrapper)
+	exportFunction("setResponseFormat", setResponseFormatWrapper)
+	exportFunction("setLocale", setLocaleWrapper)
+	exportFunction("setSchemaVersion", setSchemaVersionWrapper)
+	exportFunction("appendChunk", appendChunkWrapper)
+	exportFunction("finishChunks", finishChunksWrapper)
+	exportFunction("createSession", createSessionWrapper)
+	exportFunction("runStep", runStepWrapper)
+	exportFunction("closeSession", closeSessionWrapper)
+	exportFunction("getTypeDefinitions", getTypeDefinitionsWrapper)
+	exportFunction("getCapabilities", getCapabilitiesWrapper)
+	exportFunction("getConstants", getConstantsWrapper)
+	exportFunction("getStats", getStatsWrapper)
+	exportFunction("shutdown", shutdownWrapper)
+	exportFunction("moduleToBit", moduleToBitWrapper)
+
+	<-shutdownRequested
+}
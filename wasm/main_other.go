@@ -0,0 +1,49 @@
+//go:build !(js && wasm) && !wasip1
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+// main は js/wasm 以外で作ったときの入口. 公開する関数は syscall/js で登録するので,
+// ここでは同じ処理を HTTP の API (server.go) か標準入出力の API (stdio.go) として公開する.
+// 学習用のページと授業のライブ表示をまとめて配るのは rscoder serve (cmd/rscoder) で行う.
+//
+//	go run ./wasm -http :8080
+//	curl -d '{"input": "漢字"}' localhost:8080/encode
+//	echo '{"command": "encode", "input": "漢字"}' | go run ./wasm -stdio
+//	echo '{"jsonrpc": "2.0", "id": 1, "method": "encode", "params": ["漢字"]}' | go run ./wasm -jsonrpc
+func main() {
+	addr := flag.String("http", "", "HTTP の API を公開するアドレス (例: :8080)")
+	stdio := flag.Bool("stdio", false, "標準入力から1行ずつ JSON の要求を読み, 応答を標準出力に書く")
+	jsonrpc := flag.Bool("jsonrpc", false, "標準入力から1行ずつ JSON-RPC 2.0 の要求を読み, 応答を標準出力に書く")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "このプログラムは GOOS=js GOARCH=wasm go build -o docs/main.wasm ./wasm で作り, ブラウザから読み込んで使います.")
+		fmt.Fprintln(flag.CommandLine.Output(), "他の環境では -http で POST /encode, /ecc, /mask, /decode を公開し, -stdio と -jsonrpc (JSON-RPC 2.0) で同じ処理を標準入出力から行います. コマンドラインで符号化する場合は go run ./cmd/rscoder を使ってください.")
+		fmt.Fprintln(flag.CommandLine.Output(), "学習用のページを手元で公開する場合は go run ./cmd/rscoder serve を使ってください.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *stdio {
+		if err := serveStdio(os.Stdin, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *jsonrpc {
+		if err := serveJSONRPC(os.Stdin, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if *addr == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	log.Printf("HTTP の API を %s で公開します", *addr)
+	log.Fatal(serveAPI(*addr))
+}
@@ -0,0 +1,25 @@
+//go:build wasip1
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// main は WASI (wasip1) で作ったときの入口. syscall/js の代わりに標準入出力で JSON の要求と応答をやり取りする (stdio.go).
+// 引数に -jsonrpc を渡すと JSON-RPC 2.0 でやり取りする (jsonrpc.go).
+//
+//	GOOS=wasip1 GOARCH=wasm go build -o rscoder.wasm ./wasm
+//	echo '{"command": "encode", "input": "漢字"}' | wasmtime rscoder.wasm
+//	echo '{"jsonrpc": "2.0", "id": 1, "method": "encode", "params": ["漢字"]}' | wasmtime rscoder.wasm -jsonrpc
+func main() {
+	serve := serveStdio
+	if len(os.Args) > 1 && os.Args[1] == "-jsonrpc" {
+		serve = serveJSONRPC
+	}
+	if err := serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
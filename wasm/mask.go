@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// --- マスクパターン ---
+
+// マスク条件 (qr.MaskConditions) と表示用の条件式 (qr.MaskConditionTexts) は qr パッケージにある.
+
+// MaskDerivationData はバイト単位のマスクの導出過程
+type MaskDerivationData struct {
+	PatternNumber int                  `json:"PatternNumber"`
+	Condition     string               `json:"Condition"`
+	MaskHex       string               `json:"MaskHex"`
+	Bytes         []MaskByteDerivation `json:"Bytes"`
+}
+
+// MaskByteDerivation は1バイト分のマスクが, どのモジュールの条件から決まったか
+type MaskByteDerivation struct {
+	CodewordIndex int     `json:"CodewordIndex"`
+	Positions     [][]int `json:"Positions"` // 上位ビットから順に [行, 列]
+	Binary        string  `json:"Binary"`
+	Hex           string  `json:"Hex"`
+}
+
+// deriveMaskPatternBytes は符号語の配置順にマスク条件を評価し, バイト単位のマスクを求める
+func deriveMaskPatternBytes(pattern int) ([]byte, []MaskByteDerivation) {
+	condition := qr.MaskConditions[pattern]
+	positions := qr.DataPositions()
+
+	maskBytes := make([]byte, len(positions)/8)
+	derivations := make([]MaskByteDerivation, len(maskBytes))
+	for i := range maskBytes {
+		derivations[i].CodewordIndex = i
+		for bit := 0; bit < 8; bit++ {
+			pos := positions[i*8+bit]
+			derivations[i].Positions = append(derivations[i].Positions, []int{pos.Row, pos.Col})
+			if condition(pos.Row, pos.Col) {
+				maskBytes[i] |= 0x80 >> bit
+			}
+		}
+		derivations[i].Binary = fmt.Sprintf("%08b", maskBytes[i])
+		derivations[i].Hex = fmt.Sprintf("%02X", maskBytes[i])
+	}
+	return maskBytes, derivations
+}
+
+// processMaskPattern は指定したパターン番号のバイト単位のマスクを導出する
+func (c *call) processMaskPattern(pattern int) (TemplateData, error) {
+	if pattern < 0 || pattern >= len(qr.MaskConditions) {
+		return TemplateData{}, errorf("マスクパターン番号は0から%dの範囲で指定してください.", len(qr.MaskConditions)-1)
+	}
+	maskBytes, derivations := deriveMaskPatternBytes(pattern)
+
+	var data TemplateData
+	data.Mask.PatternNumber = pattern
+	data.Mask.Condition = qr.MaskConditionTexts[pattern]
+	data.Mask.MaskHex = formatBytesToHex(maskBytes)
+	data.Mask.Bytes = derivations
+	data.Intermediate.MaskPatternHex = data.Mask.MaskHex
+	return data, nil
+}
+
+// processRenderMaskPattern はマスクパターンそのものを, 機能パターンを除いたシンボルの領域に描く.
+// 反転するモジュールを前景色, 反転しないモジュールを背景色, 機能パターンを灰色で塗ったSVGを返す.
+func (c *call) processRenderMaskPattern(pattern int, opts RenderOptions) (TemplateData, error) {
+	data, err := c.processMaskPattern(pattern)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	if err := opts.validate(); err != nil {
+		return TemplateData{}, err
+	}
+
+	m := qr.NewMatrix(symbolSize)
+	m.PlaceFunctionPatterns()
+	maskMatrix := make([][]int, m.Size)
+	for r := 0; r < m.Size; r++ {
+		maskMatrix[r] = make([]int, m.Size)
+		for c := 0; c < m.Size; c++ {
+			switch {
+			case m.IsFunction(r, c):
+				maskMatrix[r][c] = qr.ModuleReserved
+			case qr.MaskConditions[pattern](r, c):
+				maskMatrix[r][c] = qr.ModuleDark
+			default:
+				maskMatrix[r][c] = qr.ModuleLight
+			}
+		}
+	}
+	maskMatrix = addQuietZone(maskMatrix, opts.Margin)
+
+	size := len(maskMatrix) * opts.ModuleSize
+	var inverted, excluded []qr.Position
+	for r, row := range maskMatrix {
+		for c, v := range row {
+			if v == qr.ModuleDark {
+				inverted = append(inverted, qr.Position{Row: r, Col: c})
+			} else if v == qr.ModuleReserved {
+				excluded = append(excluded, qr.Position{Row: r, Col: c})
+			}
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, size, size)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`, opts.Background)
+	fmt.Fprintf(&b, `<path fill="#CCCCCC" d="%s"/>`, svgModulePath(excluded, opts.ModuleSize, 0))
+	fmt.Fprintf(&b, `<path fill="%s" d="%s"/>`, opts.Foreground, svgModulePath(inverted, opts.ModuleSize, 0))
+	b.WriteString(`</svg>`)
+
+	data.Matrix.Size = m.Size
+	data.Matrix.QuietZone = opts.Margin
+	data.Matrix.MaskPatternMatrix = maskMatrix
+	data.Render.Format = "svg"
+	data.Render.Content = b.String()
+	data.Render.Width = size
+	data.Render.Height = size
+	if opts.DataURI {
+		data.Render.DataURI = "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(data.Render.Content))
+	}
+	return data, nil
+}
+
+// --- マスクパターンの評価 ---
+
+// 失点の重み (N1〜N4)
+const (
+	penaltyWeightN1 = 3  // 同色モジュールが5個以上連続
+	penaltyWeightN2 = 3  // 2×2の同色ブロック
+	penaltyWeightN3 = 40 // 1:1:3:1:1 のパターン
+	penaltyWeightN4 = 10 // 暗モジュールの比率の50%からの偏り (5%ごと)
+)
+
+// MaskComparisonData は1つのマスクパターンを適用したシンボルとその失点
+type MaskComparisonData struct {
+	PatternNumber     int     `json:"PatternNumber"`
+	Condition         string  `json:"Condition"`
+	MaskedMatrix      [][]int `json:"MaskedMatrix"`
+	FormatInformation string  `json:"FormatInformation"`
+	PenaltyN1         int     `json:"PenaltyN1"`
+	PenaltyN2         int     `json:"PenaltyN2"`
+	PenaltyN3         int     `json:"PenaltyN3"`
+	PenaltyN4         int     `json:"PenaltyN4"`
+	PenaltyTotal      int     `json:"PenaltyTotal"`
+	DarkModuleCount   int     `json:"DarkModuleCount"`
+	Rank              int     `json:"Rank"` // 失点が少ない順 (同点は同順位)
+}
+
+// processCompareMasks は符号語(2進数, マスク前)に8種類のマスクパターンを適用し, 失点を比較する
+func (c *call) processCompareMasks(codewordBinary string) (TemplateData, error) {
+	codewordBytes, err := c.parseCodewordBinary(codewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	comparisons := make([]MaskComparisonData, len(qr.MaskConditions))
+	for pattern := range qr.MaskConditions {
+		if err := c.checkCancelled(); err != nil {
+			return TemplateData{}, err
+		}
+		m, err := buildSymbol(codewordBytes, pattern)
+		if err != nil {
+			return TemplateData{}, err
+		}
+		c := &comparisons[pattern]
+		c.PatternNumber = pattern
+		c.Condition = qr.MaskConditionTexts[pattern]
+		c.MaskedMatrix = m.Snapshot()
+		c.FormatInformation = fmt.Sprintf("%015b", qr.FormatInformation(eccLevelBits, pattern))
+		c.PenaltyN1 = penaltyN1(m.Modules)
+		c.PenaltyN2 = penaltyN2(m.Modules)
+		c.PenaltyN3 = penaltyN3(m.Modules)
+		c.PenaltyN4, c.DarkModuleCount = penaltyN4(m.Modules)
+		c.PenaltyTotal = c.PenaltyN1 + c.PenaltyN2 + c.PenaltyN3 + c.PenaltyN4
+	}
+	for i := range comparisons {
+		comparisons[i].Rank = 1
+		for j := range comparisons {
+			if comparisons[j].PenaltyTotal < comparisons[i].PenaltyTotal {
+				comparisons[i].Rank++
+			}
+		}
+	}
+
+	var data TemplateData
+	data.Intermediate.CodewordHex = formatBytesToHex(codewordBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(codewordBytes)
+	data.MaskComparisons = comparisons
+	return data, nil
+}
+
+// lines は行列の全ての行と列を返す (N1, N3の評価用)
+func lines(modules [][]int) [][]int {
+	size := len(modules)
+	result := make([][]int, 0, size*2)
+	for r := 0; r < size; r++ {
+		result = append(result, modules[r])
+	}
+	for c := 0; c < size; c++ {
+		column := make([]int, size)
+		for r := 0; r < size; r++ {
+			column[r] = modules[r][c]
+		}
+		result = append(result, column)
+	}
+	return result
+}
+
+// penaltyN1 は行・列で同色のモジュールが5個以上連続する箇所の失点 (3 + 超過数)
+func penaltyN1(modules [][]int) int {
+	penalty := 0
+	for _, line := range lines(modules) {
+		run := 1
+		for i := 1; i <= len(line); i++ {
+			if i < len(line) && line[i] == line[i-1] {
+				run++
+				continue
+			}
+			if run >= 5 {
+				penalty += penaltyWeightN1 + (run - 5)
+			}
+			run = 1
+		}
+	}
+	return penalty
+}
+
+// penaltyN2 は2×2の同色ブロックごとの失点
+func penaltyN2(modules [][]int) int {
+	penalty := 0
+	for r := 0; r+1 < len(modules); r++ {
+		for c := 0; c+1 < len(modules); c++ {
+			v := modules[r][c]
+			if modules[r][c+1] == v && modules[r+1][c] == v && modules[r+1][c+1] == v {
+				penalty += penaltyWeightN2
+			}
+		}
+	}
+	return penalty
+}
+
+// penaltyN3 は行・列に現れる 暗明暗暗暗明暗 (1:1:3:1:1) の前後いずれかに明モジュールが4個続く箇所の失点.
+// シンボルの外側は明モジュールとして扱う.
+func penaltyN3(modules [][]int) int {
+	patterns := [][]int{
+		{1, 0, 1, 1, 1, 0, 1, 0, 0, 0, 0},
+		{0, 0, 0, 0, 1, 0, 1, 1, 1, 0, 1},
+	}
+	penalty := 0
+	for _, line := range lines(modules) {
+		padded := make([]int, len(line)+8)
+		copy(padded[4:], line)
+		for start := 0; start+11 <= len(padded); start++ {
+			for _, pattern := range patterns {
+				match := true
+				for k, v := range pattern {
+					if padded[start+k] != v {
+						match = false
+						break
+					}
+				}
+				if match {
+					penalty += penaltyWeightN3
+				}
+			}
+		}
+	}
+	return penalty
+}
+
+// penaltyN4 は暗モジュールの比率が50%から5%ずれるごとの失点と, 暗モジュールの数を返す
+func penaltyN4(modules [][]int) (int, int) {
+	dark := 0
+	for _, row := range modules {
+		for _, v := range row {
+			if v == qr.ModuleDark {
+				dark++
+			}
+		}
+	}
+	total := len(modules) * len(modules)
+	deviation := dark*100/total - 50
+	if deviation < 0 {
+		deviation = -deviation
+	}
+	return deviation / 5 * penaltyWeightN4, dark
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// --- シンボル行列 (型番1) ---
+
+const symbolSize = qr.Size // 型番1の1辺のモジュール数
+
+const maxQuietZone = 16 // 指定できるクワイエットゾーンの最大幅
+
+// 誤り訂正レベルLを示す形式情報の2ビット (L:01, M:00, Q:11, H:10)
+const eccLevelBits = qr.ECCLevelL
+
+const formatInformationMask = qr.FormatMask // 形式情報に掛けるマスク 101010000010010
+
+// QRMatrixData は各段階のシンボル行列 (行優先, 0:明 1:暗 2:未確定)
+// QuietZone が1以上の場合, 各行列はその幅の明モジュールで囲まれている (Size はクワイエットゾーンを含まない).
+type QRMatrixData struct {
+	Size                  int      `json:"Size"`
+	QuietZone             int      `json:"QuietZone"`
+	FunctionPatternMatrix [][]int  `json:"FunctionPatternMatrix"`
+	DataPlacedMatrix      [][]int  `json:"DataPlacedMatrix"`
+	MaskedMatrix          [][]int  `json:"MaskedMatrix"`
+	FormatInformation     string   `json:"FormatInformation"` // 15ビットの形式情報 (上位ビットから)
+	RegionMatrix          [][]int  `json:"RegionMatrix"`      // 各モジュールの領域 (値は RegionNames の添字)
+	RegionNames           []string `json:"RegionNames"`
+	CodewordMatrix        [][]int  `json:"CodewordMatrix"`    // 各モジュールに配置された符号語の番号 (-1:機能パターン)
+	SourceMatrix          [][]int  `json:"SourceMatrix"`      // 各モジュールの由来となった入力文字の番号 (-1:文字以外)
+	MaskPatternMatrix     [][]int  `json:"MaskPatternMatrix"` // マスクパターンそのもの (1:反転する 0:反転しない 2:機能パターンのため対象外)
+}
+
+// addQuietZone は行列の周囲に幅 width の明モジュールを付け加えた新しい行列を返す
+func addQuietZone(modules [][]int, width int) [][]int {
+	return padMatrix(modules, width, qr.ModuleLight)
+}
+
+// padMatrix は行列の周囲を幅 width だけ値 fill で埋めた新しい行列を返す
+func padMatrix(modules [][]int, width, fill int) [][]int {
+	if width == 0 {
+		return modules
+	}
+	size := len(modules) + width*2
+	result := make([][]int, size)
+	for r := range result {
+		result[r] = make([]int, size)
+		for c := range result[r] {
+			result[r][c] = fill
+		}
+	}
+	for r, row := range modules {
+		copy(result[r+width][width:], row)
+	}
+	return result
+}
+
+// parseCodewordBinary は26バイトの符号語の2進数文字列を解析する
+func (c *call) parseCodewordBinary(codewordBinary string) ([]byte, error) {
+	codewordBytes, err := c.binaryStringToBytes(codewordBinary)
+	if err != nil {
+		return nil, errorf("符号語の2進数文字列の解析に失敗しました: %w", err)
+	}
+	if len(codewordBytes) != 26 {
+		return nil, newCodedError(errCodeBadLength, fmt.Sprint(len(codewordBytes)), noErrorPosition, "符号語は26バイトである必要がありますが, %dバイトでした.", len(codewordBytes))
+	}
+	return codewordBytes, nil
+}
+
+// processMatrix は符号語(2進数, マスク前)からシンボル行列を組み立てる
+func (c *call) processMatrix(codewordBinary string, quietZone int) (TemplateData, error) {
+	if quietZone < 0 || quietZone > maxQuietZone {
+		return TemplateData{}, errorf("クワイエットゾーンの幅は0から%dの範囲で指定してください.", maxQuietZone)
+	}
+	codewordBytes, err := c.parseCodewordBinary(codewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	m := qr.NewMatrix(symbolSize)
+	m.PlaceFunctionPatterns()
+
+	var data TemplateData
+	data.Matrix.Size = m.Size
+	data.Matrix.QuietZone = quietZone
+	data.Matrix.FunctionPatternMatrix = addQuietZone(m.Snapshot(), quietZone)
+
+	m.PlaceCodewords(codewordBytes)
+	m.MarkECCRegion(dataCodewordCount)
+	data.Matrix.DataPlacedMatrix = addQuietZone(m.Snapshot(), quietZone)
+
+	m.ApplyMask(qr.MaskConditions[c.opts.MaskPattern])
+	formatBits := qr.FormatInformation(eccLevelBits, c.opts.MaskPattern)
+	m.PlaceFormatInformation(formatBits)
+	data.Matrix.FormatInformation = fmt.Sprintf("%015b", formatBits)
+	data.Matrix.MaskedMatrix = addQuietZone(m.Snapshot(), quietZone)
+	data.Matrix.RegionMatrix = addQuietZone(qr.CopyModules(m.Region), quietZone)
+	data.Matrix.RegionNames = qr.RegionNames
+
+	return data, nil
+}
+
+// buildSymbol は qr.Symbol で符号語を配置し, マスクと形式情報まで適用したシンボルを組み立てる.
+// 符号語の長さとマスクパターンは呼び出し側で確かめてあるので, qr.Symbol のエラーは ERR_INTERNAL にする.
+func buildSymbol(codewordBytes []byte, pattern int) (*qr.Matrix, error) {
+	m, err := qr.Symbol(codewordBytes, pattern)
+	if err != nil {
+		return nil, newCodedError(errCodeInternal, err.Error(), noErrorPosition, "シンボルを組み立てられませんでした: %v", err)
+	}
+	return m, nil
+}
+
+// --- ビットとモジュールの対応 ---
+
+// BitPositionData は符号語のビットと行列上のモジュールの対応 (行・列はクワイエットゾーンを含まない)
+type BitPositionData struct {
+	CodewordIndex int `json:"CodewordIndex"` // 0始まりの符号語番号
+	BitIndex      int `json:"BitIndex"`      // 符号語内のビット位置 (0:最上位ビット 〜 7:最下位ビット)
+	Row           int `json:"Row"`
+	Column        int `json:"Column"`
+}
+
+// processBitToModule は符号語番号とビット位置から, そのビットが配置されるモジュールを求める
+func (c *call) processBitToModule(codewordIndex, bitIndex int) (TemplateData, error) {
+	positions := qr.DataPositions()
+	if codewordIndex < 0 || codewordIndex >= len(positions)/8 {
+		return TemplateData{}, errorf("符号語番号は0から%dの範囲で指定してください.", len(positions)/8-1)
+	}
+	if bitIndex < 0 || bitIndex > 7 {
+		return TemplateData{}, errorf("ビット位置は0から7の範囲で指定してください.")
+	}
+
+	pos := positions[codewordIndex*8+bitIndex]
+	var data TemplateData
+	data.BitPosition = BitPositionData{CodewordIndex: codewordIndex, BitIndex: bitIndex, Row: pos.Row, Column: pos.Col}
+	return data, nil
+}
+
+// processModuleToBit は行列上のモジュールから, そこに配置される符号語番号とビット位置を求める
+func (c *call) processModuleToBit(row, col int) (TemplateData, error) {
+	if row < 0 || row >= symbolSize || col < 0 || col >= symbolSize {
+		return TemplateData{}, errorf("行と列は0から%dの範囲で指定してください.", symbolSize-1)
+	}
+	for i, pos := range qr.DataPositions() {
+		if pos.Row == row && pos.Col == col {
+			var data TemplateData
+			data.BitPosition = BitPositionData{CodewordIndex: i / 8, BitIndex: i % 8, Row: row, Column: col}
+			return data, nil
+		}
+	}
+	return TemplateData{}, errorf("(%d, %d) は機能パターンのモジュールであり, 符号語のビットは配置されません.", row, col)
+}
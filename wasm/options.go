@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// --- 呼び出しごとのオプション ---
+
+// どの関数にも, 最後の引数として { version, eccLevel, maskPattern, outputFormat, parsing, locale, schemaVersion, verbosity, byteArrays, onProgress, signal } のオブジェクトを渡せる.
+// 省略した項目はこれまでと同じ値 (型番1, 誤り訂正レベルL, マスク000, setResponseFormat で選んだ形式) になる.
+
+// QROptions は1回の呼び出しで使う設定
+type QROptions struct {
+	Version       int    // 型番 (現在は1だけ)
+	ECCLevel      string // 誤り訂正レベル (現在は "L" だけ)
+	MaskPattern   int    // マスクパターン参照子 (0〜7)
+	OutputFormat  string // 応答の形式 ("json", "object", "transferable". 空なら setResponseFormat の設定に従う)
+	Parsing       string // 2進数文字列の読み取り方 ("strict", "lenient". 空なら空白を取り除くだけ)
+	Locale        string // メッセージの言語 ("ja", "en". 空なら setLocale の設定に従う)
+	SchemaVersion int    // 応答の版 (1, 2. 0なら setSchemaVersion の設定に従う)
+	Verbosity     string // 応答の詳しさ ("full", "compact". 空なら "full")
+	ByteArrays    bool   // 符号語や PNG, PDF を16進数・Base64 の文字列ではなく Uint8Array で返すか (object, transferable の形式のみ)
+
+	// Progress は処理の小さな段階が終わるたびに呼ばれる (JS の onProgress({ step, value }) に当たる. nil なら呼ばない)
+	Progress func(step, value string)
+
+	// Context が取り消されると, 長い処理 (全マスクの評価, 描画, 一括の符号化) を区切りでやめて ERR_CANCELLED を返す
+	// (JS の signal (AbortSignal) や, HTTP の要求の取り消しに当たる. nil なら取り消さない)
+	Context context.Context
+
+	yield   func() // 取り消しを確かめる前に JS のイベントループに処理を渡す (Async の呼び出しだけ)
+	release func() // 呼び出しが終わったら signal の監視をやめる
+}
+
+// 進み具合を知らせる段階の名前
+const (
+	progressConversion  = "conversion"  // 漢字を Shift-JIS に変換して13ビットに圧縮した (value: 連結したビット列)
+	progressBitAssembly = "bitAssembly" // モード指示子・文字数指示子・終端パターンを付けた (value: ビット列)
+	progressPadding     = "padding"     // 埋め草コード語を付けた (value: データコード語の16進数)
+	progressDivision    = "division"    // 生成多項式で割って誤り訂正コード語を求めた (value: 誤り訂正コード語の16進数)
+	progressMasking     = "masking"     // マスクを掛けた (value: マスク後の符号語の16進数)
+)
+
+// qrOptionKeys はオプションのオブジェクトのキー
+var qrOptionKeys = []string{"version", "eccLevel", "maskPattern", "outputFormat", "parsing", "locale", "schemaVersion", "verbosity", "byteArrays", "onProgress", "signal"}
+
+func defaultQROptions() QROptions {
+	return QROptions{Version: 1, ECCLevel: "L", MaskPattern: defaultMaskPattern}
+}
+
+// validate はオプションの値を確認する
+func (o QROptions) validate() error {
+	if o.Version != 1 {
+		return errorf("型番は現在1だけに対応しています: %d", o.Version)
+	}
+	if o.ECCLevel != "L" {
+		return errorf("誤り訂正レベルは現在 L だけに対応しています: %s", o.ECCLevel)
+	}
+	if o.MaskPattern < 0 || o.MaskPattern > 7 {
+		return errorf("マスクパターン参照子は0〜7で指定してください: %d", o.MaskPattern)
+	}
+	if o.OutputFormat != "" && !isResponseFormat(o.OutputFormat) {
+		return errorf("不明な応答の形式です: %s (json, object, transferable のどれかを指定してください).", o.OutputFormat)
+	}
+	if o.Parsing != "" && o.Parsing != parsingStrict && o.Parsing != parsingLenient {
+		return errorf("不明な読み取り方です: %s (strict または lenient を指定してください).", o.Parsing)
+	}
+	if o.Locale != "" && !isLocale(o.Locale) {
+		return errorf("不明な言語です: %s (ja または en を指定してください).", o.Locale)
+	}
+	if o.SchemaVersion != 0 && o.SchemaVersion != schemaVersion && o.SchemaVersion != schemaVersionV1 {
+		return errorf("対応していない応答の版です: %d (%d または %d を指定してください).", o.SchemaVersion, schemaVersionV1, schemaVersion)
+	}
+	if o.Verbosity != "" && o.Verbosity != verbosityFull && o.Verbosity != verbosityCompact {
+		return errorf("不明な詳しさです: %s (full または compact を指定してください).", o.Verbosity)
+	}
+	return nil
+}
+
+// call は1回の呼び出しで使う Encoder とオプション. 処理の関数 (process*) はこのメソッドにし,
+// パッケージの変数 (currentEncoder や setLocale などで選んだ既定値) は newCall で一度だけ読む.
+// 呼び出しごとに別の call を作るので, 同時に実行しても互いの設定を書き換えない.
+type call struct {
+	*Encoder
+	opts              QROptions // 省略した言語・応答の形式・応答の版は既定値で埋めてある
+	coefficientFormat string    // 多項式の係数の表し方 (setCoefficientFormat で選んだもの)
+	mark              time.Time // 前の段階が終わった時刻 (呼び出しの始めに合わせる)
+}
+
+// newCall は Encoder e とオプション opts で1回の呼び出しを始める
+func newCall(e *Encoder, opts QROptions) *call {
+	if opts.OutputFormat == "" {
+		opts.OutputFormat = responseFormat
+	}
+	if opts.Locale == "" {
+		opts.Locale = locale
+	}
+	if opts.SchemaVersion == 0 {
+		opts.SchemaVersion = requestedSchemaVersion
+	}
+	return &call{Encoder: e, opts: opts, coefficientFormat: coefficientFormat, mark: time.Now()}
+}
+
+// defaultCall は既定のオプションの呼び出しを始める (要求を読めなかったときのエラーの応答などに使う)
+func defaultCall() *call {
+	return newCall(currentEncoder.Load(), defaultQROptions())
+}
+
+// maskPatternBytes は呼び出しのマスクパターンを符号語に重ねたバイト列を返す
+func (c *call) maskPatternBytes() []byte {
+	if c.opts.MaskPattern == c.maskPattern {
+		return c.maskBytes
+	}
+	bytes, _ := deriveMaskPatternBytes(c.opts.MaskPattern)
+	return bytes
+}
+
+// reportProgress は段階 step が終わったことを統計に記録し, 呼び出しに進み具合の通知先があれば知らせる
+func (c *call) reportProgress(step, value string) {
+	now := time.Now()
+	recordStep(step, now.Sub(c.mark))
+	c.mark = now
+	if c.opts.Progress != nil {
+		c.opts.Progress(step, value)
+	}
+}
+
+// checkCancelled は呼び出しが取り消されていれば ERR_CANCELLED のエラーを返す. 長い処理の区切りごとに呼ぶ.
+func (c *call) checkCancelled() error {
+	if c.opts.Context == nil {
+		return nil
+	}
+	if c.opts.yield != nil {
+		c.opts.yield()
+	}
+	if c.opts.Context.Err() != nil {
+		return errCancelled
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"context"
+	"syscall/js"
+	"time"
+)
+
+// qrOptionsArg は v がオプションのオブジェクト (qrOptionKeys のどれかを持つ) なら読み取る.
+// onlyOptions は v がオプション以外のキーを持たないか (描画オプションなどと兼ねていないか) を表す.
+func qrOptionsArg(v js.Value) (opts QROptions, found, onlyOptions bool) {
+	opts = defaultQROptions()
+	if v.Type() != js.TypeObject || js.Global().Get("Array").Call("isArray", v).Bool() {
+		return opts, false, false
+	}
+	for _, key := range qrOptionKeys {
+		if v.Get(key).Type() != js.TypeUndefined {
+			found = true
+		}
+	}
+	if !found {
+		return opts, false, false
+	}
+	if x := v.Get("version"); x.Type() == js.TypeNumber {
+		opts.Version = x.Int()
+	}
+	if x := v.Get("eccLevel"); x.Type() == js.TypeString {
+		opts.ECCLevel = x.String()
+	}
+	if x := v.Get("maskPattern"); x.Type() == js.TypeNumber {
+		opts.MaskPattern = x.Int()
+	}
+	if x := v.Get("outputFormat"); x.Type() == js.TypeString {
+		opts.OutputFormat = x.String()
+	}
+	if x := v.Get("parsing"); x.Type() == js.TypeString {
+		opts.Parsing = x.String()
+	}
+	if x := v.Get("locale"); x.Type() == js.TypeString {
+		opts.Locale = x.String()
+	}
+	if x := v.Get("schemaVersion"); x.Type() == js.TypeNumber {
+		opts.SchemaVersion = x.Int()
+	}
+	if x := v.Get("verbosity"); x.Type() == js.TypeString {
+		opts.Verbosity = x.String()
+	}
+	if x := v.Get("byteArrays"); x.Type() == js.TypeBoolean {
+		opts.ByteArrays = x.Bool()
+	}
+	if callback := v.Get("onProgress"); callback.Type() == js.TypeFunction {
+		opts.Progress = func(step, value string) {
+			callback.Invoke(map[string]interface{}{"step": step, "value": value})
+		}
+	}
+	if signal := v.Get("signal"); signal.Type() == js.TypeObject {
+		ctx, cancel := context.WithCancel(context.Background())
+		onAbort := js.FuncOf(func(js.Value, []js.Value) interface{} {
+			cancel()
+			return nil
+		})
+		if signal.Get("aborted").Truthy() {
+			cancel()
+		}
+		signal.Call("addEventListener", "abort", onAbort)
+		opts.Context = ctx
+		opts.release = func() {
+			signal.Call("removeEventListener", "abort", onAbort)
+			onAbort.Release()
+			cancel()
+		}
+	}
+	keys := js.Global().Get("Object").Call("keys", v)
+	onlyOptions = true
+	for i := 0; i < keys.Length(); i++ {
+		isOption := false
+		for _, key := range qrOptionKeys {
+			if keys.Index(i).String() == key {
+				isOption = true
+			}
+		}
+		if !isOption {
+			onlyOptions = false
+		}
+	}
+	return opts, true, onlyOptions
+}
+
+// withOptions は最後の引数のオプションを読み取り, その呼び出し (call) で fn を呼ぶ関数にする.
+// オプションだけのオブジェクトは引数から取り除くので, 各関数の引数の数は変わらない.
+// async (Promise で返す版) では, 取り消しを確かめるたびにイベントループに処理を渡し, signal の abort を受け取れるようにする.
+// 同期の版はイベントループを止めているので, 呼び出しの前に取り消された signal だけを受け付ける.
+func withOptions(fn func(c *call, args []js.Value) interface{}, async bool) func(this js.Value, args []js.Value) interface{} {
+	return func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 {
+			return fn(defaultCall(), args)
+		}
+		opts, found, onlyOptions := qrOptionsArg(args[len(args)-1])
+		if !found {
+			return fn(defaultCall(), args)
+		}
+		if opts.release != nil {
+			defer opts.release()
+		}
+		if async && opts.Context != nil {
+			opts.yield = yieldEventLoop
+		}
+		if err := opts.validate(); err != nil {
+			return defaultCall().errorResponse(err)
+		}
+		if onlyOptions {
+			args = args[:len(args)-1]
+		}
+		return fn(newCall(currentEncoder.Load(), opts), args)
+	}
+}
+
+// yieldEventLoop は goroutine を少し眠らせ, その間に JS のイベント (signal の abort など) を処理させる.
+// 同期の呼び出しの中で眠るとイベントループが止まったままになるので, Async の版からだけ使う.
+func yieldEventLoop() {
+	time.Sleep(time.Millisecond)
+}
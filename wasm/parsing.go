@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- 2進数文字列の読み取り方 ---
+
+// 呼び出しのオプション { parsing } で, binaryStringToBytes の読み取り方を選べる.
+// 省略した場合はこれまでどおり空白と改行を取り除いてから8ビットずつ読む.
+const (
+	parsingStrict  = "strict"  // 0, 1 と, 8ビットごとの区切りの半角空白1つだけを受け付ける
+	parsingLenient = "lenient" // カンマ, セミコロンでも区切れ, 0b, 0x の接頭辞や16進数も受け付ける
+)
+
+// strictBinaryToBytes は 0, 1 と, バイトの境目に置いた半角空白1つ以外の文字があればエラーにする
+func strictBinaryToBytes(binaryStr string) ([]byte, error) {
+	var bits strings.Builder
+	previousSpace := true // 先頭の空白も認めない
+	for i, r := range []rune(binaryStr) {
+		switch {
+		case r == '0' || r == '1':
+			bits.WriteRune(r)
+			previousSpace = false
+		case r == ' ' && !previousSpace && bits.Len()%8 == 0:
+			previousSpace = true
+		case r == ' ':
+			return nil, newCodedError(errCodeBadBinary, string(r), i, "%d文字目の空白はバイトの区切りの位置にありません (strict).", i)
+		default:
+			return nil, newCodedError(errCodeBadBinary, string(r), i, "%d文字目の '%c' は使えない文字です (strict: 0, 1 と区切りの空白だけ).", i, r)
+		}
+	}
+	if previousSpace && bits.Len() > 0 {
+		return nil, newCodedError(errCodeBadBinary, " ", len([]rune(binaryStr))-1, "末尾に空白があります (strict).")
+	}
+	return bitStreamToBytes(bits.String())
+}
+
+// lenientBinaryToBytes は空白, カンマ, セミコロンで区切った値を読む. 区切りの中の _ は無視する.
+// 値は 0b で始まれば2進数, 0x で始まれば16進数 (1桁4ビット), 接頭辞がなければ 0, 1 だけなら2進数, それ以外は16進数とする.
+func lenientBinaryToBytes(binaryStr string) ([]byte, error) {
+	var bits strings.Builder
+	runes := []rune(binaryStr)
+	for start := 0; start < len(runes); {
+		if isLenientSeparator(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && !isLenientSeparator(runes[end]) {
+			end++
+		}
+		tokenBits, err := lenientTokenBits(strings.ReplaceAll(string(runes[start:end]), "_", ""))
+		if err != nil {
+			return nil, newCodedError(errCodeBadBinary, string(runes[start:end]), start, "%d文字目からの '%s' を読めません: %v", start, string(runes[start:end]), err)
+		}
+		bits.WriteString(tokenBits)
+		start = end
+	}
+	return bitStreamToBytes(bits.String())
+}
+
+func isLenientSeparator(r rune) bool {
+	return r == ' ' || r == '\n' || r == '\r' || r == '\t' || r == ',' || r == ';'
+}
+
+// lenientTokenBits は区切られた1つの値をビット列にする
+func lenientTokenBits(token string) (string, error) {
+	lower := strings.ToLower(token)
+	switch {
+	case strings.HasPrefix(lower, "0b"):
+		return binaryDigits(lower[2:])
+	case strings.HasPrefix(lower, "0x"):
+		return hexDigitsToBits(lower[2:])
+	}
+	if bits, err := binaryDigits(lower); err == nil {
+		return bits, nil
+	}
+	return hexDigitsToBits(lower)
+}
+
+func binaryDigits(s string) (string, error) {
+	if s == "" {
+		return "", errorf("数字がありません")
+	}
+	for _, r := range s {
+		if r != '0' && r != '1' {
+			return "", errorf("'%c' は2進数の数字ではありません", r)
+		}
+	}
+	return s, nil
+}
+
+func hexDigitsToBits(s string) (string, error) {
+	if s == "" {
+		return "", errorf("数字がありません")
+	}
+	var bits strings.Builder
+	for _, r := range s {
+		var v int
+		switch {
+		case r >= '0' && r <= '9':
+			v = int(r - '0')
+		case r >= 'a' && r <= 'f':
+			v = int(r-'a') + 10
+		default:
+			return "", errorf("'%c' は16進数の数字ではありません", r)
+		}
+		fmt.Fprintf(&bits, "%04b", v)
+	}
+	return bits.String(), nil
+}
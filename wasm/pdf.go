@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image/color"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// --- PDF出力 (シンボルと計算過程を1ページにまとめる) ---
+
+// A4の大きさ (ポイント)
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMarginLeft = 40
+)
+
+// pdfPage は1ページ分の描画命令を組み立てる.
+// 日本語を表示するため, 文字は規格で定められた日本語フォント (HeiseiKakuGo-W5, UniJIS-UCS2-H) で描く.
+type pdfPage struct {
+	content strings.Builder
+	y       float64 // 次に文字を書く位置 (上から下へ進む)
+}
+
+// text は (x, y) に文字列を描く (yはページ下端からの距離)
+func (p *pdfPage) text(x, y, size float64, s string) {
+	var hexText strings.Builder
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&hexText, "%04X", u)
+	}
+	fmt.Fprintf(&p.content, "BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n", size, x, y, hexText.String())
+}
+
+// line は現在位置に1行書き, 次の行へ進む. 長い行は width 文字ごとに折り返す.
+func (p *pdfPage) line(size float64, s string, width int) {
+	runes := []rune(s)
+	for {
+		n := len(runes)
+		if n > width {
+			n = width
+		}
+		p.text(pdfMarginLeft, p.y, size, string(runes[:n]))
+		p.y -= size * 1.4
+		runes = runes[n:]
+		if len(runes) == 0 {
+			return
+		}
+	}
+}
+
+// setFill は以降の塗りつぶしの色を設定する
+func (p *pdfPage) setFill(c color.RGBA) {
+	fmt.Fprintf(&p.content, "%.3f %.3f %.3f rg\n", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+// rect は塗りつぶした長方形を描く
+func (p *pdfPage) rect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%.2f %.2f %.2f %.2f re f\n", x, y, w, h)
+}
+
+// buildPDF は1ページのPDFファイルを組み立てる
+func buildPDF(content string) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>", pdfPageWidth, pdfPageHeight),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+		"<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5 /Encoding /UniJIS-UCS2-H /DescendantFonts [6 0 R] >>",
+		// CID 1〜95 (ASCII) は半角幅
+		"<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5 /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> /FontDescriptor 7 0 R /DW 1000 /W [1 95 500] >>",
+		"<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4 /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 752 /Descent -221 /CapHeight 737 /StemV 114 >>",
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+	return buf.Bytes()
+}
+
+// plainPolynomial は formatPolynomial のLaTeX表記をPDF用の平文 (α^k x^n など) に直す
+func plainPolynomial(latex string) string {
+	replacer := strings.NewReplacer("\\alpha", "α", "\\mathtt", "", " \\cdot ", "", "{", "", "}", "")
+	return replacer.Replace(latex)
+}
+
+// processRenderPDF は漢字入力から STEP1〜4 を行い, シンボルと計算過程を1ページのPDFにまとめる
+// シンボルの大きさは紙面に収まるよう決めるので, opts.ModuleSize は使わない.
+func (c *call) processRenderPDF(kanjiInput string, opts RenderOptions) (TemplateData, error) {
+	if err := opts.validate(); err != nil {
+		return TemplateData{}, err
+	}
+	step2, err := c.processStep1To2(kanjiInput)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	step3, err := c.processStep3(step2.Intermediate.PaddedBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	step4, err := c.processStep4(step3.Intermediate.CodewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	codewordBytes, err := c.parseCodewordBinary(step3.Intermediate.CodewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	if err := c.checkCancelled(); err != nil {
+		return TemplateData{}, err
+	}
+
+	page := &pdfPage{y: pdfPageHeight - 50}
+	page.line(16, "QRコード 符号化の過程 (型番1, 誤り訂正レベルL)", 60)
+	page.y -= 4
+	page.line(11, "入力: "+kanjiInput, 60)
+	page.y -= 6
+
+	page.line(12, "STEP1: 13ビット圧縮", 60)
+	page.line(9, "漢字   Shift JIS   減算後                   圧縮後   13ビット", 100)
+	for _, res := range step2.Results {
+		page.line(9, fmt.Sprintf("%s     %s        %-24s %s     %s", res.Kanji, res.ShiftJISCode, res.SubtractedCode, res.CompressedHex, res.Binary13Bit), 100)
+	}
+	page.y -= 6
+
+	page.line(12, "STEP2: データコード語", 60)
+	page.line(9, "モード指示子 + 文字数指示子: "+step2.Intermediate.ModeIndicator+" "+step2.Intermediate.CharCountIndicator, 100)
+	page.line(9, "データコード語 (16進数): "+step2.Intermediate.PaddedHex, 100)
+	page.y -= 6
+
+	page.line(12, "STEP3: 誤り訂正符号化", 60)
+	page.line(9, "I(x) = "+plainPolynomial(step3.Intermediate.DataPolynomial), 100)
+	page.line(9, "R(x) = "+plainPolynomial(step3.Intermediate.ErrorCorrectionPolynomial), 100)
+	page.line(9, "符号語 (16進数): "+step3.Intermediate.CodewordHex, 100)
+	page.y -= 6
+
+	page.line(12, "STEP4: マスク処理", 60)
+	page.line(9, "マスクパターン: "+step4.Intermediate.MaskPatternHex, 100)
+	page.line(9, "マスク後 (16進数): "+step4.Intermediate.MaskedCodewordHex, 100)
+	page.y -= 10
+
+	// シンボル (クワイエットゾーン込みで残りの領域に収まる大きさで描く)
+	symbol, err := buildSymbol(codewordBytes, c.opts.MaskPattern)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	modules := addQuietZone(symbol.Snapshot(), opts.Margin)
+	moduleSize := 6.0
+	if available := page.y - 40; available < moduleSize*float64(len(modules)) {
+		moduleSize = available / float64(len(modules))
+	}
+	foreground, _ := parseHexColor(opts.Foreground)
+	background, _ := parseHexColor(opts.Background)
+	top := page.y
+	page.setFill(background)
+	page.rect(pdfMarginLeft, top-float64(len(modules))*moduleSize, float64(len(modules))*moduleSize, float64(len(modules))*moduleSize)
+	page.setFill(foreground)
+	for r, row := range modules {
+		for c, v := range row {
+			if v == qr.ModuleDark {
+				page.rect(pdfMarginLeft+float64(c)*moduleSize, top-float64(r+1)*moduleSize, moduleSize, moduleSize)
+			}
+		}
+	}
+
+	pdfBytes := buildPDF(page.content.String())
+
+	var data TemplateData
+	data.KanjiInput = kanjiInput
+	data.Render.Format = "pdf"
+	data.Render.Content = base64.StdEncoding.EncodeToString(pdfBytes)
+	data.Render.Width = pdfPageWidth
+	data.Render.Height = pdfPageHeight
+	if opts.DataURI {
+		data.Render.DataURI = "data:application/pdf;base64," + data.Render.Content
+	}
+	return data, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+// --- 一括の符号化 ---
+
+// processEncodeAll は STEP1-2 から STEP4 までと行列の組み立て, 描画をまとめて行い, 1つの TemplateData にする.
+// 途中の段階を見なくてよい場合に使う. format が空なら描画は省く. 取り消されると段階の区切りでやめる.
+func (c *call) processEncodeAll(kanjiInput, format string, opts RenderOptions) (TemplateData, error) {
+	data, err := c.processStep1To2(kanjiInput)
+	if err != nil {
+		return data, err
+	}
+	if err := c.checkCancelled(); err != nil {
+		return data, err
+	}
+	dataBytes, err := hexStringToBytes(data.Intermediate.PaddedHex)
+	if err != nil {
+		return data, errorf("データコード語の変換に失敗しました: %w", err)
+	}
+
+	step3, err := c.processStep3Bytes(dataBytes)
+	if err != nil {
+		return data, err
+	}
+	codewordBytes, err := hexStringToBytes(step3.Intermediate.CodewordHex)
+	if err != nil {
+		return data, errorf("符号語の変換に失敗しました: %w", err)
+	}
+	if err := c.checkCancelled(); err != nil {
+		return data, err
+	}
+	step4, err := c.processStep4Bytes(codewordBytes)
+	if err != nil {
+		return data, err
+	}
+
+	// STEP3 の途中経過に, STEP1-2 のビット列と STEP4 のマスクの結果を加える
+	intermediate := step3.Intermediate
+	intermediate.ModeIndicator = data.Intermediate.ModeIndicator
+	intermediate.CharCountIndicator = data.Intermediate.CharCountIndicator
+	intermediate.ConcatenatedBinary = data.Intermediate.ConcatenatedBinary
+	intermediate.TerminatedBinary = data.Intermediate.TerminatedBinary
+	intermediate.PaddedBinaryBlocks = data.Intermediate.PaddedBinaryBlocks
+	intermediate.MaskPatternHex = step4.Intermediate.MaskPatternHex
+	intermediate.MaskedCodewordHex = step4.Intermediate.MaskedCodewordHex
+	intermediate.MaskedCodewordBinary = step4.Intermediate.MaskedCodewordBinary
+	data.Intermediate = intermediate
+	data.Polynomial.DivisionRows = step3.Polynomial.DivisionRows
+
+	if err := c.checkCancelled(); err != nil {
+		return data, err
+	}
+	matrix, err := c.processMatrix(intermediate.CodewordBinary, opts.Margin)
+	if err != nil {
+		return data, err
+	}
+	data.Matrix = matrix.Matrix
+
+	if format != "" {
+		if err := c.checkCancelled(); err != nil {
+			return data, err
+		}
+		render, err := c.processRender(intermediate.CodewordBinary, format, opts)
+		if err != nil {
+			return data, err
+		}
+		data.Render = render.Render
+	}
+	return data, nil
+}
@@ -0,0 +1,440 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// --- 多項式の計算 (フロントエンドの電卓用, GF(2^8) 上) ---
+
+// 多項式は他と同じく最高次の係数から順に並べた整数の配列で受け渡す.
+
+// PolynomialData は多項式の計算の結果
+type PolynomialData struct {
+	Operands    [][]int      `json:"Operands"`    // 入力した多項式
+	OperandsTex []string     `json:"OperandsTex"` // 入力した多項式のLaTeX表記
+	X           int          `json:"X"`           // 代入した値
+	Value       int          `json:"Value"`       // p(x) の値
+	ValueAlpha  string       `json:"ValueAlpha"`
+	Horner      []HornerStep `json:"Horner"`
+
+	Result          []int            `json:"Result"` // 演算の結果の多項式
+	ResultTex       string           `json:"ResultTex"`
+	PartialProducts []PartialProduct `json:"PartialProducts"`
+	GCDSteps        []DivisionStep   `json:"GCDSteps"`
+	DivisionRows    []DivisionRow    `json:"DivisionRows"`
+	Quotient        []int            `json:"Quotient"`
+	QuotientTex     string           `json:"QuotientTex"`
+	GeneratorSteps  []GeneratorStep  `json:"GeneratorSteps"`
+	GeneratorTable  []GeneratorEntry `json:"GeneratorTable"`
+
+	CoefficientFormat string `json:"CoefficientFormat"` // LaTeX表記の係数の表し方 ("alpha", "decimal", "hex")
+}
+
+// GeneratorEntry は生成多項式の表の1行
+type GeneratorEntry struct {
+	Degree       int    `json:"Degree"`
+	Coefficients []int  `json:"Coefficients"` // 係数 (最高次から, 整数)
+	Exponents    []int  `json:"Exponents"`    // 係数を α^k で表したときの k (規格の表と同じ形)
+	Latex        string `json:"Latex"`
+}
+
+// qrGeneratorDegrees は QRコードの誤り訂正コード語数として使われる生成多項式の次数 (規格の附属書の表と同じ)
+var qrGeneratorDegrees = []int{7, 10, 13, 15, 16, 17, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 66, 68}
+
+// GeneratorStep は生成多項式を求める途中の積 g_i(x) = g_{i-1}(x)·(x + α^{i-1})
+type GeneratorStep struct {
+	Index        int    `json:"Index"`        // i
+	Factor       string `json:"Factor"`       // 掛けた因数 (LaTeX)
+	Coefficients []int  `json:"Coefficients"` // g_i(x) の係数 (最高次から, 整数)
+	Latex        string `json:"Latex"`        // g_i(x) のLaTeX表記 (α表記)
+}
+
+// DivisionRow は筆算による割り算の1行 (先頭の係数を消すために α^k·x^d·g(x) を引く)
+type DivisionRow struct {
+	Step           int    `json:"Step"`
+	QuotientDegree int    `json:"QuotientDegree"` // この行で立てる商の項の次数 d
+	Factor         int    `json:"Factor"`         // この行で立てる商の項の係数
+	FactorAlpha    string `json:"FactorAlpha"`
+	Subtrahend     []int  `json:"Subtrahend"` // 引く多項式 (被除数と同じ長さにそろえる)
+	Result         []int  `json:"Result"`     // 引いた (XORした) 後の多項式 (被除数と同じ長さ)
+	SubtrahendTex  string `json:"SubtrahendTex"`
+	ResultTex      string `json:"ResultTex"`
+}
+
+// DivisionStep は割り算 dividend = quotient·divisor + remainder の1回分
+type DivisionStep struct {
+	Step         int    `json:"Step"`
+	Dividend     []int  `json:"Dividend"`
+	Divisor      []int  `json:"Divisor"`
+	Quotient     []int  `json:"Quotient"`
+	Remainder    []int  `json:"Remainder"`
+	DividendTex  string `json:"DividendTex"`
+	DivisorTex   string `json:"DivisorTex"`
+	QuotientTex  string `json:"QuotientTex"`
+	RemainderTex string `json:"RemainderTex"`
+}
+
+// PartialProduct は積の展開の1項分 (p1 の1つの項 × p2)
+type PartialProduct struct {
+	Degree      int    `json:"Degree"`      // p1 の項の次数
+	Coefficient int    `json:"Coefficient"` // p1 の項の係数
+	Product     []int  `json:"Product"`     // その項と p2 の積 (次数は結果にそろえる)
+	ProductTex  string `json:"ProductTex"`
+}
+
+// HornerStep はホーナー法の1段 (acc ← acc·x + 係数)
+type HornerStep struct {
+	Degree      int `json:"Degree"` // この段で加える係数の次数
+	Coefficient int `json:"Coefficient"`
+	Product     int `json:"Product"` // 前の段の値 × x
+	Accumulator int `json:"Accumulator"`
+}
+
+// validatePolynomial は係数が全て GF(2^8) の要素で, 空でないことを確かめる
+func validatePolynomial(p []int) error {
+	if len(p) == 0 {
+		return errorf("多項式の係数を1つ以上指定してください.")
+	}
+	for i, c := range p {
+		if c < 0 || c > 255 {
+			return errorf("係数は0〜255で指定してください (%d番目: %d).", i, c)
+		}
+	}
+	return nil
+}
+
+// polynomialNotation は入力された多項式の表記を解析しやすい形にそろえる.
+// LaTeX の \alpha^{k}, \cdot, \mathtt{1D} や α, *, · などを a^k, 0x1D の形に直し, 空白を取り除く.
+var polynomialNotation = strings.NewReplacer(
+	"\\mathtt{", "0x",
+	"\\alpha", "a",
+	"α", "a",
+	"\\cdot", "",
+	"\\,", "",
+	"·", "",
+	"*", "",
+	"{", "",
+	"}", "",
+	" ", "",
+	"\t", "",
+)
+
+// parsePolynomial は "a^5 x^3 + a^2 x + 1" のような α表記の多項式 (formatPolynomial が出力するLaTeXも可) を
+// 係数の配列 (最高次から) に直す. 係数は a^k, a, 10進数, 0x で始まる16進数のどれで書いてもよい.
+// 同じ次数の項が複数ある場合は足し合わせる (XOR).
+func (e *Encoder) parsePolynomial(s string) ([]int, error) {
+	normalized := polynomialNotation.Replace(s)
+	if normalized == "" {
+		return nil, errorf("多項式を入力してください.")
+	}
+	coefficients := map[int]int{}
+	maxDegree := 0
+	for _, term := range strings.Split(normalized, "+") {
+		coeff, degree, err := e.parsePolynomialTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		coefficients[degree] ^= coeff
+		if degree > maxDegree {
+			maxDegree = degree
+		}
+	}
+	p := make([]int, maxDegree+1)
+	for degree, coeff := range coefficients {
+		p[maxDegree-degree] = coeff
+	}
+	return polyTrim(p), nil
+}
+
+// parsePolynomialTerm は項1つ (例: "a^5x^3", "29x", "0x1D", "x^2", "1") の係数と次数を返す
+func (e *Encoder) parsePolynomialTerm(term string) (coeff, degree int, err error) {
+	rest := term
+	coeff = 1
+	hasCoeff := false
+	switch {
+	case term == "":
+		return 0, 0, errorf("空の項があります.")
+	case strings.HasPrefix(term, "0x") || strings.HasPrefix(term, "0X"):
+		digits := leadingRun(term[2:], "0123456789abcdefABCDEF")
+		if digits == "" {
+			return 0, 0, errorf("16進数の係数を読み取れません: %s", term)
+		}
+		v, _ := strconv.ParseUint(digits, 16, 32)
+		coeff, rest, hasCoeff = int(v), term[2+len(digits):], true
+	case term[0] >= '0' && term[0] <= '9':
+		digits := leadingRun(term, "0123456789")
+		v, _ := strconv.Atoi(digits)
+		coeff, rest, hasCoeff = v, term[len(digits):], true
+	case term[0] == 'a':
+		k := 1
+		rest = term[1:]
+		if strings.HasPrefix(rest, "^") {
+			digits := leadingRun(rest[1:], "0123456789")
+			if digits == "" {
+				return 0, 0, errorf("α の指数を読み取れません: %s", term)
+			}
+			k, _ = strconv.Atoi(digits)
+			rest = rest[1+len(digits):]
+		}
+		coeff, hasCoeff = e.exp[k%255], true
+	}
+	if coeff > 255 {
+		return 0, 0, errorf("係数は0〜255で指定してください: %s", term)
+	}
+
+	switch {
+	case rest == "":
+		if !hasCoeff {
+			return 0, 0, errorf("項を読み取れません: %s", term)
+		}
+		return coeff, 0, nil
+	case rest == "x":
+		return coeff, 1, nil
+	case strings.HasPrefix(rest, "x^"):
+		digits := rest[2:]
+		degree, err = strconv.Atoi(digits)
+		if err != nil || degree < 0 {
+			return 0, 0, errorf("x の次数を読み取れません: %s", term)
+		}
+		if degree > maxRSCodeLength {
+			return 0, 0, errorf("次数が大きすぎます (%dまで): %s", maxRSCodeLength, term)
+		}
+		return coeff, degree, nil
+	}
+	return 0, 0, errorf("項を読み取れません: %s", term)
+}
+
+// leadingRun は s の先頭から chars に含まれる文字が続く部分を返す
+func leadingRun(s, chars string) string {
+	i := 0
+	for i < len(s) && strings.IndexByte(chars, s[i]) >= 0 {
+		i++
+	}
+	return s[:i]
+}
+
+// processPolyEval は p(x) を求める. withTrace が true ならホーナー法の各段も返す.
+func (c *call) processPolyEval(p []int, x int, withTrace bool) (TemplateData, error) {
+	if err := validatePolynomial(p); err != nil {
+		return TemplateData{}, err
+	}
+	if x < 0 || x > 255 {
+		return TemplateData{}, errorf("代入する値は0〜255で指定してください: %d", x)
+	}
+
+	var data TemplateData
+	data.Polynomial.Operands = [][]int{p}
+	data.Polynomial.OperandsTex = []string{c.formatPolynomial(p, "x")}
+	data.Polynomial.X = x
+
+	acc := 0
+	for i, coeff := range p {
+		product := c.gfMul(acc, x)
+		acc = product ^ coeff
+		if withTrace {
+			data.Polynomial.Horner = append(data.Polynomial.Horner, HornerStep{Degree: len(p) - 1 - i, Coefficient: coeff, Product: product, Accumulator: acc})
+		}
+	}
+	data.Polynomial.Value = acc
+	data.Polynomial.ValueAlpha = c.alphaNotation(acc)
+	return data, nil
+}
+
+// processPolyMul は p1(x)·p2(x) を求める. withTrace が true なら p1 の各項と p2 の積 (部分積) も返す.
+func (c *call) processPolyMul(p1, p2 []int, withTrace bool) (TemplateData, error) {
+	for _, p := range [][]int{p1, p2} {
+		if err := validatePolynomial(p); err != nil {
+			return TemplateData{}, err
+		}
+	}
+
+	var data TemplateData
+	data.Polynomial.Operands = [][]int{p1, p2}
+	data.Polynomial.OperandsTex = []string{c.formatPolynomial(p1, "x"), c.formatPolynomial(p2, "x")}
+	result := c.polyMul(p1, p2)
+	data.Polynomial.Result = result
+	data.Polynomial.ResultTex = c.formatPolynomial(result, "x")
+
+	if withTrace {
+		for i, coeff := range p1 {
+			if coeff == 0 {
+				continue
+			}
+			degree := len(p1) - 1 - i
+			// a·x^degree · p2(x) を結果と同じ長さの配列にそろえる
+			product := make([]int, len(result))
+			copy(product[i:], c.polyScale(p2, coeff))
+			data.Polynomial.PartialProducts = append(data.Polynomial.PartialProducts, PartialProduct{
+				Degree:      degree,
+				Coefficient: coeff,
+				Product:     product,
+				ProductTex:  c.formatPolynomial(product, "x"),
+			})
+		}
+	}
+	return data, nil
+}
+
+// polyGCD はユークリッドの互除法で2つの多項式の最大公約式 (最高次係数を1にしたもの) を求め, 各回の割り算を返す
+func (c *call) polyGCD(p1, p2 []int) ([]int, []DivisionStep, error) {
+	a, b := polyTrim(p1), polyTrim(p2)
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	var steps []DivisionStep
+	for step := 1; !(len(b) == 1 && b[0] == 0); step++ {
+		quotient, remainder, err := c.polyDivMod(a, b)
+		if err != nil {
+			return nil, nil, err
+		}
+		steps = append(steps, DivisionStep{
+			Step:         step,
+			Dividend:     a,
+			Divisor:      b,
+			Quotient:     quotient,
+			Remainder:    remainder,
+			DividendTex:  c.formatPolynomial(a, "x"),
+			DivisorTex:   c.formatPolynomial(b, "x"),
+			QuotientTex:  c.formatPolynomial(quotient, "x"),
+			RemainderTex: c.formatPolynomial(remainder, "x"),
+		})
+		a, b = b, remainder
+	}
+	if a[0] == 0 {
+		return a, steps, nil
+	}
+	inverse, err := c.gfInv(a[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.polyScale(a, inverse), steps, nil
+}
+
+// processPolyGCD は2つの多項式の最大公約式と, 互除法の各回の商と剰余を求める
+func (c *call) processPolyGCD(p1, p2 []int) (TemplateData, error) {
+	for _, p := range [][]int{p1, p2} {
+		if err := validatePolynomial(p); err != nil {
+			return TemplateData{}, err
+		}
+	}
+
+	var data TemplateData
+	data.Polynomial.Operands = [][]int{p1, p2}
+	data.Polynomial.OperandsTex = []string{c.formatPolynomial(p1, "x"), c.formatPolynomial(p2, "x")}
+	gcd, steps, err := c.polyGCD(p1, p2)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	data.Polynomial.Result = gcd
+	data.Polynomial.ResultTex = c.formatPolynomial(gcd, "x")
+	data.Polynomial.GCDSteps = steps
+	return data, nil
+}
+
+// polyDivTrace は筆算と同じ手順で dividend ÷ divisor を行い, 商, 剰余と各行を返す.
+// 係数が0の位置では行を立てない (商のその項は0). 係数が GF(2^8) の要素でないか, 除数が0多項式ならエラーを返す.
+func (c *call) polyDivTrace(dividend, divisor []int) ([]int, []int, []DivisionRow, error) {
+	for _, p := range [][]int{dividend, divisor} {
+		if err := validatePolynomial(p); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	divisor = polyTrim(divisor)
+	if divisor[0] == 0 {
+		return nil, nil, nil, errorf("0多項式で割ることはできません.")
+	}
+	current := make([]int, len(dividend))
+	copy(current, dividend)
+	if len(dividend) < len(divisor) {
+		return []int{0}, polyTrim(current), nil, nil
+	}
+
+	quotient := make([]int, len(dividend)-len(divisor)+1)
+	var rows []DivisionRow
+	for i := range quotient {
+		if current[i] == 0 {
+			continue
+		}
+		factor, err := c.gfDiv(current[i], divisor[0])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		quotient[i] = factor
+		subtrahend := make([]int, len(dividend))
+		for j, d := range divisor {
+			subtrahend[i+j] = c.gfMul(d, factor)
+		}
+		next := make([]int, len(dividend))
+		for j := range next {
+			next[j] = current[j] ^ subtrahend[j]
+		}
+		rows = append(rows, DivisionRow{
+			Step:           len(rows) + 1,
+			QuotientDegree: len(quotient) - 1 - i,
+			Factor:         factor,
+			FactorAlpha:    c.alphaNotation(factor),
+			Subtrahend:     subtrahend,
+			Result:         next,
+			SubtrahendTex:  c.formatPolynomial(subtrahend, "x"),
+			ResultTex:      c.formatPolynomial(next, "x"),
+		})
+		current = next
+	}
+	return polyTrim(quotient), polyTrim(current[len(quotient):]), rows, nil
+}
+
+// processPolyDiv は dividend ÷ divisor の商と剰余を求める. withTrace が true なら筆算の各行も返す.
+func (c *call) processPolyDiv(dividend, divisor []int, withTrace bool) (TemplateData, error) {
+	quotient, remainder, rows, err := c.polyDivTrace(dividend, divisor)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	var data TemplateData
+	data.Polynomial.Operands = [][]int{dividend, divisor}
+	data.Polynomial.OperandsTex = []string{c.formatPolynomial(dividend, "x"), c.formatPolynomial(divisor, "x")}
+	data.Polynomial.Quotient = quotient
+	data.Polynomial.QuotientTex = c.formatPolynomial(quotient, "x")
+	data.Polynomial.Result = remainder
+	data.Polynomial.ResultTex = c.formatPolynomial(remainder, "x")
+	if withTrace {
+		data.Polynomial.DivisionRows = rows
+	}
+	return data, nil
+}
+
+// processGeneratorPolynomial は degree 次の生成多項式を求める. withSteps が true なら途中の積 g_1(x)〜 も返す.
+func (c *call) processGeneratorPolynomial(degree int, withSteps bool) (TemplateData, error) {
+	if degree < 1 || degree > 254 {
+		return TemplateData{}, errorf("生成多項式の次数は1〜254で指定してください: %d", degree)
+	}
+	var data TemplateData
+	g := c.lookupGeneratorPolynomial(degree)
+	if withSteps {
+		// 途中の積のLaTeX表記を作るのは重いので, 求められたときだけ掛け算をやり直す
+		g, data.Polynomial.GeneratorSteps = c.getGeneratorPolynomialWithSteps(degree, 0)
+	}
+	data.Polynomial.Result = g
+	data.Polynomial.ResultTex = c.formatPolynomial(g, "x")
+	return data, nil
+}
+
+// processGeneratorTable は QRコードで使う全ての次数の生成多項式の表を返す
+func (c *call) processGeneratorTable() TemplateData {
+	var data TemplateData
+	for _, degree := range qrGeneratorDegrees {
+		g := c.lookupGeneratorPolynomial(degree)
+		exponents := make([]int, len(g))
+		for i, coeff := range g {
+			exponents[i] = c.log[coeff]
+		}
+		data.Polynomial.GeneratorTable = append(data.Polynomial.GeneratorTable, GeneratorEntry{
+			Degree:       degree,
+			Coefficients: g,
+			Exponents:    exponents,
+			Latex:        c.formatPolynomial(g, "x"),
+		})
+	}
+	return data
+}
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+// STEP3 と同じ RS(26, 19) の剰余の計算にかかる時間と割り当てを比べる
+
+func benchmarkDividend() []int {
+	dividend := make([]int, 26)
+	for i := 0; i < 19; i++ {
+		dividend[i] = (i*37 + 11) % 256
+	}
+	return dividend
+}
+
+func BenchmarkPolyDiv(b *testing.B) {
+	dividend := benchmarkDividend()
+	g := defaultEncoder.lookupGeneratorPolynomial(7)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		defaultEncoder.polyDiv(dividend, g)
+	}
+}
+
+func BenchmarkPolyDivInPlace(b *testing.B) {
+	dividend := benchmarkDividend()
+	g := defaultEncoder.lookupGeneratorPolynomial(7)
+	buf := make([]int, len(dividend))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(buf, dividend)
+		defaultEncoder.polyDivInPlace(buf, g)
+	}
+}
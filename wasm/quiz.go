@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// --- 有限体の計算問題 (宿題用) ---
+
+const maxQuizProblems = 100 // 1回に作る問題の数の上限
+
+// quizOperations は出題する演算
+var quizOperations = []string{"mul", "div", "inv"}
+
+// QuizData は GF(2^8) の計算問題の一覧
+type QuizData struct {
+	Seed     int64         `json:"Seed"`
+	Problems []QuizProblem `json:"Problems"`
+}
+
+// QuizProblem は問題1つと, 指数表・対数表を引いて解く手順
+type QuizProblem struct {
+	Number      int      `json:"Number"`    // 問題番号 (1から)
+	Operation   string   `json:"Operation"` // "mul", "div", "inv"
+	Operands    []int    `json:"Operands"`
+	Question    string   `json:"Question"` // 問題文 (16進数)
+	Answer      int      `json:"Answer"`
+	AnswerHex   string   `json:"AnswerHex"`
+	AnswerAlpha string   `json:"AnswerAlpha"`
+	Solution    []string `json:"Solution"` // 解き方 (1行ずつ)
+}
+
+// processGFQuiz は GF(2^8) の乗算・除算・逆元の問題を count 問作る.
+// 同じ seed なら同じ問題になるので, 解答と組にして配布できる.
+func (c *call) processGFQuiz(count int, seed int64) (TemplateData, error) {
+	if count < 1 || count > maxQuizProblems {
+		return TemplateData{}, errorf("問題の数は1〜%dで指定してください: %d", maxQuizProblems, count)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	f := c.field
+	problems := make([]QuizProblem, count)
+	for i := range problems {
+		operation := quizOperations[rng.Intn(len(quizOperations))]
+		// 0 を含む問題は表を引く練習にならないので 1〜255 から選ぶ
+		a := 1 + rng.Intn(f.Order())
+		b := 1 + rng.Intn(f.Order())
+
+		p := QuizProblem{Number: i + 1, Operation: operation}
+		switch operation {
+		case "mul":
+			p.Operands = []int{a, b}
+			p.Answer = f.Mul(a, b)
+			p.Question = fmt.Sprintf("%02X × %02X を求めよ.", a, b)
+			p.Solution = []string{
+				fmt.Sprintf("対数表より log(%02X) = %d, log(%02X) = %d", a, f.Log(a), b, f.Log(b)),
+				fmt.Sprintf("指数を足す: (%d + %d) mod %d = %d", f.Log(a), f.Log(b), f.Order(), f.Log(p.Answer)),
+				fmt.Sprintf("指数表より α^%d = %02X", f.Log(p.Answer), p.Answer),
+			}
+		case "div":
+			p.Operands = []int{a, b}
+			answer, err := f.Div(a, b)
+			if err != nil {
+				return TemplateData{}, fieldError(err)
+			}
+			p.Answer = answer
+			p.Question = fmt.Sprintf("%02X ÷ %02X を求めよ.", a, b)
+			p.Solution = []string{
+				fmt.Sprintf("対数表より log(%02X) = %d, log(%02X) = %d", a, f.Log(a), b, f.Log(b)),
+				fmt.Sprintf("指数を引く: (%d - %d) mod %d = %d", f.Log(a), f.Log(b), f.Order(), f.Log(p.Answer)),
+				fmt.Sprintf("指数表より α^%d = %02X", f.Log(p.Answer), p.Answer),
+			}
+		case "inv":
+			p.Operands = []int{a}
+			answer, err := f.Inv(a)
+			if err != nil {
+				return TemplateData{}, fieldError(err)
+			}
+			p.Answer = answer
+			p.Question = fmt.Sprintf("%02X の逆元を求めよ.", a)
+			p.Solution = []string{
+				fmt.Sprintf("対数表より log(%02X) = %d", a, f.Log(a)),
+				fmt.Sprintf("α^%d = 1 なので指数は (%d - %d) mod %d = %d", f.Order(), f.Order(), f.Log(a), f.Order(), f.Log(p.Answer)),
+				fmt.Sprintf("指数表より α^%d = %02X", f.Log(p.Answer), p.Answer),
+				fmt.Sprintf("確認: %02X × %02X = 01", a, p.Answer),
+			}
+		}
+		p.AnswerHex = fmt.Sprintf("%02X", p.Answer)
+		p.AnswerAlpha = f.Alpha(p.Answer)
+		problems[i] = p
+	}
+
+	var data TemplateData
+	data.Quiz = QuizData{Seed: seed, Problems: problems}
+	return data, nil
+}
@@ -0,0 +1,501 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// --- シンボルの描画 ---
+
+const (
+	defaultModuleSize = 10 // 1モジュールのピクセル数の既定値
+	defaultMargin     = 4  // 描画時のクワイエットゾーンの既定値 (規格上の最小幅)
+	maxModuleSize     = 100
+
+	animationStepSeconds = 0.3 // アニメーションで1つの符号語を配置する間隔 (秒)
+)
+
+// RenderData は描画結果
+type RenderData struct {
+	Format  string `json:"Format"`  // "svg", "svg-animated", "svg-colored", "png", "pbm", "xbm", "html", "text", "pdf"
+	Content string `json:"Content"` // 描画結果の文字列 (PNG, PDFはBase64エンコードしたもの)
+	Width   int    `json:"Width"`   // ピクセル数 (テキストの場合は文字数と行数, PDFの場合はポイント数. クワイエットゾーンを含む)
+	Height  int    `json:"Height"`
+	DataURI string `json:"DataURI"` // <img> の src にそのまま指定できる data URI (要求された場合のみ)
+}
+
+// RenderOptions は全ての描画関数に共通の描画オプション. JSからはオブジェクトとして渡される.
+type RenderOptions struct {
+	ModuleSize int    // 1モジュールのピクセル数 (PDFでは紙面に収まるよう調整する)
+	Margin     int    // クワイエットゾーンの幅 (モジュール数)
+	Foreground string // 暗モジュールの色 (#RRGGBB)
+	Background string // 明モジュールの色 (#RRGGBB)
+	DataURI    bool   // 描画結果を data URI としても返すか (SVG, PNG, PDF)
+	HalfBlock  bool   // テキスト描画で上下2モジュールを1文字で表すか
+	Invert     bool   // テキスト描画で明暗を反転するか
+	ColorBy    string // 色分け描画の基準 ("character" または "codeword")
+}
+
+func defaultRenderOptions() RenderOptions {
+	return RenderOptions{
+		ModuleSize: defaultModuleSize,
+		Margin:     defaultMargin,
+		Foreground: "#000000",
+		Background: "#FFFFFF",
+		ColorBy:    "character",
+	}
+}
+
+// validate は描画オプションの値を確認する
+func (o RenderOptions) validate() error {
+	if o.ModuleSize < 1 || o.ModuleSize > maxModuleSize {
+		return errorf("モジュールのサイズは1から%dの範囲で指定してください.", maxModuleSize)
+	}
+	if o.Margin < 0 || o.Margin > maxQuietZone {
+		return errorf("クワイエットゾーンの幅は0から%dの範囲で指定してください.", maxQuietZone)
+	}
+	if _, err := parseHexColor(o.Foreground); err != nil {
+		return err
+	}
+	if _, err := parseHexColor(o.Background); err != nil {
+		return err
+	}
+	if o.ColorBy != "character" && o.ColorBy != "codeword" {
+		return errorf("'%s' はサポート外の色分け方法です (character または codeword を指定してください).", o.ColorBy)
+	}
+	return nil
+}
+
+// parseHexColor は "#RRGGBB" 形式の色を解析する
+func parseHexColor(s string) (color.RGBA, error) {
+	var r, g, b uint8
+	if len(s) != 7 || s[0] != '#' {
+		return color.RGBA{}, errorf("色 '%s' は #RRGGBB の形式で指定してください.", s)
+	}
+	if _, err := fmt.Sscanf(s[1:], "%02x%02x%02x", &r, &g, &b); err != nil {
+		return color.RGBA{}, errorf("色 '%s' は #RRGGBB の形式で指定してください.", s)
+	}
+	return color.RGBA{r, g, b, 0xFF}, nil
+}
+
+// processRender は符号語(2進数, マスク前)から最終的なシンボルを組み立て, 指定した形式で描画する
+func (c *call) processRender(codewordBinary, format string, opts RenderOptions) (TemplateData, error) {
+	if err := opts.validate(); err != nil {
+		return TemplateData{}, err
+	}
+	codewordBytes, err := c.parseCodewordBinary(codewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	symbol, err := buildSymbol(codewordBytes, c.opts.MaskPattern)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	modules := addQuietZone(symbol.Snapshot(), opts.Margin)
+	if err := c.checkCancelled(); err != nil {
+		return TemplateData{}, err
+	}
+
+	var data TemplateData
+	data.Render.Format = format
+	data.Render.Width = len(modules) * opts.ModuleSize
+	data.Render.Height = len(modules) * opts.ModuleSize
+	switch format {
+	case "svg", "svg-animated":
+		if format == "svg" {
+			data.Render.Content = renderSVG(modules, opts)
+		} else {
+			data.Render.Content = c.renderAnimatedSVG(codewordBytes, opts)
+		}
+		if opts.DataURI {
+			data.Render.DataURI = "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(data.Render.Content))
+		}
+	case "png":
+		pngBytes, err := renderPNG(modules, opts)
+		if err != nil {
+			return TemplateData{}, errorf("PNGの生成に失敗しました: %v", err)
+		}
+		data.Render.Content = base64.StdEncoding.EncodeToString(pngBytes)
+		if opts.DataURI {
+			data.Render.DataURI = "data:image/png;base64," + data.Render.Content
+		}
+	case "pbm":
+		data.Render.Content = renderPBM(modules, opts.ModuleSize)
+	case "xbm":
+		data.Render.Content = renderXBM(modules, opts.ModuleSize)
+	case "html":
+		data.Render.Content = renderHTMLTable(modules, addQuietZone(qr.CopyModules(symbol.Region), opts.Margin))
+		data.Render.Width = len(modules)
+		data.Render.Height = len(modules)
+	case "text":
+		data.Render.Content = renderText(modules, opts.HalfBlock, opts.Invert)
+		data.Render.Width = len(modules) * 2
+		data.Render.Height = len(modules)
+		if opts.HalfBlock {
+			data.Render.Width = len(modules)
+			data.Render.Height = (len(modules) + 1) / 2
+		}
+	default:
+		return TemplateData{}, errorf("'%s' はサポート外の描画形式です.", format)
+	}
+	return data, nil
+}
+
+// renderSVG は行列(クワイエットゾーンを含む)を, 暗モジュールを1つのパスで塗るSVGに変換する
+func renderSVG(modules [][]int, opts RenderOptions) string {
+	var dark []qr.Position
+	for r, row := range modules {
+		for c, v := range row {
+			if v == qr.ModuleDark {
+				dark = append(dark, qr.Position{Row: r, Col: c})
+			}
+		}
+	}
+
+	size := len(modules) * opts.ModuleSize
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, size, size)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`, opts.Background)
+	fmt.Fprintf(&b, `<path fill="%s" d="%s"/>`, opts.Foreground, svgModulePath(dark, opts.ModuleSize, 0))
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+// svgModulePath は指定したモジュールを塗るSVGのパスデータを返す (margin はクワイエットゾーンの幅)
+func svgModulePath(positions []qr.Position, moduleSize, margin int) string {
+	var b strings.Builder
+	for _, pos := range positions {
+		fmt.Fprintf(&b, "M%d %dh%dv%dh-%dz", (pos.Col+margin)*moduleSize, (pos.Row+margin)*moduleSize, moduleSize, moduleSize, moduleSize)
+	}
+	return b.String()
+}
+
+// renderAnimatedSVG は符号語が1つずつ配置され, 最後にマスクと形式情報が適用される様子をSMILアニメーションで描く
+func (c *call) renderAnimatedSVG(codewordBytes []byte, opts RenderOptions) string {
+	moduleSize, margin := opts.ModuleSize, opts.Margin
+	m := qr.NewMatrix(symbolSize)
+	m.PlaceFunctionPatterns()
+
+	// 機能パターン (形式情報を除き, 最初から表示する)
+	var functionDark []qr.Position
+	for r := 0; r < m.Size; r++ {
+		for c := 0; c < m.Size; c++ {
+			if m.Modules[r][c] == qr.ModuleDark {
+				functionDark = append(functionDark, qr.Position{Row: r, Col: c})
+			}
+		}
+	}
+
+	positions := m.DataModulePositions()
+	m.PlaceCodewords(codewordBytes)
+	unmasked := m.Snapshot()
+	m.ApplyMask(qr.MaskConditions[c.opts.MaskPattern])
+	m.PlaceFormatInformation(qr.FormatInformation(eccLevelBits, c.opts.MaskPattern))
+
+	size := (m.Size + margin*2) * moduleSize
+	maskBegin := float64(len(codewordBytes)+1) * animationStepSeconds
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, size, size)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`, opts.Background)
+	fmt.Fprintf(&b, `<path fill="%s" d="%s"/>`, opts.Foreground, svgModulePath(functionDark, moduleSize, margin))
+
+	// マスク前の符号語を1つずつ表示し, マスク適用時に隠す
+	for k := 0; k < len(codewordBytes); k++ {
+		var dark []qr.Position
+		for _, pos := range positions[k*8 : k*8+8] {
+			if unmasked[pos.Row][pos.Col] == qr.ModuleDark {
+				dark = append(dark, pos)
+			}
+		}
+		fmt.Fprintf(&b, `<g opacity="0"><set attributeName="opacity" to="1" begin="%.1fs" fill="freeze"/><set attributeName="opacity" to="0" begin="%.1fs" fill="freeze"/><path fill="%s" d="%s"/></g>`,
+			float64(k+1)*animationStepSeconds, maskBegin, opts.Foreground, svgModulePath(dark, moduleSize, margin))
+	}
+
+	// マスク適用後のデータと形式情報
+	var masked []qr.Position
+	for r := 0; r < m.Size; r++ {
+		for c := 0; c < m.Size; c++ {
+			region := m.Region[r][c]
+			if m.Modules[r][c] == qr.ModuleDark && (region == qr.RegionData || region == qr.RegionECC || region == qr.RegionFormat) {
+				masked = append(masked, qr.Position{Row: r, Col: c})
+			}
+		}
+	}
+	fmt.Fprintf(&b, `<g opacity="0"><set attributeName="opacity" to="1" begin="%.1fs" fill="freeze"/><path fill="%s" d="%s"/></g>`,
+		maskBegin, opts.Foreground, svgModulePath(masked, moduleSize, margin))
+	b.WriteString(`</svg>`)
+	return b.String()
+}
+
+// renderHTMLTable は行列をHTMLの<table>の断片に変換する.
+// 各セルには領域名 (qr.RegionNames: finder, timing, data, ecc, format など) と dark/light のクラスを付けるので, 見た目はCSSで指定する.
+func renderHTMLTable(modules, regions [][]int) string {
+	var b strings.Builder
+	b.WriteString(`<table class="qr-matrix">` + "\n")
+	for r, row := range modules {
+		b.WriteString("<tr>")
+		for c, v := range row {
+			shade := "light"
+			if v == qr.ModuleDark {
+				shade = "dark"
+			}
+			fmt.Fprintf(&b, `<td class="%s %s"></td>`, qr.RegionNames[regions[r][c]], shade)
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n")
+	return b.String()
+}
+
+// renderPNG は行列(クワイエットゾーンを含む)を前景色・背景色の2色のPNGに変換する
+func renderPNG(modules [][]int, opts RenderOptions) ([]byte, error) {
+	moduleSize := opts.ModuleSize
+	foreground, _ := parseHexColor(opts.Foreground)
+	background, _ := parseHexColor(opts.Background)
+	size := len(modules) * moduleSize
+	palette := color.Palette{background, foreground} // 添字0:明 1:暗
+	img := image.NewPaletted(image.Rect(0, 0, size, size), palette)
+	for r, row := range modules {
+		for c, v := range row {
+			if v != qr.ModuleDark {
+				continue
+			}
+			for y := r * moduleSize; y < (r+1)*moduleSize; y++ {
+				for x := c * moduleSize; x < (c+1)*moduleSize; x++ {
+					img.SetColorIndex(x, y, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// scaledPixel は1モジュールを moduleSize×moduleSize に拡大したときの画素 (x, y) が暗モジュールならtrueを返す
+func scaledPixel(modules [][]int, moduleSize, x, y int) bool {
+	return modules[y/moduleSize][x/moduleSize] == qr.ModuleDark
+}
+
+// renderPBM は行列をテキスト形式のPBM (P1, 1:黒 0:白) に変換する
+func renderPBM(modules [][]int, moduleSize int) string {
+	size := len(modules) * moduleSize
+	var b strings.Builder
+	fmt.Fprintf(&b, "P1\n%d %d\n", size, size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if x > 0 {
+				b.WriteString(" ")
+			}
+			if scaledPixel(modules, moduleSize, x, y) {
+				b.WriteString("1")
+			} else {
+				b.WriteString("0")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderXBM は行列をXBM (C言語の配列. 各バイトは下位ビットが左の画素, 1:黒) に変換する
+func renderXBM(modules [][]int, moduleSize int) string {
+	size := len(modules) * moduleSize
+	var bytesOut []string
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x += 8 {
+			var v byte
+			for bit := 0; bit < 8 && x+bit < size; bit++ {
+				if scaledPixel(modules, moduleSize, x+bit, y) {
+					v |= 1 << bit
+				}
+			}
+			bytesOut = append(bytesOut, fmt.Sprintf("0x%02x", v))
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#define qrcode_width %d\n#define qrcode_height %d\n", size, size)
+	b.WriteString("static unsigned char qrcode_bits[] = {\n")
+	for i := 0; i < len(bytesOut); i += 12 {
+		end := i + 12
+		if end > len(bytesOut) {
+			end = len(bytesOut)
+		}
+		b.WriteString("   " + strings.Join(bytesOut[i:end], ", "))
+		if end < len(bytesOut) {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("};\n")
+	return b.String()
+}
+
+// renderText は行列をブロック文字で描画する.
+// 通常は1モジュールを "██" (2文字) で表し, halfBlock の場合は上下2モジュールを1文字 (█ ▀ ▄ 空白) で表す.
+// invert の場合は明暗を反転する (暗い背景の端末で読み取らせる場合に使う).
+func renderText(modules [][]int, halfBlock, invert bool) string {
+	size := len(modules)
+	ink := func(r, c int) bool {
+		if r >= size {
+			return false // 行数が奇数の場合の最下行の下側
+		}
+		return (modules[r][c] == qr.ModuleDark) != invert
+	}
+
+	var b strings.Builder
+	if !halfBlock {
+		for r := 0; r < size; r++ {
+			for c := 0; c < size; c++ {
+				if ink(r, c) {
+					b.WriteString("██")
+				} else {
+					b.WriteString("  ")
+				}
+			}
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+
+	for r := 0; r < size; r += 2 {
+		for c := 0; c < size; c++ {
+			top, bottom := ink(r, c), ink(r+1, c)
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// --- 符号語・入力文字による色分け ---
+
+// processSourceMap は漢字入力から STEP1〜4 を行い, 各モジュールがどの符号語・どの入力文字に由来するかを求める.
+// opts.ColorBy ("character" または "codeword") に従って色分けしたSVGも返す.
+func (c *call) processSourceMap(kanjiInput string, opts RenderOptions) (TemplateData, error) {
+	if err := opts.validate(); err != nil {
+		return TemplateData{}, err
+	}
+	moduleSize, margin := opts.ModuleSize, opts.Margin
+	step2, err := c.processStep1To2(kanjiInput)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	step3, err := c.processStep3(step2.Intermediate.PaddedBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	codewordBytes, err := c.parseCodewordBinary(step3.Intermediate.CodewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	symbol, err := buildSymbol(codewordBytes, c.opts.MaskPattern)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	codewordMatrix := make([][]int, symbol.Size)
+	sourceMatrix := make([][]int, symbol.Size)
+	for r := 0; r < symbol.Size; r++ {
+		codewordMatrix[r] = make([]int, symbol.Size)
+		sourceMatrix[r] = make([]int, symbol.Size)
+		for c := 0; c < symbol.Size; c++ {
+			codewordMatrix[r][c] = -1
+			sourceMatrix[r][c] = -1
+		}
+	}
+	// データコード語のビットはビット列の先頭から順に並ぶので, 指示子の後ろ13ビットずつが各文字に対応する
+	headerBits := len(step2.Intermediate.ModeIndicator) + len(step2.Intermediate.CharCountIndicator)
+	charBits := len(step2.Results) * 13
+	for i, pos := range qr.DataPositions() {
+		codewordMatrix[pos.Row][pos.Col] = i / 8
+		if i >= headerBits && i < headerBits+charBits {
+			sourceMatrix[pos.Row][pos.Col] = (i - headerBits) / 13
+		}
+	}
+
+	modules := addQuietZone(symbol.Snapshot(), margin)
+	codewordMatrix = padMatrix(codewordMatrix, margin, -1)
+	sourceMatrix = padMatrix(sourceMatrix, margin, -1)
+
+	var data TemplateData
+	data.KanjiInput = kanjiInput
+	data.Results = step2.Results
+	data.Matrix.Size = symbol.Size
+	data.Matrix.QuietZone = margin
+	data.Matrix.MaskedMatrix = modules
+	data.Matrix.CodewordMatrix = codewordMatrix
+	data.Matrix.SourceMatrix = sourceMatrix
+	data.Render.Format = "svg-colored"
+	data.Render.Width = len(modules) * moduleSize
+	data.Render.Height = len(modules) * moduleSize
+	if opts.ColorBy == "character" {
+		data.Render.Content = renderColoredSVG(modules, codewordMatrix, sourceMatrix, sourceMatrix, len(step2.Results), opts)
+	} else {
+		data.Render.Content = renderColoredSVG(modules, codewordMatrix, sourceMatrix, codewordMatrix, len(codewordBytes), opts)
+	}
+	if opts.DataURI {
+		data.Render.DataURI = "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(data.Render.Content))
+	}
+	return data, nil
+}
+
+// renderColoredSVG は colorIndex の値ごとに色相を変えてモジュールを塗ったSVGを返す.
+// 各モジュールには data-codeword と data-source 属性を付けるので, UI側で由来を調べられる.
+// colorIndex が-1のモジュールは, データ領域なら灰色, 機能パターンなら前景色・背景色で塗る.
+func renderColoredSVG(modules, codewordMatrix, sourceMatrix, colorIndex [][]int, colorCount int, opts RenderOptions) string {
+	moduleSize := opts.ModuleSize
+	size := len(modules) * moduleSize
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, size, size)
+	for r, row := range modules {
+		for c, v := range row {
+			var fill string
+			switch {
+			case colorIndex[r][c] >= 0:
+				hue := colorIndex[r][c] * 360 / colorCount
+				lightness := 85
+				if v == qr.ModuleDark {
+					lightness = 35
+				}
+				fill = fmt.Sprintf("hsl(%d,70%%,%d%%)", hue, lightness)
+			case codewordMatrix[r][c] >= 0:
+				fill = "#DDDDDD"
+				if v == qr.ModuleDark {
+					fill = "#555555"
+				}
+			default:
+				fill = opts.Background
+				if v == qr.ModuleDark {
+					fill = opts.Foreground
+				}
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" data-codeword="%d" data-source="%d"/>`,
+				c*moduleSize, r*moduleSize, moduleSize, moduleSize, fill, codewordMatrix[r][c], sourceMatrix[r][c])
+		}
+	}
+	b.WriteString(`</svg>`)
+	return b.String()
+}
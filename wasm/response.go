@@ -0,0 +1,81 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/base64"
+)
+
+// --- JSへの応答 ---
+
+// フロントエンド用の型定義 (docs/qrcode.d.ts と, 埋め込み用の wasm/qrcode.d.ts) とラッパー (docs/qrcode.js) は
+// 構造体の json タグから作る.
+//go:generate go run gen_dts.go
+
+// typeDefinitions は TemplateData などの TypeScript の型定義 (getTypeDefinitions で返す)
+//
+//go:embed qrcode.d.ts
+var typeDefinitions string
+
+// 応答の形式. 既定ではこれまでどおり JSON 文字列を返し, "object" にすると JS 側で JSON.parse しなくてよい
+// JSのオブジェクトを直接返す.
+// "transferable" は "object" と同じ形だが, PNG, PDF の描画結果とモジュールの行列を ArrayBuffer で返し,
+// それらを Transfer にまとめる. Web Worker から postMessage(result, result.Transfer) で複製せずに渡せる.
+const (
+	responseJSON         = "json"
+	responseObject       = "object"
+	responseTransferable = "transferable"
+)
+
+var responseFormat = responseJSON
+
+// setResponseFormat は以降の応答の形式を切り替える
+func setResponseFormat(format string) error {
+	if !isResponseFormat(format) {
+		return errorf("不明な応答の形式です: %s (json, object, transferable のどれかを指定してください).", format)
+	}
+	responseFormat = format
+	return nil
+}
+
+// isResponseFormat は format が応答の形式として正しいかを返す
+func isResponseFormat(format string) bool {
+	return format == responseJSON || format == responseObject || format == responseTransferable
+}
+
+// responseData は応答の形式によらない前処理 (呼び出しのオプションの詳しさと応答の版) を data に施す
+func (c *call) responseData(data interface{}) interface{} {
+	if c.opts.Verbosity == verbosityCompact {
+		data = compactResponse(data)
+	}
+	return versioned(data, c.opts.SchemaVersion)
+}
+
+// errorData は err を Error に入れた応答を, responseData と同じ前処理をして返す
+func (c *call) errorData(err error) interface{} {
+	return c.responseData(TemplateData{Error: c.errorInfo(err)})
+}
+
+// byteArrayFields は呼び出しのオプション { byteArrays: true } のとき, 16進数の文字列の代わりに Uint8Array で返す項目.
+// (JSON の応答では使えないので, outputFormat が object か transferable の場合だけ効く)
+var byteArrayFields = map[string]bool{
+	"PaddedHex":         true,
+	"CodewordHex":       true,
+	"MaskPatternHex":    true,
+	"MaskedCodewordHex": true,
+	"ReceivedHex":       true,
+	"CorrectedHex":      true,
+	"ParityHex":         true,
+	"StreamHex":         true,
+}
+
+// binaryContent は PNG, PDF の描画結果 (Base64) をバイト列に戻す. それ以外の形式では nil を返す.
+func (r RenderData) binaryContent() []byte {
+	if r.Format != "png" && r.Format != "pdf" {
+		return nil
+	}
+	b, err := base64.StdEncoding.DecodeString(r.Content)
+	if err != nil {
+		return nil
+	}
+	return b
+}
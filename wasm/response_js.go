@@ -0,0 +1,216 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"syscall/js"
+)
+
+// respond は処理結果を呼び出しの応答の形式 (オプションで指定しなければ setResponseFormat で選んだもの) で返す
+func (c *call) respond(data interface{}) interface{} {
+	data = c.responseData(data)
+	switch c.opts.OutputFormat {
+	case responseObject:
+		return js.ValueOf(c.jsValueOf(reflect.ValueOf(data), nil))
+	case responseTransferable:
+		var transfer []interface{}
+		value := c.jsValueOf(reflect.ValueOf(data), &transfer)
+		if object, ok := value.(map[string]interface{}); ok {
+			object["Transfer"] = transfer
+		}
+		return js.ValueOf(value)
+	}
+	return jsonResponse(data)
+}
+
+// jsValueOf は Go の値を js.ValueOf で変換できる値 (map[string]interface{}, []interface{}, 数値, 文字列など) にする.
+// 構造体のキーは json タグに従うので, JSON 文字列を JSON.parse した結果と同じ形になる.
+// transfer が nil でなければ, 大きなバイナリ (PNG, PDF, モジュールの行列) を ArrayBuffer にして transfer に加える.
+func (c *call) jsValueOf(v reflect.Value, transfer *[]interface{}) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return c.jsValueOf(v.Elem(), transfer)
+	case reflect.Struct:
+		object := make(map[string]interface{}, v.NumField())
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if options == "omitempty" && v.Field(i).IsZero() {
+				continue
+			}
+			object[name] = c.jsValueOf(v.Field(i), transfer)
+		}
+		if c.opts.ByteArrays {
+			for i := 0; i < t.NumField(); i++ {
+				if !byteArrayFields[t.Field(i).Name] || v.Field(i).String() == "" {
+					continue
+				}
+				if b, err := hexStringToBytes(v.Field(i).String()); err == nil {
+					object[t.Field(i).Name] = uint8Array(b, transfer)
+				}
+			}
+		}
+		if render, ok := v.Interface().(RenderData); ok {
+			if content := render.binaryContent(); content != nil {
+				switch {
+				case c.opts.ByteArrays:
+					object["Content"] = uint8Array(content, transfer)
+				case transfer != nil:
+					object["Content"] = transferBuffer(content, transfer)
+				}
+			}
+		}
+		return object
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if modules, ok := v.Interface().([][]int); ok && transfer != nil {
+			return c.transferMatrix(modules, transfer)
+		}
+		fallthrough
+	case reflect.Array:
+		array := make([]interface{}, v.Len())
+		for i := range array {
+			array[i] = c.jsValueOf(v.Index(i), transfer)
+		}
+		return array
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		object := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			object[fmt.Sprint(iter.Key().Interface())] = c.jsValueOf(iter.Value(), transfer)
+		}
+		return object
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	}
+	return nil
+}
+
+// uint8Array は b を js.CopyBytesToJS で1回だけ写した Uint8Array を返す. transfer が nil でなければその ArrayBuffer を加える.
+func uint8Array(b []byte, transfer *[]interface{}) js.Value {
+	array := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(array, b)
+	if transfer != nil {
+		*transfer = append(*transfer, array.Get("buffer"))
+	}
+	return array
+}
+
+// transferBuffer は b を写した ArrayBuffer を作り, transfer に加えて返す
+func transferBuffer(b []byte, transfer *[]interface{}) js.Value {
+	return uint8Array(b, transfer).Get("buffer")
+}
+
+// transferMatrix はモジュールの行列を { Rows, Columns, Buffer } (Buffer は行優先で1要素1バイト) にする.
+// 0〜255 に収まらない値を含む行列はそのまま配列で返す.
+func (c *call) transferMatrix(modules [][]int, transfer *[]interface{}) interface{} {
+	columns := 0
+	if len(modules) > 0 {
+		columns = len(modules[0])
+	}
+	b := make([]byte, 0, len(modules)*columns)
+	for _, row := range modules {
+		if len(row) != columns {
+			return c.jsValueOf(reflect.ValueOf(modules), nil)
+		}
+		for _, m := range row {
+			if m < 0 || m > 0xFF {
+				return c.jsValueOf(reflect.ValueOf(modules), nil)
+			}
+			b = append(b, byte(m))
+		}
+	}
+	return map[string]interface{}{"Rows": len(modules), "Columns": columns, "Buffer": transferBuffer(b, transfer)}
+}
+
+// exportFunction は Go の関数を JS のグローバル関数 name として登録する.
+// 同時に, 同じ処理を Promise で返す nameAsync も登録する (呼び出し側は結果を await で待てる).
+// どちらも最後の引数にオプションのオブジェクト (QROptions) を受け付け, 中で panic が起きてもエラーの応答を返す.
+func exportFunction(name string, fn func(c *call, args []js.Value) interface{}) {
+	exportedFunctions = append(exportedFunctions, name)
+	fn = recovered(name, fn)
+	syncFunc, asyncFunc := js.FuncOf(timed(name, withOptions(fn, false))), js.FuncOf(promiseFunc(timed(name, withOptions(fn, true))))
+	registeredFuncs = append(registeredFuncs, syncFunc, asyncFunc)
+	js.Global().Set(name, syncFunc)
+	js.Global().Set(name+"Async", asyncFunc)
+}
+
+// registeredFuncs は exportFunction で登録した js.Func (shutdown で解放する)
+var registeredFuncs []js.Func
+
+// shutdownRequested は shutdown が呼ばれると閉じられ, main を終わらせる
+var shutdownRequested = make(chan struct{})
+
+// shutdown は登録した関数を JS のグローバルから取り除いて解放し, セッションを捨ててプログラムを終わらせる.
+// シングルページアプリが WebAssembly モジュールを読み込み直しても, 古いコールバックが残らないようにする.
+func shutdown() error {
+	select {
+	case <-shutdownRequested:
+		return errorf("すでに終了しています.")
+	default:
+	}
+	for _, name := range exportedFunctions {
+		js.Global().Delete(name)
+		js.Global().Delete(name + "Async")
+	}
+	// 実行中の関数 (shutdown 自身) を解放しても, その呼び出しは最後まで続けられる
+	for _, f := range registeredFuncs {
+		f.Release()
+	}
+	exportedFunctions, registeredFuncs = nil, nil
+	sessions = map[int]*pipelineSession{}
+	pendingChunks, pendingChunkCount, chunkedPayload = nil, 0, nil
+	close(shutdownRequested)
+	return nil
+}
+
+// promiseFunc は fn を goroutine で実行し, 結果で resolve する Promise を返す関数にする.
+// エラーも他と同じく Error を含む応答として resolve する.
+// js/wasm の Go は JS と同じ1つのスレッドで動くので, goroutine にしても処理はイベントループの外では動かず,
+// 計算している間は同期の版と同じく UI が止まる. 違いは Promise をすぐ返して後で resolve することと,
+// Async の版では取り消しを確かめる区切り (checkCancelled) ごとにイベントループに処理を渡すことだけ.
+// UI を止めたくない場合は, モジュールを Web Worker で読み込んで呼び出す.
+func promiseFunc(fn func(this js.Value, args []js.Value) interface{}) func(this js.Value, args []js.Value) interface{} {
+	return func(this js.Value, args []js.Value) interface{} {
+		// args は呼び出しの間だけ有効とは限らないので, goroutine に渡す前に写しておく
+		copied := append([]js.Value(nil), args...)
+		executor := js.FuncOf(func(_ js.Value, callbacks []js.Value) interface{} {
+			resolve := callbacks[0]
+			go func() {
+				resolve.Invoke(fn(this, copied))
+			}()
+			return nil
+		})
+		defer executor.Release()
+		return js.Global().Get("Promise").New(executor)
+	}
+}
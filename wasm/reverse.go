@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/kanji"
+)
+
+// --- 逆変換 (マスク後の符号語 → 漢字) ---
+
+// processDecodeAll は STEP4 の出力 (マスク後の符号語, 2進数) から符号化と逆の順に
+// マスクの解除, 誤り訂正, 終端パターンと埋め草の除去, 漢字の復元を行う.
+// 途中の値は符号化の方向と同じ項目に入れて返す.
+func (c *call) processDecodeAll(maskedBinary string) (TemplateData, error) {
+	maskedBytes, err := c.parseCodewordBinary(maskedBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	var data TemplateData
+	data.MaxCharCount = maxCharCount
+	maskBytes := c.maskPatternBytes()
+	data.Intermediate.MaskPatternHex = formatBytesToHex(maskBytes)
+	data.Intermediate.MaskedCodewordHex = formatBytesToHex(maskedBytes)
+	data.Intermediate.MaskedCodewordBinary = formatBytesToBinary(maskedBytes)
+
+	// STEP4の逆: マスクを解除する
+	received := make([]byte, len(maskedBytes))
+	for i := range maskedBytes {
+		received[i] = maskedBytes[i] ^ maskBytes[i]
+	}
+
+	// STEP3の逆: 誤りを訂正する
+	if err := c.checkCancelled(); err != nil {
+		return data, err
+	}
+	data.Decoding, err = c.decodeReceived(bytesToInts(received), decodeMethodBerlekampMassey, nil)
+	if err != nil {
+		return data, err
+	}
+	if !data.Decoding.Success {
+		return data, errorf("%s", data.Decoding.Failure.Message)
+	}
+	codewordBytes, err := hexStringToBytes(data.Decoding.CorrectedHex)
+	if err != nil {
+		return data, err
+	}
+	step3, err := c.processStep3(formatBytesToBinary(codewordBytes[:19]))
+	if err != nil {
+		return data, err
+	}
+	data.Intermediate.PaddedHex = step3.Intermediate.PaddedHex
+	data.Intermediate.PaddedBinary = step3.Intermediate.PaddedBinary
+	data.Intermediate.DataPolynomial = step3.Intermediate.DataPolynomial
+	data.Intermediate.ErrorCorrectionPolynomial = step3.Intermediate.ErrorCorrectionPolynomial
+	data.Intermediate.CodewordPolynomial = step3.Intermediate.CodewordPolynomial
+	data.Intermediate.DataCoefficients = step3.Intermediate.DataCoefficients
+	data.Intermediate.ErrorCorrectionCoefficients = step3.Intermediate.ErrorCorrectionCoefficients
+	data.Intermediate.CodewordCoefficients = step3.Intermediate.CodewordCoefficients
+	data.Intermediate.CodewordHex = step3.Intermediate.CodewordHex
+	data.Intermediate.CodewordBinary = step3.Intermediate.CodewordBinary
+
+	// STEP1〜2の逆: データコード語を読む
+	if err := parseDataCodewords(codewordBytes[:19], &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// AnswerCheckData は答え合わせの結果 (復元した文字列と成否だけ)
+type AnswerCheckData struct {
+	Text    string `json:"Text"`
+	Success bool   `json:"Success"`
+	Error   string `json:"Error"`
+}
+
+// processCheckAnswer はマスク後の符号語(2進数)を復号し, 元の文字列が得られたかだけを返す
+func (c *call) processCheckAnswer(maskedBinary string) AnswerCheckData {
+	data, err := c.processDecodeAll(maskedBinary)
+	if err != nil {
+		return AnswerCheckData{Error: localizedMessage(c.opts.Locale, err)}
+	}
+	return AnswerCheckData{Text: data.KanjiInput, Success: true}
+}
+
+// RepairData は利用者が編集した符号語を修復した結果
+type RepairData struct {
+	Corrected        bool   `json:"Corrected"`        // 誤り訂正に成功したか (誤りがなかった場合も含む)
+	ChangedPositions []int  `json:"ChangedPositions"` // 訂正で書き換えたバイトの位置
+	ValidKanjiData   bool   `json:"ValidKanjiData"`   // 訂正後のデータコード語が漢字モードのデータとして読めたか
+	Text             string `json:"Text"`
+	Message          string `json:"Message"` // 失敗した場合の理由
+}
+
+// processRepair は利用者が自由に編集した26バイトの符号語 (16進数, マスク前) の誤りを訂正し,
+// どのバイトを書き換えたか, 漢字モードのデータとして読めるか, 読めた文字列を返す
+func (c *call) processRepair(codewordHex string) (TemplateData, error) {
+	codewordBytes, err := hexStringToBytes(codewordHex)
+	if err != nil {
+		return TemplateData{}, errorf("符号語の16進数文字列の解析に失敗しました: %v", err)
+	}
+	if len(codewordBytes) != 26 {
+		return TemplateData{}, errorf("符号語は26バイトである必要がありますが, %dバイトでした.", len(codewordBytes))
+	}
+
+	var data TemplateData
+	data.MaxCharCount = maxCharCount
+	data.Decoding, err = c.decodeReceived(bytesToInts(codewordBytes), decodeMethodBerlekampMassey, nil)
+	if err != nil {
+		return data, err
+	}
+	repair := &data.Decoding.Repair
+	if !data.Decoding.Success {
+		repair.Message = data.Decoding.Failure.Message
+		return data, nil
+	}
+	repair.Corrected = true
+	for _, d := range data.Decoding.Diff {
+		if d.Changed {
+			repair.ChangedPositions = append(repair.ChangedPositions, d.Position)
+		}
+	}
+
+	correctedBytes, err := hexStringToBytes(data.Decoding.CorrectedHex)
+	if err != nil {
+		return data, err
+	}
+	data.Intermediate.CodewordHex = formatBytesToHex(correctedBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(correctedBytes)
+	data.Intermediate.PaddedHex = formatBytesToHex(correctedBytes[:19])
+	data.Intermediate.PaddedBinary = formatBytesToBinary(correctedBytes[:19])
+	if err := parseDataCodewords(correctedBytes[:19], &data); err != nil {
+		repair.Message = localizedMessage(c.opts.Locale, err)
+		return data, nil
+	}
+	repair.ValidKanjiData = true
+	repair.Text = data.KanjiInput
+	return data, nil
+}
+
+// parseDataCodewords は19バイトのデータコード語からモード指示子, 文字数指示子, 13ビットの漢字を読み取り,
+// 元の文字列と各文字の計算過程を data に書き込む
+func parseDataCodewords(dataBytes []byte, data *TemplateData) error {
+	var bits strings.Builder
+	for _, b := range dataBytes {
+		fmt.Fprintf(&bits, "%08b", b)
+	}
+	stream := bits.String()
+
+	modeIndicator := stream[:4]
+	if modeIndicator != modeIndicatorKanji {
+		return errorf("モード指示子が漢字モード(1000)ではありません: %s", modeIndicator)
+	}
+	charCountIndicator := stream[4:12]
+	count, _ := strconv.ParseUint(charCountIndicator, 2, 8)
+	if count == 0 || int(count) > maxCharCount {
+		return errorf("文字数指示子が不正です: %s (%d文字)", charCountIndicator, count)
+	}
+	end := 12 + int(count)*13
+	concatenated := stream[12:end]
+
+	var results []KanjiCompressionResult
+	var codes []uint16
+	for i := 0; i < len(concatenated); i += kanji.Bits {
+		value, _ := strconv.ParseUint(concatenated[i:i+kanji.Bits], 2, 16)
+		c, err := kanji.Expand(uint16(value))
+		if err != nil {
+			return errorf("13ビットの値 %04X はサポート外のShift-JISコード範囲に対応します", value)
+		}
+		results = append(results, compressionResult(c))
+		codes = append(codes, c.ShiftJIS)
+	}
+
+	decoded, err := kanji.DecodeShiftJIS(codes)
+	if err != nil {
+		return errorf("Shift-JISからの変換に失敗しました: %v", err)
+	}
+	runes := []rune(decoded)
+	if len(runes) != len(results) {
+		return errorf("復元した文字数(%d)が文字数指示子(%d)と一致しません.", len(runes), len(results))
+	}
+	for i := range results {
+		results[i].Kanji = string(runes[i])
+	}
+
+	// 終端パターン (最大4ビットの0) と, バイト境界までの0, 埋め草コード語 (EC, 11) を確かめる
+	terminatorEnd := end + 4
+	if terminatorEnd > len(stream) {
+		terminatorEnd = len(stream)
+	}
+	if strings.Trim(stream[end:terminatorEnd], "0") != "" {
+		return errorf("終端パターンが0000ではありません: %s", stream[end:terminatorEnd])
+	}
+	paddedEnd := (terminatorEnd + 7) / 8 * 8
+	if strings.Trim(stream[terminatorEnd:paddedEnd], "0") != "" {
+		return errorf("バイト境界までの埋め草ビットが0ではありません: %s", stream[terminatorEnd:paddedEnd])
+	}
+	for i, b := range dataBytes[paddedEnd/8:] {
+		if b != padCodewords[i%2] {
+			return errorf("埋め草コード語が EC 11 の繰り返しではありません: %02X", b)
+		}
+	}
+
+	var paddedBinaryBlocks []string
+	for i := 0; i < paddedEnd; i += 8 {
+		paddedBinaryBlocks = append(paddedBinaryBlocks, stream[i:i+8])
+	}
+
+	data.KanjiInput = string(runes)
+	data.Results = results
+	data.Intermediate.ModeIndicator = modeIndicator
+	data.Intermediate.CharCountIndicator = charCountIndicator
+	data.Intermediate.ConcatenatedBinary = concatenated
+	data.Intermediate.TerminatedBinary = stream[:terminatorEnd]
+	data.Intermediate.PaddedBinaryBlocks = strings.Join(paddedBinaryBlocks, " ")
+	return nil
+}
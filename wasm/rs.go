@@ -0,0 +1,111 @@
+package main
+
+// --- 一般の RS(n, k) 符号 (GF(2^8) 上) ---
+
+// QRコードの STEP3 は19バイト + 7バイトに固定されているが, ここでは任意の長さのデータを扱う.
+const maxRSCodeLength = 255 // GF(2^8) 上のRS符号の符号長の上限
+
+// 符号化の方法
+const (
+	rsModeSystematic    = "systematic"     // 組織符号: I(x)·x^(n-k) + (I(x)·x^(n-k) mod g(x))
+	rsModeNonSystematic = "non-systematic" // 非組織符号: I(x)·g(x)
+)
+
+// RSOptions は一般の RS(n, k) 符号の設定
+type RSOptions struct {
+	Mode      string // rsModeSystematic または rsModeNonSystematic
+	FirstRoot int    // 生成多項式の最初の根 α^b の指数 b (QRコードは b = 0, 多くの規格では b = 1)
+}
+
+// defaultRSOptions は QRコードと同じ設定 (組織符号, b = 0) を返す
+func defaultRSOptions() RSOptions {
+	return RSOptions{Mode: rsModeSystematic}
+}
+
+// validate は RS 符号の設定の値を確認する
+func (o RSOptions) validate() error {
+	if o.Mode != rsModeSystematic && o.Mode != rsModeNonSystematic {
+		return errorf("不明な符号化の方法です: %s (systematic または non-systematic を指定してください).", o.Mode)
+	}
+	if o.FirstRoot < 0 || o.FirstRoot > 254 {
+		return errorf("最初の根の指数 b は0〜254で指定してください: %d", o.FirstRoot)
+	}
+	return nil
+}
+
+// RSCodeData は一般の RS(n, k) 符号化の結果
+type RSCodeData struct {
+	Mode      string `json:"Mode"`
+	FirstRoot int    `json:"FirstRoot"` // 生成多項式の根は α^b, α^(b+1), ..., α^(b+n-k-1)
+	N         int    `json:"N"`         // 符号長 (バイト)
+	K         int    `json:"K"`         // データ長 (バイト)
+	Parity    int    `json:"Parity"`    // 誤り訂正コード語の数 n-k
+	ParityHex string `json:"ParityHex"` // 誤り訂正コード語 (組織符号の場合だけ)
+	Generator string `json:"Generator"` // 生成多項式 (LaTeX)
+}
+
+// validateRSParameters はデータ長と誤り訂正コード語の数が GF(2^8) 上のRS符号として成り立つか確かめる
+func validateRSParameters(dataLength, nParity int) error {
+	if dataLength < 1 {
+		return errorf("データを1バイト以上指定してください.")
+	}
+	if nParity < 1 {
+		return errorf("誤り訂正コード語の数は1以上で指定してください: %d", nParity)
+	}
+	if dataLength+nParity > maxRSCodeLength {
+		return errorf("符号長 (データ%dバイト + 誤り訂正%dバイト) が%dを超えています.", dataLength, nParity, maxRSCodeLength)
+	}
+	return nil
+}
+
+// processRSEncode はデータ(2進数)を RS(k+nParity, k) 符号で符号化する.
+// opts で組織符号か非組織符号か, 生成多項式の最初の根を選ぶ.
+func (c *call) processRSEncode(dataBinary string, nParity int, opts RSOptions) (TemplateData, error) {
+	if err := opts.validate(); err != nil {
+		return TemplateData{}, err
+	}
+	dataBytes, err := c.binaryStringToBytes(dataBinary)
+	if err != nil {
+		return TemplateData{}, errorf("データの2進数文字列の解析に失敗しました: %w", err)
+	}
+	if err := validateRSParameters(len(dataBytes), nParity); err != nil {
+		return TemplateData{}, err
+	}
+	codec, err := c.codecFrom(opts.FirstRoot)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	var codewordBytes []byte
+	if opts.Mode == rsModeNonSystematic {
+		codewordBytes, err = codec.EncodeNonSystematic(dataBytes, nParity)
+	} else {
+		codewordBytes, err = codec.Encode(dataBytes, nParity)
+	}
+	if err != nil {
+		return TemplateData{}, fieldError(err)
+	}
+
+	var data TemplateData
+	data.Intermediate.PaddedHex = formatBytesToHex(dataBytes)
+	data.Intermediate.PaddedBinary = formatBytesToBinary(dataBytes)
+	data.Intermediate.DataPolynomial = c.formatPolynomial(bytesToInts(dataBytes), "x")
+	data.Intermediate.CodewordPolynomial = c.formatPolynomial(bytesToInts(codewordBytes), "x")
+	data.Intermediate.DataCoefficients = c.polynomialCoefficients(bytesToInts(dataBytes))
+	data.Intermediate.CodewordCoefficients = c.polynomialCoefficients(bytesToInts(codewordBytes))
+	data.Intermediate.CodewordHex = formatBytesToHex(codewordBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(codewordBytes)
+	data.RS = RSCodeData{
+		Mode:      opts.Mode,
+		FirstRoot: opts.FirstRoot,
+		N:         len(codewordBytes),
+		K:         len(dataBytes),
+		Parity:    nParity,
+		Generator: c.formatPolynomial(codec.Generator(nParity), "x"),
+	}
+	if opts.Mode == rsModeSystematic {
+		data.Intermediate.ErrorCorrectionPolynomial = c.formatPolynomial(bytesToInts(codewordBytes[len(dataBytes):]), "x")
+		data.Intermediate.ErrorCorrectionCoefficients = c.polynomialCoefficients(bytesToInts(codewordBytes[len(dataBytes):]))
+		data.RS.ParityHex = formatBytesToHex(codewordBytes[len(dataBytes):])
+	}
+	return data, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+// --- 応答の版 ---
+
+// 応答の形を互換性のない形で変えたら schemaVersion を上げ, 古い形を求められたときのために変換を残す.
+//
+//	1: 最初の形. KanjiInput, Results, Intermediate, Error (文字列), MaxCharCount だけを持つ.
+//	2: Error をエラーコード付きのオブジェクトにし, SchemaVersion と各機能の項目を加えた.
+const (
+	schemaVersion   = 2
+	schemaVersionV1 = 1
+)
+
+var requestedSchemaVersion = schemaVersion // setSchemaVersion で変えられる. 呼び出しのオプション { schemaVersion } が優先する.
+
+// setSchemaVersion は以降の応答の版を切り替える
+func setSchemaVersion(version int) error {
+	if version != schemaVersion && version != schemaVersionV1 {
+		return errorf("対応していない応答の版です: %d (%d または %d を指定してください).", version, schemaVersionV1, schemaVersion)
+	}
+	requestedSchemaVersion = version
+	return nil
+}
+
+// templateDataV1 は版1の応答の形
+type templateDataV1 struct {
+	KanjiInput   string                   `json:"KanjiInput"`
+	Results      []KanjiCompressionResult `json:"Results"`
+	Intermediate intermediateDataV1       `json:"Intermediate"`
+	Error        string                   `json:"Error"`
+	MaxCharCount int                      `json:"MaxCharCount"`
+}
+
+// intermediateDataV1 は版1の途中経過 (係数の配列を持たない)
+type intermediateDataV1 struct {
+	ModeIndicator             string `json:"ModeIndicator"`
+	CharCountIndicator        string `json:"CharCountIndicator"`
+	ConcatenatedBinary        string `json:"ConcatenatedBinary"`
+	TerminatedBinary          string `json:"TerminatedBinary"`
+	PaddedBinaryBlocks        string `json:"PaddedBinaryBlocks"`
+	PaddedHex                 string `json:"PaddedHex"`
+	PaddedBinary              string `json:"PaddedBinary"`
+	DataPolynomial            string `json:"DataPolynomial"`
+	ErrorCorrectionPolynomial string `json:"ErrorCorrectionPolynomial"`
+	CodewordPolynomial        string `json:"CodewordPolynomial"`
+	CodewordHex               string `json:"CodewordHex"`
+	CodewordBinary            string `json:"CodewordBinary"`
+	MaskPatternHex            string `json:"MaskPatternHex"`
+	MaskedCodewordHex         string `json:"MaskedCodewordHex"`
+	MaskedCodewordBinary      string `json:"MaskedCodewordBinary"`
+}
+
+// versioned は応答を版 version の形にする. TemplateData 以外はそのまま返す.
+func versioned(data interface{}, version int) interface{} {
+	d, ok := data.(TemplateData)
+	if !ok {
+		return data
+	}
+	if version == schemaVersionV1 {
+		return toSchemaV1(d)
+	}
+	d.SchemaVersion = schemaVersion
+	return d
+}
+
+// toSchemaV1 は応答を版1の形にする. 版1にない項目は捨てる.
+func toSchemaV1(d TemplateData) templateDataV1 {
+	v1 := templateDataV1{KanjiInput: d.KanjiInput, Results: d.Results, MaxCharCount: d.MaxCharCount}
+	if d.Error != nil {
+		v1.Error = d.Error.Message
+	}
+	i := d.Intermediate
+	v1.Intermediate = intermediateDataV1{
+		ModeIndicator:             i.ModeIndicator,
+		CharCountIndicator:        i.CharCountIndicator,
+		ConcatenatedBinary:        i.ConcatenatedBinary,
+		TerminatedBinary:          i.TerminatedBinary,
+		PaddedBinaryBlocks:        i.PaddedBinaryBlocks,
+		PaddedHex:                 i.PaddedHex,
+		PaddedBinary:              i.PaddedBinary,
+		DataPolynomial:            i.DataPolynomial,
+		ErrorCorrectionPolynomial: i.ErrorCorrectionPolynomial,
+		CodewordPolynomial:        i.CodewordPolynomial,
+		CodewordHex:               i.CodewordHex,
+		CodewordBinary:            i.CodewordBinary,
+		MaskPatternHex:            i.MaskPatternHex,
+		MaskedCodewordHex:         i.MaskedCodewordHex,
+		MaskedCodewordBinary:      i.MaskedCodewordBinary,
+	}
+	return v1
+}
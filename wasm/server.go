@@ -0,0 +1,69 @@
+//go:build !(js && wasm) && !wasip1
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// --- HTTP の API ---
+
+// WASM を動かせない環境 (古い教室の端末など) や他のサーバーからも同じ処理を使えるように,
+// 符号化の各段階を POST /encode, /ecc, /mask, /decode で公開する.
+// 応答は WASM の関数が返す JSON (TemplateData) と同じで, 入力の誤りは 400, 内部の誤りは 500 で返す.
+// 接続が切れるなどして要求が取り消されると, 処理を区切りでやめる (503 を書くが, 普通は誰も読まない).
+
+// newAPIHandler は apiHandlers を登録した http.Handler を返す
+func newAPIHandler() http.Handler {
+	mux := http.NewServeMux()
+	for name, fn := range apiHandlers {
+		mux.Handle("/"+name, apiHandler(name, fn))
+	}
+	return mux
+}
+
+// apiHandler は要求の本文を読んで fn を呼び, 応答を JSON で書く http.Handler にする
+func apiHandler(name string, fn func(*call, apiRequest) (TemplateData, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeAPIResponse(w, http.StatusMethodNotAllowed, defaultCall().errorData(errorf("%s には対応していません. POST で要求してください.", r.Method)))
+			return
+		}
+		var req apiRequest
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		if err == nil {
+			err = json.Unmarshal(body, &req)
+		}
+		if err != nil {
+			writeAPIResponse(w, http.StatusBadRequest, defaultCall().errorData(errorf("要求の本文を JSON として読めませんでした: %v", err)))
+			return
+		}
+
+		data, err := callAPI(r.Context(), name, req, fn)
+		switch {
+		case err == nil:
+			writeAPIResponse(w, http.StatusOK, data)
+		case hasErrorCode(err, errCodeInternal):
+			writeAPIResponse(w, http.StatusInternalServerError, data)
+		case hasErrorCode(err, errCodeCancelled):
+			writeAPIResponse(w, http.StatusServiceUnavailable, data)
+		default:
+			writeAPIResponse(w, http.StatusBadRequest, data)
+		}
+	})
+}
+
+// writeAPIResponse は前処理を済ませた応答を JSON で書く
+func writeAPIResponse(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	io.WriteString(w, jsonResponse(data))
+}
+
+// serveAPI は addr で HTTP の API を公開する (止まるまで戻らない)
+func serveAPI(addr string) error {
+	return http.ListenAndServe(addr, newAPIHandler())
+}
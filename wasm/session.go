@@ -0,0 +1,116 @@
+package main
+
+// --- パイプラインのセッション ---
+
+// セッションは STEP1-2 → STEP3 → STEP4 の途中のバイト列を Go の中に持っておき,
+// フロントエンドが前の段階の出力 (2進数文字列) を次の段階の入力に写さなくてよいようにする.
+
+const maxSessions = 32 // 同時に開いておけるセッションの数
+
+// セッションで実行できる段階 (画面の STEP の番号に合わせる)
+const (
+	sessionStepData = 2 // STEP1-2: 漢字からデータコード語を作る
+	sessionStepECC  = 3 // STEP3: 誤り訂正コード語を付ける
+	sessionStepMask = 4 // STEP4: マスクを掛ける
+)
+
+// SessionData はセッションの状態
+type SessionData struct {
+	ID            int `json:"ID"`
+	CompletedStep int `json:"CompletedStep"` // 最後に終えた段階 (まだ何もしていなければ0)
+	NextStep      int `json:"NextStep"`      // 次に実行できる段階 (全て終えていれば0)
+}
+
+// pipelineSession はセッションごとの入力と途中のバイト列
+type pipelineSession struct {
+	input         string
+	completedStep int
+	dataBytes     []byte // STEP1-2 の結果 (19バイト)
+	codewordBytes []byte // STEP3 の結果 (26バイト)
+	maskedBytes   []byte // STEP4 の結果 (26バイト)
+}
+
+var sessions = map[int]*pipelineSession{}
+var nextSessionID = 1
+
+// info はセッションの状態を応答用にまとめる
+func (s *pipelineSession) info(id int) SessionData {
+	next := s.completedStep + 1
+	if s.completedStep == 0 {
+		next = sessionStepData
+	}
+	if next > sessionStepMask {
+		next = 0
+	}
+	return SessionData{ID: id, CompletedStep: s.completedStep, NextStep: next}
+}
+
+// processCreateSession は漢字の入力からセッションを作る (まだどの段階も実行しない)
+func (c *call) processCreateSession(kanjiInput string) (TemplateData, error) {
+	if len(sessions) >= maxSessions {
+		return TemplateData{}, errorf("開いているセッションが多すぎます (%d個まで). 使い終わったセッションを closeSession で閉じてください.", maxSessions)
+	}
+	id := nextSessionID
+	nextSessionID++
+	s := &pipelineSession{input: kanjiInput}
+	sessions[id] = s
+
+	data := TemplateData{KanjiInput: kanjiInput, MaxCharCount: maxCharCount}
+	data.Session = s.info(id)
+	return data, nil
+}
+
+// processRunStep はセッションの段階 step を, 前の段階の結果を入力にして実行する.
+// step が0なら次の段階を実行する. 前の段階をやり直すと, それより後の結果は捨てる.
+func (c *call) processRunStep(id, step int) (TemplateData, error) {
+	s, ok := sessions[id]
+	if !ok {
+		return TemplateData{}, errorf("セッション %d はありません.", id)
+	}
+	if step == 0 {
+		step = s.info(id).NextStep
+		if step == 0 {
+			return TemplateData{}, errorf("セッション %d は全ての段階を終えています.", id)
+		}
+	}
+
+	var data TemplateData
+	var err error
+	switch step {
+	case sessionStepData:
+		if data, err = c.processStep1To2(s.input); err != nil {
+			return data, err
+		}
+		s.dataBytes, _ = hexStringToBytes(data.Intermediate.PaddedHex)
+	case sessionStepECC:
+		if s.completedStep < sessionStepData {
+			return TemplateData{}, errorf("STEP3 の前に STEP1-2 を実行してください.")
+		}
+		if data, err = c.processStep3Bytes(s.dataBytes); err != nil {
+			return data, err
+		}
+		s.codewordBytes, _ = hexStringToBytes(data.Intermediate.CodewordHex)
+	case sessionStepMask:
+		if s.completedStep < sessionStepECC {
+			return TemplateData{}, errorf("STEP4 の前に STEP3 を実行してください.")
+		}
+		if data, err = c.processStep4Bytes(s.codewordBytes); err != nil {
+			return data, err
+		}
+		s.maskedBytes, _ = hexStringToBytes(data.Intermediate.MaskedCodewordHex)
+	default:
+		return TemplateData{}, errorf("段階は %d〜%d で指定してください: %d", sessionStepData, sessionStepMask, step)
+	}
+	s.completedStep = step
+	data.Session = s.info(id)
+	return data, nil
+}
+
+// processCloseSession はセッションを閉じて途中の結果を捨てる
+func (c *call) processCloseSession(id int) (TemplateData, error) {
+	if _, ok := sessions[id]; !ok {
+		return TemplateData{}, errorf("セッション %d はありません.", id)
+	}
+	delete(sessions, id)
+	return TemplateData{}, nil
+}
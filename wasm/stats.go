@@ -0,0 +1,76 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// --- 実行時の統計 ---
+
+// 処理の遅い環境 (学生のノートPCなど) で, どこに時間が掛かっているかを調べるために使う.
+
+// StatsData はメモリ確保, GC, 関数の呼び出しにかかった時間の統計
+type StatsData struct {
+	Mallocs        uint64          `json:"Mallocs"`        // これまでに確保したオブジェクトの数
+	Frees          uint64          `json:"Frees"`          // これまでに解放したオブジェクトの数
+	HeapAlloc      uint64          `json:"HeapAlloc"`      // 使用中のヒープのバイト数
+	TotalAlloc     uint64          `json:"TotalAlloc"`     // これまでに確保したバイト数の合計
+	NumGC          uint32          `json:"NumGC"`          // GC の回数
+	PauseTotalMs   float64         `json:"PauseTotalMs"`   // GC で止まった時間の合計 (ミリ秒)
+	RecentPausesMs []float64       `json:"RecentPausesMs"` // 直近の GC の停止時間 (新しい順, 最大16回)
+	Calls          []CallStatsData `json:"Calls"`          // 関数ごとの呼び出しの統計 (登録した順)
+	LastPipeline   []StepTiming    `json:"LastPipeline"`   // 最後のパイプライン (変換〜マスク) の段階ごとの時間
+}
+
+// CallStatsData は1つの関数の呼び出しの統計
+type CallStatsData struct {
+	Name    string  `json:"Name"`
+	Count   int     `json:"Count"`
+	LastMs  float64 `json:"LastMs"`  // 最後の呼び出しにかかった時間 (ミリ秒)
+	TotalMs float64 `json:"TotalMs"` // 呼び出しにかかった時間の合計 (ミリ秒)
+}
+
+// StepTiming はパイプラインの1段階 (progress の段階の名前) にかかった時間
+type StepTiming struct {
+	Step string  `json:"Step"`
+	Ms   float64 `json:"Ms"` // 前の段階 (または呼び出しの始め) からの時間 (ミリ秒)
+}
+
+const maxRecentPauses = 16
+
+var callStats = map[string]*CallStatsData{}
+
+// lastPipeline は最後のパイプラインの段階ごとの時間. HTTP の API では呼び出しが同時に記録するので statsMu で守る.
+var (
+	statsMu      sync.Mutex
+	lastPipeline []StepTiming
+)
+
+// recordStep はパイプラインの段階 step が elapsed で終わったことを記録する. 変換の段階から新しい実行として数え直す.
+func recordStep(step string, elapsed time.Duration) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	if step == progressConversion {
+		lastPipeline = nil
+	}
+	lastPipeline = append(lastPipeline, StepTiming{Step: step, Ms: milliseconds(elapsed)})
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// processStats は現在の統計を返す
+func (c *call) processStats() TemplateData {
+	var data TemplateData
+	statsMu.Lock()
+	data.Stats = StatsData{LastPipeline: append([]StepTiming(nil), lastPipeline...)}
+	statsMu.Unlock()
+	readMemoryStats(&data.Stats)
+	for _, name := range exportedFunctions {
+		if stats, ok := callStats[name]; ok {
+			data.Stats.Calls = append(data.Stats.Calls, *stats)
+		}
+	}
+	return data
+}
@@ -0,0 +1,26 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+	"time"
+)
+
+// timed は fn の呼び出しにかかった時間を name の統計に加える関数にする
+func timed(name string, fn func(this js.Value, args []js.Value) interface{}) func(this js.Value, args []js.Value) interface{} {
+	return func(this js.Value, args []js.Value) interface{} {
+		start := time.Now()
+		defer func() {
+			stats, ok := callStats[name]
+			if !ok {
+				stats = &CallStatsData{Name: name}
+				callStats[name] = stats
+			}
+			stats.Count++
+			stats.LastMs = milliseconds(time.Since(start))
+			stats.TotalMs += stats.LastMs
+		}()
+		return fn(this, args)
+	}
+}
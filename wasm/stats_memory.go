@@ -0,0 +1,22 @@
+//go:build !tinygo
+
+package main
+
+import (
+	"runtime"
+	"time"
+)
+
+// readMemoryStats はメモリ確保と GC の統計を stats に書く
+func readMemoryStats(stats *StatsData) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	stats.Mallocs, stats.Frees, stats.HeapAlloc, stats.TotalAlloc = m.Mallocs, m.Frees, m.HeapAlloc, m.TotalAlloc
+	stats.NumGC = m.NumGC
+	stats.PauseTotalMs = milliseconds(time.Duration(m.PauseTotalNs))
+	// PauseNs は循環バッファで, 最新の停止時間は PauseNs[(NumGC+255)%256] にある
+	for i := 0; i < maxRecentPauses && uint32(i) < m.NumGC; i++ {
+		pause := m.PauseNs[(int(m.NumGC)-1-i+len(m.PauseNs))%len(m.PauseNs)]
+		stats.RecentPausesMs = append(stats.RecentPausesMs, milliseconds(time.Duration(pause)))
+	}
+}
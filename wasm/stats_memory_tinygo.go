@@ -0,0 +1,13 @@
+//go:build tinygo
+
+package main
+
+import "runtime"
+
+// readMemoryStats はメモリ確保の統計を stats に書く.
+// TinyGo の runtime は GC の回数と停止時間を記録しないので, NumGC, PauseTotalMs, RecentPausesMs は0と空のままにする.
+func readMemoryStats(stats *StatsData) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	stats.Mallocs, stats.Frees, stats.HeapAlloc, stats.TotalAlloc = m.Mallocs, m.Frees, m.HeapAlloc, m.TotalAlloc
+}
@@ -0,0 +1,31 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// js/wasm 以外の入口 (callAPI) でも, 最後のパイプラインの段階の時間を呼び出しの始めから測ることを確かめる
+
+func TestCallAPIStepTiming(t *testing.T) {
+	const pause = 50 * time.Millisecond
+	for i := 0; i < 2; i++ {
+		if _, err := callAPI(context.Background(), "encode", apiRequest{Input: "漢字"}, apiHandlers["encode"]); err != nil {
+			t.Fatal(err)
+		}
+		pipeline := defaultCall().processStats().Stats.LastPipeline
+		if len(pipeline) == 0 || pipeline[0].Step != progressConversion {
+			t.Fatalf("LastPipeline = %+v", pipeline)
+		}
+		// 前の呼び出しの終わりや時刻0から測ると, 呼び出しの間に待った時間より長くなる
+		for _, step := range pipeline {
+			if step.Ms < 0 || step.Ms >= milliseconds(pause) {
+				t.Errorf("%d回目の呼び出しの %s が %.3fms です", i+1, step.Step, step.Ms)
+			}
+		}
+		time.Sleep(pause)
+	}
+}
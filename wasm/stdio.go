@@ -0,0 +1,57 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// --- 標準入出力の API ---
+
+// 1行に1つの JSON の要求 {"command": "encode", "input": "漢字", ...} を読み, 1行に1つの JSON の応答を書く.
+// command は apiHandlers の名前 (encode, ecc, mask, decode) で, ほかの項目は apiRequest と同じ.
+// WASI (wasip1) で作った場合の入口で, wasmtime や wazero から採点のスクリプトなどで使う.
+
+// stdioRequest は標準入力の1行の要求
+type stdioRequest struct {
+	Command string `json:"command"`
+	apiRequest
+}
+
+// serveStdio は r から要求を1行ずつ読み, 応答を1行ずつ w に書く. r が終わると nil を返す.
+func serveStdio(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxRequestBytes)
+	out := bufio.NewWriter(w)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		io.WriteString(out, stdioResponse(line))
+		out.WriteByte('\n')
+		// 1行ごとに書き出し, 要求と応答を交互にやり取りする呼び出し側が待たされないようにする
+		if err := out.Flush(); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// stdioResponse は1行の要求を処理して, 応答の JSON を返す
+func stdioResponse(line []byte) string {
+	var req stdioRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return jsonResponse(defaultCall().errorData(errorf("要求を JSON として読めませんでした: %v", err)))
+	}
+	fn, ok := apiHandlers[req.Command]
+	if !ok {
+		return jsonResponse(defaultCall().errorData(errorf("不明なコマンドです: %s (encode, ecc, mask, decode のどれかを指定してください).", req.Command)))
+	}
+	data, _ := callAPI(context.Background(), req.Command, req.apiRequest, fn)
+	return jsonResponse(data)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mocho271828/rs_coding-compresser/internal/kanji"
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// processStep1To2 は漢字入力からデータコード語を生成する (STEP 1-2)
+func (c *call) processStep1To2(kanjiInput string) (TemplateData, error) {
+	data := TemplateData{MaxCharCount: maxCharCount, KanjiInput: kanjiInput}
+	runes := []rune(kanjiInput)
+
+	if len(runes) == 0 {
+		return data, newCodedError(errCodeEmpty, "", noErrorPosition, "漢字が入力されていません.")
+	}
+	if len(runes) > maxCharCount {
+		return data, newCodedError(errCodeTooLong, fmt.Sprint(len(runes)), maxCharCount, "文字数が多すぎます. %d文字以下で入力してください.", maxCharCount)
+	}
+
+	stream, units, err := encodeSegments(kanjiInput)
+	if err != nil {
+		return data, errorf("圧縮処理中にエラーが発生しました: %w", err)
+	}
+	for _, u := range units {
+		data.Results = append(data.Results, unitResult(u))
+	}
+
+	data.Intermediate.ModeIndicator = stream.ModeIndicator
+	data.Intermediate.CharCountIndicator = stream.CharCountIndicator
+	data.Intermediate.ConcatenatedBinary = stream.Body
+	data.Intermediate.TerminatedBinary = stream.Terminated
+	c.reportProgress(progressConversion, data.Intermediate.ConcatenatedBinary)
+	c.reportProgress(progressBitAssembly, stream.Terminated)
+
+	var paddedBinaryBlocks []string
+	for i := 0; i < len(stream.Padded); i += 8 {
+		paddedBinaryBlocks = append(paddedBinaryBlocks, stream.Padded[i:i+8])
+	}
+	data.Intermediate.PaddedBinaryBlocks = strings.Join(paddedBinaryBlocks, " ")
+
+	dataBytes := stream.Bytes
+	data.Intermediate.PaddedHex = formatBytesToHex(dataBytes)
+	data.Intermediate.PaddedBinary = formatBytesToBinary(dataBytes)
+	c.reportProgress(progressPadding, data.Intermediate.PaddedHex)
+
+	return data, nil
+}
+
+// processStep3 はデータコード語(2進数)からRS符号化を行う (STEP 3)
+func (c *call) processStep3(dataCodewordsBinary string) (TemplateData, error) {
+	dataBytes, err := c.binaryStringToBytes(dataCodewordsBinary)
+	if err != nil {
+		return TemplateData{}, errorf("データコード語の2進数文字列の解析に失敗しました: %w", err)
+	}
+	return c.processStep3Bytes(dataBytes)
+}
+
+// processStep3Bytes はデータコード語(バイト列)からRS符号化を行う (STEP 3)
+func (c *call) processStep3Bytes(dataBytes []byte) (TemplateData, error) {
+	if len(dataBytes) != dataCodewordCount {
+		return TemplateData{}, newCodedError(errCodeBadLength, fmt.Sprint(len(dataBytes)), noErrorPosition, "データコード語は19バイトである必要がありますが, %dバイトでした.", len(dataBytes))
+	}
+
+	dataPoly := bytesToInts(dataBytes)
+	generatorPoly := c.lookupGeneratorPolynomial(eccCodewordCount)
+	remainderPoly, err := c.polyDiv(polyLeftShift(dataPoly, eccCodewordCount), generatorPoly)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	codewordPoly := polyAdd(polyLeftShift(dataPoly, eccCodewordCount), remainderPoly)
+	codewordBytes := intsToBytes(codewordPoly)
+	c.reportProgress(progressDivision, formatBytesToHex(codewordBytes[dataCodewordCount:]))
+
+	var data TemplateData
+	data.Intermediate.PaddedHex = formatBytesToHex(dataBytes)
+	data.Intermediate.PaddedBinary = formatBytesToBinary(dataBytes)
+	// 剰余を求める割り算を筆算の形でも返す
+	if _, _, data.Polynomial.DivisionRows, err = c.polyDivTrace(polyLeftShift(dataPoly, eccCodewordCount), generatorPoly); err != nil {
+		return TemplateData{}, err
+	}
+	data.Intermediate.DataPolynomial = c.formatPolynomial(dataPoly, "x")
+	data.Intermediate.ErrorCorrectionPolynomial = c.formatPolynomial(remainderPoly, "x")
+	data.Intermediate.CodewordPolynomial = c.formatPolynomial(codewordPoly, "x")
+	data.Intermediate.DataCoefficients = c.polynomialCoefficients(dataPoly)
+	data.Intermediate.ErrorCorrectionCoefficients = c.polynomialCoefficients(remainderPoly)
+	data.Intermediate.CodewordCoefficients = c.polynomialCoefficients(codewordPoly)
+	data.Intermediate.CodewordHex = formatBytesToHex(codewordBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(codewordBytes)
+
+	return data, nil
+}
+
+// processStep4 は符号語(2進数)にマスク処理を行う (STEP 4)
+func (c *call) processStep4(codewordBinary string) (TemplateData, error) {
+	codewordBytes, err := c.binaryStringToBytes(codewordBinary)
+	if err != nil {
+		return TemplateData{}, errorf("符号語の2進数文字列の解析に失敗しました: %w", err)
+	}
+	return c.processStep4Bytes(codewordBytes)
+}
+
+// processStep4Bytes は符号語(バイト列)にマスク処理を行う (STEP 4)
+func (c *call) processStep4Bytes(codewordBytes []byte) (TemplateData, error) {
+	if len(codewordBytes) != totalCodewordCount {
+		return TemplateData{}, newCodedError(errCodeBadLength, fmt.Sprint(len(codewordBytes)), noErrorPosition, "符号語は26バイトである必要がありますが, %dバイトでした.", len(codewordBytes))
+	}
+
+	maskBytes := c.maskPatternBytes()
+	maskedBytes := make([]byte, len(codewordBytes))
+	for i := range codewordBytes {
+		maskedBytes[i] = codewordBytes[i] ^ maskBytes[i]
+	}
+	c.reportProgress(progressMasking, formatBytesToHex(maskedBytes))
+
+	var data TemplateData
+	// マスク適用前のデータも返す
+	data.Intermediate.CodewordHex = formatBytesToHex(codewordBytes)
+	data.Intermediate.CodewordBinary = formatBytesToBinary(codewordBytes)
+	data.Intermediate.MaskPatternHex = formatBytesToHex(maskBytes)
+	data.Intermediate.MaskedCodewordHex = formatBytesToHex(maskedBytes)
+	data.Intermediate.MaskedCodewordBinary = formatBytesToBinary(maskedBytes)
+
+	return data, nil
+}
+
+// --- 符号化モード (qr.ModeEncoder) ---
+
+// encodeSegments は入力を qr.DefaultSegmenter で区間に分け, 各区間のモードで符号化してデータコード語を組み立てる.
+// 漢字モードの範囲外の文字は位置の分かるエラーにする.
+func encodeSegments(kanjiInput string) (qr.DataStream, []qr.Unit, error) {
+	segments, err := qr.DefaultSegmenter.Segment(kanjiInput)
+	if err != nil {
+		return qr.DataStream{}, nil, err
+	}
+	stream, units, err := qr.AssembleSegments(segments)
+	var rangeErr *kanji.RangeError
+	if errors.As(err, &rangeErr) {
+		return qr.DataStream{}, nil, newCodedError(errCodeSJISRange, string(rangeErr.Rune), rangeErr.Index, "'%s' (%04X) はサポート外のShift-JISコード範囲です", string(rangeErr.Rune), rangeErr.Code)
+	}
+	if err != nil {
+		return qr.DataStream{}, nil, errorf("Shift-JISへの変換に失敗しました: %v", err)
+	}
+	return stream, units, nil
+}
+
+// unitResult は符号化の1単位の過程を表示用の文字列にする. 漢字モード以外は元の文字と符号化した値だけを埋める.
+func unitResult(u qr.Unit) KanjiCompressionResult {
+	if c, ok := u.Detail.(kanji.Char); ok {
+		return compressionResult(c)
+	}
+	return KanjiCompressionResult{
+		Kanji:         u.Text,
+		CompressedHex: fmt.Sprintf("%04X", u.Value),
+		Binary13Bit:   fmt.Sprintf("%0*b", u.Bits, u.Value),
+	}
+}
+
+// compressionResult は1文字の圧縮の過程を表示用の文字列にする
+func compressionResult(c kanji.Char) KanjiCompressionResult {
+	r := KanjiCompressionResult{
+		ShiftJISCode:   fmt.Sprintf("%04X", c.ShiftJIS),
+		SubtractedCode: fmt.Sprintf("%04X - %04X = %04X", c.ShiftJIS, c.Offset, c.Subtracted),
+		CompressedHex:  fmt.Sprintf("%04X", c.Value),
+		Binary13Bit:    fmt.Sprintf("%0*b", kanji.Bits, c.Value),
+	}
+	if c.Rune != 0 {
+		r.Kanji = string(c.Rune)
+	}
+	return r
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"github.com/mocho271828/rs_coding-compresser/internal/gf"
+	"github.com/mocho271828/rs_coding-compresser/internal/kanji"
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// --- グローバル変数, 定数, 構造体定義 ---
+
+const maxCharCount = qr.MaxKanjiChars // 型番1, 漢字モードの最大文字数
+
+// 型番1-L の符号語の数と, データの組み立てに使う値 (getConstants で JS にも渡す). 値は qr パッケージで決める.
+const (
+	dataCodewordCount  = qr.DataCodewords    // データコード語の数
+	eccCodewordCount   = qr.ECCCodewords     // 誤り訂正コード語の数
+	totalCodewordCount = qr.TotalCodewords   // 符号語の総数
+	modeIndicatorKanji = kanji.ModeIndicator // 漢字モードのモード指示子
+	terminatorPattern  = qr.Terminator       // 終端パターン
+)
+
+var padCodewords = qr.PadCodewords // 埋め草コード語 (交互に繰り返す)
+
+const primitivePolynomial = gf.QRPolynomial // 原始多項式: x^8 + x^4 + x^3 + x^2 + 1
+
+// マスクパターン (参照子000: (i+j) mod 2 = 0). バイト列は NewEncoder で配置順から導出する.
+const defaultMaskPattern = 0
+
+// --- 構造体定義 (JSON出力用にタグを追加) ---
+
+type TemplateData struct {
+	SchemaVersion   int                      `json:"SchemaVersion"` // 応答の版 (schema.go)
+	KanjiInput      string                   `json:"KanjiInput"`
+	Results         []KanjiCompressionResult `json:"Results"`
+	Intermediate    QRCodeIntermediateData   `json:"Intermediate"`
+	Matrix          QRMatrixData             `json:"Matrix"`
+	BitPosition     BitPositionData          `json:"BitPosition"`
+	Mask            MaskDerivationData       `json:"Mask"`
+	MaskComparisons []MaskComparisonData     `json:"MaskComparisons"`
+	Render          RenderData               `json:"Render"`
+	Worksheet       WorksheetData            `json:"Worksheet"`
+	Decoding        DecodingData             `json:"Decoding"`
+	Burst           BurstData                `json:"Burst"`
+	Field           FieldData                `json:"Field"`
+	Polynomial      PolynomialData           `json:"Polynomial"`
+	RS              RSCodeData               `json:"RS"`
+	Quiz            QuizData                 `json:"Quiz"`
+	CodeMatrix      CodeMatrixData           `json:"CodeMatrix"`
+	Session         SessionData              `json:"Session"`
+	Capabilities    CapabilitiesData         `json:"Capabilities"`
+	Stats           StatsData                `json:"Stats"`
+	Validation      ValidationData           `json:"Validation"`
+	Chunk           ChunkData                `json:"Chunk"`
+	Constants       ConstantsData            `json:"Constants"`
+	Error           *ErrorInfo               `json:"Error"` // エラーがなければ null
+	MaxCharCount    int                      `json:"MaxCharCount"`
+}
+
+type KanjiCompressionResult struct {
+	Kanji          string `json:"Kanji"`
+	ShiftJISCode   string `json:"ShiftJISCode"`
+	SubtractedCode string `json:"SubtractedCode"`
+	CompressedHex  string `json:"CompressedHex"`
+	Binary13Bit    string `json:"Binary13Bit"`
+}
+
+type QRCodeIntermediateData struct {
+	ModeIndicator               string                 `json:"ModeIndicator"`
+	CharCountIndicator          string                 `json:"CharCountIndicator"`
+	ConcatenatedBinary          string                 `json:"ConcatenatedBinary"`
+	TerminatedBinary            string                 `json:"TerminatedBinary"`
+	PaddedBinaryBlocks          string                 `json:"PaddedBinaryBlocks"`
+	PaddedHex                   string                 `json:"PaddedHex"`
+	PaddedBinary                string                 `json:"PaddedBinary"`
+	DataPolynomial              string                 `json:"DataPolynomial"`
+	ErrorCorrectionPolynomial   string                 `json:"ErrorCorrectionPolynomial"`
+	CodewordPolynomial          string                 `json:"CodewordPolynomial"`
+	DataCoefficients            PolynomialCoefficients `json:"DataCoefficients"`
+	ErrorCorrectionCoefficients PolynomialCoefficients `json:"ErrorCorrectionCoefficients"`
+	CodewordCoefficients        PolynomialCoefficients `json:"CodewordCoefficients"`
+	CodewordHex                 string                 `json:"CodewordHex"`
+	CodewordBinary              string                 `json:"CodewordBinary"`
+	MaskPatternHex              string                 `json:"MaskPatternHex"`
+	MaskedCodewordHex           string                 `json:"MaskedCodewordHex"`
+	MaskedCodewordBinary        string                 `json:"MaskedCodewordBinary"`
+}
+
+// PolynomialCoefficients は多項式の係数 (最高次から) を10進数, 16進数, α の指数で並べたもの.
+// フロントエンドで LaTeX 以外の表記を作るときに使う.
+type PolynomialCoefficients struct {
+	Decimal   []int    `json:"Decimal"`
+	Hex       []string `json:"Hex"`
+	Exponents []int    `json:"Exponents"` // 係数を α^k で表したときの k (係数が0の場合は -1)
+}
@@ -0,0 +1,105 @@
+package main
+
+import "fmt"
+
+// --- 自習用の答え合わせ ---
+
+// 利用者が手で求めた途中の値 (2進数文字列) を, 入力から計算した正しい値と比べる.
+
+// 答え合わせする段階
+const (
+	validationDataCodewords  = "dataCodewords"  // STEP1-2 のデータコード語 (19バイト)
+	validationCodeword       = "codeword"       // STEP3 の符号語 (26バイト)
+	validationMaskedCodeword = "maskedCodeword" // STEP4 のマスク後の符号語 (26バイト)
+)
+
+// ValidationData は答え合わせの結果
+type ValidationData struct {
+	Stage       string           `json:"Stage"`
+	Valid       bool             `json:"Valid"`
+	ExpectedHex string           `json:"ExpectedHex"`
+	ActualHex   string           `json:"ActualHex"`
+	Discrepancy *DiscrepancyData `json:"Discrepancy"` // 最初に食い違った箇所 (正しければ null)
+	Message     string           `json:"Message"`
+}
+
+// DiscrepancyData は最初に食い違った箇所
+type DiscrepancyData struct {
+	ByteIndex int    `json:"ByteIndex"` // 何バイト目か (0始まり)
+	BitIndex  int    `json:"BitIndex"`  // そのバイトの何ビット目か (最上位が0. 長さが違う場合は -1)
+	Position  int    `json:"Position"`  // 全体で何ビット目か (0始まり)
+	Expected  string `json:"Expected"`  // 正しいバイト (2進数. 足りない場合は空)
+	Actual    string `json:"Actual"`    // 入力されたバイト (2進数. 足りない場合は空)
+}
+
+// processValidate は kanjiInput から段階 stage の正しい値を求め, 利用者の値 actualBinary と比べる
+func (c *call) processValidate(stage, kanjiInput, actualBinary string) (TemplateData, error) {
+	expected, err := c.processEncodeAll(kanjiInput, "", defaultRenderOptions())
+	if err != nil {
+		return expected, err
+	}
+	var expectedHex string
+	switch stage {
+	case validationDataCodewords:
+		expectedHex = expected.Intermediate.PaddedHex
+	case validationCodeword:
+		expectedHex = expected.Intermediate.CodewordHex
+	case validationMaskedCodeword:
+		expectedHex = expected.Intermediate.MaskedCodewordHex
+	default:
+		return TemplateData{}, errorf("不明な段階です: %s", stage)
+	}
+	expectedBytes, _ := hexStringToBytes(expectedHex)
+	actualBytes, err := c.binaryStringToBytes(actualBinary)
+	if err != nil {
+		return TemplateData{}, errorf("入力された2進数文字列の解析に失敗しました: %w", err)
+	}
+
+	data := TemplateData{KanjiInput: kanjiInput, MaxCharCount: maxCharCount}
+	v := &data.Validation
+	v.Stage = stage
+	v.ExpectedHex = expectedHex
+	v.ActualHex = formatBytesToHex(actualBytes)
+	v.Discrepancy = firstDiscrepancy(expectedBytes, actualBytes)
+	v.Valid = v.Discrepancy == nil
+	switch {
+	case v.Valid:
+		v.Message = c.localize("正解です.")
+	case v.Discrepancy.BitIndex < 0:
+		v.Message = c.localize("長さが違います. %dバイトである必要がありますが, %dバイトでした.", len(expectedBytes), len(actualBytes))
+	default:
+		v.Message = c.localize("%dバイト目の%dビット目 (全体で%dビット目) が違います.", v.Discrepancy.ByteIndex, v.Discrepancy.BitIndex, v.Discrepancy.Position)
+	}
+	return data, nil
+}
+
+// firstDiscrepancy は expected と actual が最初に食い違う箇所を返す (同じなら nil)
+func firstDiscrepancy(expected, actual []byte) *DiscrepancyData {
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		if diff := expected[i] ^ actual[i]; diff != 0 {
+			bit := bitLength(int(diff))
+			return &DiscrepancyData{
+				ByteIndex: i,
+				BitIndex:  8 - bit,
+				Position:  i*8 + 8 - bit,
+				Expected:  fmt.Sprintf("%08b", expected[i]),
+				Actual:    fmt.Sprintf("%08b", actual[i]),
+			}
+		}
+	}
+	if len(expected) == len(actual) {
+		return nil
+	}
+	i := len(expected)
+	if len(actual) < i {
+		i = len(actual)
+	}
+	d := &DiscrepancyData{ByteIndex: i, BitIndex: -1, Position: i * 8}
+	if i < len(expected) {
+		d.Expected = fmt.Sprintf("%08b", expected[i])
+	}
+	if i < len(actual) {
+		d.Actual = fmt.Sprintf("%08b", actual[i])
+	}
+	return d
+}
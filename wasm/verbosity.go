@@ -0,0 +1,58 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+// --- 応答の詳しさ ---
+
+// 呼び出しのオプション { verbosity: "compact" } を指定すると, 最終的なバイト列だけが必要なプログラムのために
+// 重い項目 (LaTeX の式, 1文字ごと・1段階ごとの経過, 行列) を空にして応答を小さくする.
+const (
+	verbosityFull    = "full"    // 全ての項目を返す (既定)
+	verbosityCompact = "compact" // 重い項目を省く
+)
+
+// compactResponse は data の重い項目を空にした写しを返す. TemplateData 以外はそのまま返す.
+func compactResponse(data interface{}) interface{} {
+	d, ok := data.(TemplateData)
+	if !ok {
+		return data
+	}
+	clearHeavyFields(reflect.ValueOf(&d).Elem())
+	return d
+}
+
+// clearHeavyFields は構造体 v の中の重い項目を再帰的にゼロ値にする
+func clearHeavyFields(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		if isHeavyField(field) {
+			value.Set(reflect.Zero(field.Type))
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct {
+			clearHeavyFields(value)
+		}
+	}
+}
+
+// isHeavyField は省く項目かを返す: LaTeX の文字列, 入力1文字ごとの結果, 途中経過 (…Step, …Row の配列), 行列
+func isHeavyField(field reflect.StructField) bool {
+	switch field.Type.Kind() {
+	case reflect.String:
+		return strings.HasSuffix(field.Name, "Latex") || strings.HasSuffix(field.Name, "Polynomial")
+	case reflect.Slice:
+		elem := field.Type.Elem()
+		if field.Name == "Results" || elem.Kind() == reflect.Slice {
+			return true
+		}
+		return strings.HasSuffix(elem.Name(), "Step") || strings.HasSuffix(elem.Name(), "Row")
+	}
+	return false
+}
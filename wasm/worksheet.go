@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/mocho271828/rs_coding-compresser/qr"
+)
+
+// --- 演習用ワークシート ---
+
+// WorksheetData は穴埋め式のワークシートとその解答 (いずれも印刷用のHTML文書)
+type WorksheetData struct {
+	Worksheet string `json:"Worksheet"`
+	AnswerKey string `json:"AnswerKey"`
+}
+
+// worksheetStyle はワークシートと解答に共通のCSS
+const worksheetStyle = `<style>
+body { font-family: sans-serif; margin: 20mm; }
+h1 { font-size: 18pt; } h2 { font-size: 13pt; margin-top: 8mm; }
+table.answers { border-collapse: collapse; }
+table.answers th, table.answers td { border: 1px solid #333; padding: 2mm 3mm; min-width: 18mm; height: 7mm; font-family: monospace; }
+.boxes span { display: inline-block; border: 1px solid #333; width: 9mm; height: 7mm; margin: 0 1mm 1mm 0; text-align: center; line-height: 7mm; font-family: monospace; }
+table.grid { border-collapse: collapse; }
+table.grid td { width: 5mm; height: 5mm; border: 1px solid #999; padding: 0; }
+table.grid td.function.dark { background: #000; } table.grid td.function.light { background: #fff; }
+table.grid td.answer.dark { background: #000; }
+</style>`
+
+// processWorksheet は漢字入力から STEP1〜4 を行い, 空欄のワークシートと, 同じ形で答えを埋めた解答を作る
+func (c *call) processWorksheet(kanjiInput string) (TemplateData, error) {
+	step2, err := c.processStep1To2(kanjiInput)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	step3, err := c.processStep3(step2.Intermediate.PaddedBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	codewordBytes, err := c.parseCodewordBinary(step3.Intermediate.CodewordBinary)
+	if err != nil {
+		return TemplateData{}, err
+	}
+	symbol, err := buildSymbol(codewordBytes, c.opts.MaskPattern)
+	if err != nil {
+		return TemplateData{}, err
+	}
+
+	var data TemplateData
+	data.KanjiInput = kanjiInput
+	data.Worksheet.Worksheet = buildWorksheetHTML(kanjiInput, step2.Results, codewordBytes, symbol, false)
+	data.Worksheet.AnswerKey = buildWorksheetHTML(kanjiInput, step2.Results, codewordBytes, symbol, true)
+	return data, nil
+}
+
+// buildWorksheetHTML はワークシートのHTMLを組み立てる. withAnswers がfalseの場合は答えの欄を空欄にする.
+func buildWorksheetHTML(kanjiInput string, results []KanjiCompressionResult, codewordBytes []byte, symbol *qr.Matrix, withAnswers bool) string {
+	answer := func(s string) string {
+		if withAnswers {
+			return html.EscapeString(s)
+		}
+		return ""
+	}
+
+	var b strings.Builder
+	title := "QRコード 符号化ワークシート"
+	if withAnswers {
+		title += " (解答)"
+	}
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html lang=\"ja\">\n<head>\n<meta charset=\"UTF-8\">\n<title>%s</title>\n%s\n</head>\n<body>\n", title, worksheetStyle)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n<p>入力: <strong>%s</strong></p>\n", title, html.EscapeString(kanjiInput))
+
+	b.WriteString("<h2>STEP1: 13ビット圧縮</h2>\n<table class=\"answers\">\n<tr><th>漢字</th><th>Shift JIS</th><th>減算後</th><th>圧縮後</th><th>13ビット</th></tr>\n")
+	for _, res := range results {
+		fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(res.Kanji), answer(res.ShiftJISCode), answer(res.SubtractedCode), answer(res.CompressedHex), answer(res.Binary13Bit))
+	}
+	b.WriteString("</table>\n")
+
+	b.WriteString("<h2>STEP2: データコード語 (16進数, 19バイト)</h2>\n<div class=\"boxes\">")
+	for _, v := range codewordBytes[:19] {
+		fmt.Fprintf(&b, "<span>%s</span>", answer(fmt.Sprintf("%02X", v)))
+	}
+	b.WriteString("</div>\n")
+
+	b.WriteString("<h2>STEP3: 誤り訂正コード語 (16進数, 7バイト)</h2>\n<div class=\"boxes\">")
+	for _, v := range codewordBytes[19:] {
+		fmt.Fprintf(&b, "<span>%s</span>", answer(fmt.Sprintf("%02X", v)))
+	}
+	b.WriteString("</div>\n")
+
+	// 機能パターンは描いておき, データ領域は空欄 (解答では暗モジュールを塗る) にする
+	b.WriteString("<h2>STEP4: シンボル (マスク適用後)</h2>\n<table class=\"grid\">\n")
+	for r := 0; r < symbol.Size; r++ {
+		b.WriteString("<tr>")
+		for c := 0; c < symbol.Size; c++ {
+			shade := "light"
+			if symbol.Modules[r][c] == qr.ModuleDark {
+				shade = "dark"
+			}
+			switch {
+			case symbol.IsFunction(r, c) && symbol.Region[r][c] != qr.RegionFormat:
+				fmt.Fprintf(&b, `<td class="function %s"></td>`, shade)
+			case withAnswers:
+				fmt.Fprintf(&b, `<td class="answer %s"></td>`, shade)
+			default:
+				b.WriteString(`<td></td>`)
+			}
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n</body>\n</html>\n")
+	return b.String()
+}
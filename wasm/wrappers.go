@@ -0,0 +1,1021 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+// generateDataCodewordsWrapper は STEP1-2 を行う
+func generateDataCodewordsWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processStep1To2(args[0].String())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// encodeAllWrapper は STEP1-2 から描画までを一度に行う.
+// 第2引数 (省略可) は描画オプションに { format } (描画形式. 既定は "svg", 空文字列なら描画しない) を加えたオブジェクト.
+func encodeAllWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	format := "svg"
+	if len(args) == 2 && args[1].Type() == js.TypeObject {
+		if v := args[1].Get("format"); v.Type() == js.TypeString {
+			format = v.String()
+		}
+	}
+	data, err := c.processEncodeAll(args[0].String(), format, renderOptionsArg(args, 1))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// applyEccWrapper は STEP3 を行う. データコード語は2進数文字列か Uint8Array で渡す.
+func applyEccWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 引数は2進数文字列か Uint8Array
+	var data TemplateData
+	var err error
+	if bytes, ok := uint8ArrayArg(args[0]); ok {
+		data, err = c.processStep3Bytes(bytes)
+	} else {
+		data, err = c.processStep3(args[0].String())
+	}
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// applyMaskWrapper は STEP4 を行う. 符号語は2進数文字列か Uint8Array で渡す.
+func applyMaskWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 引数は2進数文字列か Uint8Array
+	var data TemplateData
+	var err error
+	if bytes, ok := uint8ArrayArg(args[0]); ok {
+		data, err = c.processStep4Bytes(bytes)
+	} else {
+		data, err = c.processStep4(args[0].String())
+	}
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getMatrixWrapper は符号語からシンボル行列を各段階ごとに組み立てる
+func getMatrixWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を2進数文字列(マスク前の符号語), 第2引数(省略可)をクワイエットゾーンの幅として受け取る
+	data, err := c.processMatrix(args[0].String(), optionalIntArg(args, 1, 0))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// bitToModuleWrapper は符号語番号とビット位置から対応するモジュールの座標を求める
+func bitToModuleWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processBitToModule(args[0].Int(), args[1].Int())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// moduleToBitWrapper はモジュールの座標(行, 列)から対応する符号語番号とビット位置を求める
+func moduleToBitWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processModuleToBit(args[0].Int(), args[1].Int())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getMaskPatternWrapper は指定したパターン番号のバイト単位のマスクとその導出過程を返す
+func getMaskPatternWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processMaskPattern(args[0].Int())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderMaskPatternWrapper は指定したパターン番号のマスクパターンそのものをSVGとして描く
+func renderMaskPatternWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数をパターン番号, 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processRenderMaskPattern(args[0].Int(), opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// extractCodewordsWrapper は21×21の行列から符号語を読み取り, マスクを解除する
+func extractCodewordsWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 引数を行列 (JSON配列または0/1の文字列) として受け取る
+	data, err := c.processExtractCodewords(args[0].String())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// compareMasksWrapper は8種類のマスクパターンを適用したシンボルの失点を比較する
+func compareMasksWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 引数を2進数文字列(マスク前の符号語)として受け取る
+	data, err := c.processCompareMasks(args[0].String())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderSVGWrapper は符号語から最終的なシンボルを組み立て, SVG文字列として返す
+func renderSVGWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を2進数文字列(マスク前の符号語), 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processRender(args[0].String(), "svg", opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderAnimatedSVGWrapper は符号語が1つずつ配置され, マスクが適用される様子をアニメーションするSVGを返す
+func renderAnimatedSVGWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を2進数文字列(マスク前の符号語), 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processRender(args[0].String(), "svg-animated", opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderPNGWrapper は符号語から最終的なシンボルを組み立て, Base64エンコードしたPNGとして返す
+func renderPNGWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を2進数文字列(マスク前の符号語), 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processRender(args[0].String(), "png", opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderPBMWrapper は符号語から最終的なシンボルを組み立て, テキスト形式のPBM (P1) として返す
+func renderPBMWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を2進数文字列(マスク前の符号語), 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processRender(args[0].String(), "pbm", opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderXBMWrapper は符号語から最終的なシンボルを組み立て, XBM (C言語の配列) として返す
+func renderXBMWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を2進数文字列(マスク前の符号語), 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processRender(args[0].String(), "xbm", opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderTextWrapper は符号語から最終的なシンボルを組み立て, ブロック文字によるテキストとして返す
+func renderTextWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を2進数文字列(マスク前の符号語), 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processRender(args[0].String(), "text", opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderHTMLWrapper は符号語から最終的なシンボルを組み立て, 領域ごとのクラスを付けたHTMLの表として返す
+func renderHTMLWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を2進数文字列(マスク前の符号語), 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processRender(args[0].String(), "html", opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderSourceMapWrapper は漢字入力から, 各モジュールの由来となった符号語・入力文字と, それで色分けしたSVGを返す
+func renderSourceMapWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を漢字文字列, 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processSourceMap(args[0].String(), opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// renderPDFWrapper は漢字入力からシンボルと計算過程をまとめた1ページのPDFを生成する
+func renderPDFWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	// 第1引数を漢字文字列, 第2引数(省略可)を描画オプションのオブジェクトとして受け取る
+	opts := renderOptionsArg(args, 1)
+	data, err := c.processRenderPDF(args[0].String(), opts)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// generateWorksheetWrapper は漢字入力から穴埋め式のワークシートと解答を生成する
+func generateWorksheetWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processWorksheet(args[0].String())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// computeSyndromesWrapper は受信した符号語(2進数)のシンドロームを計算する.
+// 第2引数(省略可)の { firstRoot } で代入する α^b の最初の指数を選べる.
+func computeSyndromesWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processSyndromes(args[0].String(), rsOptionsArg(args, 1).FirstRoot)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// correctCodewordWrapper は受信した符号語(2進数)の誤りを訂正する.
+// 第2引数で誤り位置多項式の求め方 ("berlekamp-massey" または "euclid") を選べる.
+// 第3引数に消失位置の配列を渡すと, 誤りと消失を合わせて訂正する.
+func correctCodewordWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 3 {
+		return c.errorResponse(errArgumentCount)
+	}
+	var erasures []int
+	if len(args) > 2 && args[2].Type() != js.TypeUndefined && args[2].Type() != js.TypeNull {
+		var err error
+		if erasures, err = intSliceArg(args[2]); err != nil {
+			return c.errorResponse(err)
+		}
+	}
+	data, err := c.processCorrect(args[0].String(), optionalStringArg(args, 1, decodeMethodBerlekampMassey), erasures)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// correctErasuresWrapper は受信した符号語(2進数)と消失位置の配列から消失を訂正する
+func correctErasuresWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	erasures, err := intSliceArg(args[1])
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	data, err := c.processCorrectErasures(args[0].String(), erasures)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// injectErrorsWrapper は符号語(2進数)に, 誤りの個数と乱数の種から決まる誤りを加える
+func injectErrorsWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 3 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processInjectErrors(args[0].String(), args[1].Int(), int64(args[2].Int()))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// simulateBurstWrapper は符号語(2進数)を複数ブロックとして送り, バースト誤りに対するインターリーブの効果を調べる.
+// 第4引数でブロックの数を指定できる (省略時は2).
+func simulateBurstWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 3 || len(args) > 4 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processBurst(args[0].String(), args[1].Int(), args[2].Int(), optionalIntArg(args, 3, 2))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// decodeAllWrapper はマスク後の符号語(2進数)から元の漢字を復元する
+func decodeAllWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processDecodeAll(args[0].String())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// verifyCodewordWrapper は符号語(2進数)が正しい符号語かどうかを調べる (訂正は行わない)
+func verifyCodewordWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processVerifyCodeword(args[0].String())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// checkAnswerWrapper はマスク後の符号語(2進数)から復元した文字列と成否だけを返す (答え合わせ用)
+func checkAnswerWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	return c.respond(c.processCheckAnswer(args[0].String()))
+}
+
+// validateDataCodewordsWrapper は利用者が求めたデータコード語(2進数)を, 入力の漢字から求めた値と比べる
+func validateDataCodewordsWrapper(c *call, args []js.Value) interface{} {
+	return c.validationResponse(validationDataCodewords, args)
+}
+
+// validateCodewordWrapper は利用者が求めた符号語(2進数, マスク前)を, 入力の漢字から求めた値と比べる
+func validateCodewordWrapper(c *call, args []js.Value) interface{} {
+	return c.validationResponse(validationCodeword, args)
+}
+
+// validateMaskedCodewordWrapper は利用者が求めたマスク後の符号語(2進数)を, 入力の漢字から求めた値と比べる
+func validateMaskedCodewordWrapper(c *call, args []js.Value) interface{} {
+	return c.validationResponse(validationMaskedCodeword, args)
+}
+
+// validationResponse は答え合わせの関数に共通の処理. 第1引数を漢字の入力, 第2引数を利用者の値として受け取る.
+func (c *call) validationResponse(stage string, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processValidate(stage, args[0].String(), args[1].String())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getCorrectionCapacityWrapper は符号の訂正能力 (t, 最小距離, 消失訂正能力) を返す
+func getCorrectionCapacityWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 0 {
+		return c.errorResponse(errArgumentCount)
+	}
+	return c.respond(c.processCapacity())
+}
+
+// repairCodewordWrapper は利用者が編集した符号語(16進数)の誤りを訂正し, 読み取れる文字列を返す
+func repairCodewordWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processRepair(args[0].String())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// gfAddWrapper は GF(2^8) の和 a + b を求める
+func gfAddWrapper(c *call, args []js.Value) interface{} {
+	return c.fieldOperationResponse("add", 2, args)
+}
+
+// gfMulWrapper は GF(2^8) の積 a × b を求める
+func gfMulWrapper(c *call, args []js.Value) interface{} {
+	return c.fieldOperationResponse("mul", 2, args)
+}
+
+// gfDivWrapper は GF(2^8) の商 a ÷ b を求める
+func gfDivWrapper(c *call, args []js.Value) interface{} {
+	return c.fieldOperationResponse("div", 2, args)
+}
+
+// gfInvWrapper は GF(2^8) の逆元 a^(-1) を求める
+func gfInvWrapper(c *call, args []js.Value) interface{} {
+	return c.fieldOperationResponse("inv", 1, args)
+}
+
+// gfPowWrapper は GF(2^8) の累乗 a^n を求める
+func gfPowWrapper(c *call, args []js.Value) interface{} {
+	return c.fieldOperationResponse("pow", 2, args)
+}
+
+// getGFTablesWrapper は指数表と対数表を返す. 第1引数(省略可)が true なら16×16の表も返す.
+func getGFTablesWrapper(c *call, args []js.Value) interface{} {
+	if len(args) > 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	withGrid := len(args) == 1 && args[0].Type() == js.TypeBoolean && args[0].Bool()
+	return c.respond(c.processGFTables(c.field, withGrid))
+}
+
+// setPrimitivePolynomialWrapper は体を作る原始多項式を変え, 新しい指数表と対数表を返す.
+// 以降の符号化と復号は全て新しい体で行われる (0x11D で元に戻る).
+func setPrimitivePolynomialWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processSetPrimitivePolynomial(args[0].Int())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getFieldTablesWrapper は GF(2^m) (m = 3〜16) の指数表と対数表を返す.
+// 第2引数(省略可)で原始多項式を, 第3引数(省略可)が true なら16列の表も返す.
+func getFieldTablesWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 3 {
+		return c.errorResponse(errArgumentCount)
+	}
+	f, err := newGaloisField(args[0].Int(), optionalIntArg(args, 1, 0))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	withGrid := len(args) == 3 && args[2].Type() == js.TypeBoolean && args[2].Bool()
+	return c.respond(c.processGFTables(f, withGrid))
+}
+
+// fieldOperationWrapper は GF(2^m) で演算 ("add", "mul", "div", "inv", "pow") を行う.
+// 引数は (m, 演算, a, b, 原始多項式) で, inv では b を, 原始多項式は省略できる.
+func fieldOperationWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 3 || len(args) > 5 {
+		return c.errorResponse(errArgumentCount)
+	}
+	f, err := newGaloisField(args[0].Int(), optionalIntArg(args, 4, 0))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	operation := args[1].String()
+	operands := []int{args[2].Int()}
+	if operation != "inv" {
+		if len(args) < 4 {
+			return c.errorResponse(errArgumentCount)
+		}
+		operands = append(operands, args[3].Int())
+	}
+	data, err := c.processFieldOperation(f, operation, operands...)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getConjugacyClassesWrapper は GF(2^m) の共役類と最小多項式を返す.
+// 引数は (m, 元, 原始多項式) で, 元を省略すると全ての共役類を, 原始多項式を省略すると既定のものを使う.
+func getConjugacyClassesWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 3 {
+		return c.errorResponse(errArgumentCount)
+	}
+	f, err := newGaloisField(args[0].Int(), optionalIntArg(args, 2, 0))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	data, err := c.processConjugacyClasses(f, optionalIntArg(args, 1, -1))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getElementOrderWrapper は GF(2^m) の元の位数と, 原始元かどうかを返す.
+// 引数は (m, 元, 原始多項式) で, 原始多項式は省略できる.
+func getElementOrderWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 2 || len(args) > 3 {
+		return c.errorResponse(errArgumentCount)
+	}
+	f, err := newGaloisField(args[0].Int(), optionalIntArg(args, 2, 0))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	data, err := c.processElementOrder(f, args[1].Int())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// mapFieldElementWrapper は原始多項式の異なる2つの GF(2^m) の間の同型写像を返す.
+// 引数は (m, 変換元の原始多項式, 変換先の原始多項式, 元) で, 元を省略すると対応表だけを返す.
+func mapFieldElementWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 3 || len(args) > 4 {
+		return c.errorResponse(errArgumentCount)
+	}
+	from, err := newGaloisField(args[0].Int(), args[1].Int())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	to, err := newGaloisField(args[0].Int(), args[2].Int())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	data, err := c.processFieldIsomorphism(from, to, optionalIntArg(args, 3, -1))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// polyEvalWrapper は多項式 (係数の配列または α表記の文字列) に値を代入する. 第3引数(省略可)が true ならホーナー法の過程も返す.
+func polyEvalWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 2 || len(args) > 3 {
+		return c.errorResponse(errArgumentCount)
+	}
+	p, err := c.polynomialArg(args[0])
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	withTrace := len(args) == 3 && args[2].Type() == js.TypeBoolean && args[2].Bool()
+	data, err := c.processPolyEval(p, args[1].Int(), withTrace)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// polyMulWrapper は2つの多項式 (係数の配列) の積を求める. 第3引数(省略可)が true なら部分積も返す.
+func polyMulWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 2 || len(args) > 3 {
+		return c.errorResponse(errArgumentCount)
+	}
+	p1, err := c.polynomialArg(args[0])
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	p2, err := c.polynomialArg(args[1])
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	withTrace := len(args) == 3 && args[2].Type() == js.TypeBoolean && args[2].Bool()
+	data, err := c.processPolyMul(p1, p2, withTrace)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// polyGCDWrapper は2つの多項式 (係数の配列) の最大公約式を互除法の過程とともに求める
+func polyGCDWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	p1, err := c.polynomialArg(args[0])
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	p2, err := c.polynomialArg(args[1])
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	data, err := c.processPolyGCD(p1, p2)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// polyDivWrapper は多項式 (係数の配列) どうしの割り算を行う. 第3引数(省略可)が true なら筆算の各行も返す.
+func polyDivWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 2 || len(args) > 3 {
+		return c.errorResponse(errArgumentCount)
+	}
+	dividend, err := c.polynomialArg(args[0])
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	divisor, err := c.polynomialArg(args[1])
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	withTrace := len(args) == 3 && args[2].Type() == js.TypeBoolean && args[2].Bool()
+	data, err := c.processPolyDiv(dividend, divisor, withTrace)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getGeneratorPolynomialWrapper は次数を指定して生成多項式を求める. 第2引数(省略可)が true なら途中の積も返す.
+func getGeneratorPolynomialWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	withSteps := len(args) == 2 && args[1].Type() == js.TypeBoolean && args[1].Bool()
+	data, err := c.processGeneratorPolynomial(args[0].Int(), withSteps)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getGeneratorTableWrapper は QRコードで使う全ての次数の生成多項式の表を返す
+func getGeneratorTableWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 0 {
+		return c.errorResponse(errArgumentCount)
+	}
+	return c.respond(c.processGeneratorTable())
+}
+
+// setCoefficientFormatWrapper は以降に出力する多項式の係数の表し方 ("alpha", "decimal", "hex") を切り替える
+func setCoefficientFormatWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	if err := setCoefficientFormat(args[0].String()); err != nil {
+		return c.errorResponse(err)
+	}
+	var data TemplateData
+	data.Polynomial.CoefficientFormat = coefficientFormat
+	return c.respond(data)
+}
+
+// setResponseFormatWrapper は以降の関数が返す形式を "json" (JSON文字列, 既定) か "object" (JSのオブジェクト) に切り替える
+func setResponseFormatWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	if err := setResponseFormat(args[0].String()); err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(TemplateData{})
+}
+
+// setLocaleWrapper は以降のエラーや説明の言語を "ja" (既定) か "en" に切り替える. エラーコードは変わらない.
+func setLocaleWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	if err := setLocale(args[0].String()); err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(TemplateData{})
+}
+
+// setSchemaVersionWrapper は以降の応答の版を切り替える. 1 を指定すると最初の形 (Error が文字列) で返す.
+func setSchemaVersionWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	if err := setSchemaVersion(args[0].Int()); err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(TemplateData{})
+}
+
+// appendChunkWrapper は入力の断片 (文字列は UTF-8 のバイト列として, または Uint8Array) を Go 側に溜める
+func appendChunkWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	chunk, ok := uint8ArrayArg(args[0])
+	if !ok {
+		chunk = []byte(args[0].String())
+	}
+	data, err := c.processAppendChunk(chunk)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// finishChunksWrapper は溜めた断片を1つの入力にまとめる
+func finishChunksWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 0 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processFinishChunks()
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// createSessionWrapper は漢字の入力からパイプラインのセッションを作る
+func createSessionWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processCreateSession(args[0].String())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// runStepWrapper はセッションの段階 (2: STEP1-2, 3: STEP3, 4: STEP4) を実行する. 第2引数を省略すると次の段階を実行する.
+func runStepWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 1 || len(args) > 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processRunStep(args[0].Int(), optionalIntArg(args, 1, 0))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// closeSessionWrapper はセッションを閉じる
+func closeSessionWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processCloseSession(args[0].Int())
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getCapabilitiesWrapper はモジュールの版と対応している機能の一覧を返す
+func getCapabilitiesWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 0 {
+		return c.errorResponse(errArgumentCount)
+	}
+	return c.respond(c.processCapabilities())
+}
+
+// getStatsWrapper はメモリ確保, GC, 関数ごとの処理時間の統計を返す
+func getStatsWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 0 {
+		return c.errorResponse(errArgumentCount)
+	}
+	return c.respond(c.processStats())
+}
+
+// shutdownWrapper は登録した関数を全て解放してプログラムを終わらせる. 以後はモジュールの関数を呼べない.
+func shutdownWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 0 {
+		return c.errorResponse(errArgumentCount)
+	}
+	if err := shutdown(); err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(TemplateData{})
+}
+
+// getConstantsWrapper はモード指示子, 埋め草コード語, 原始多項式, 容量などの定数を返す
+func getConstantsWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 0 {
+		return c.errorResponse(errArgumentCount)
+	}
+	return c.respond(c.processConstants())
+}
+
+// getTypeDefinitionsWrapper は応答の TypeScript の型定義 (.d.ts) を文字列で返す
+func getTypeDefinitionsWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 0 {
+		return c.errorResponse(errArgumentCount)
+	}
+	return typeDefinitions
+}
+
+// rsEncodeWrapper は任意の長さのデータ(2進数)に, 指定した数の誤り訂正コード語を付ける.
+// 第3引数(省略可)は符号化の方法 ("systematic" (既定) か "non-systematic") か,
+// { mode, firstRoot } のオプションのオブジェクト.
+func rsEncodeWrapper(c *call, args []js.Value) interface{} {
+	if len(args) < 2 || len(args) > 3 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processRSEncode(args[0].String(), args[1].Int(), rsOptionsArg(args, 2))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// generateGFQuizWrapper は GF(2^8) の計算問題を解答付きで作る (問題の数, 乱数の種)
+func generateGFQuizWrapper(c *call, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processGFQuiz(args[0].Int(), int64(args[1].Int()))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// getCodeMatricesWrapper は RS(26, 19) 符号の生成行列と検査行列を返す.
+// 第1引数(省略可)に受信語(2進数)を渡すと, シンドロームを行列とベクトルの積として計算する.
+func getCodeMatricesWrapper(c *call, args []js.Value) interface{} {
+	if len(args) > 1 {
+		return c.errorResponse(errArgumentCount)
+	}
+	data, err := c.processCodeMatrices(optionalStringArg(args, 0, ""))
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// fieldOperationResponse は argCount 個の整数の引数で体の演算を行い, 結果のJSONを返す
+func (c *call) fieldOperationResponse(operation string, argCount int, args []js.Value) interface{} {
+	if len(args) != argCount {
+		return c.errorResponse(errArgumentCount)
+	}
+	operands := make([]int, argCount)
+	for i := range operands {
+		operands[i] = args[i].Int()
+	}
+	data, err := c.processFieldOperation(c.field, operation, operands...)
+	if err != nil {
+		return c.errorResponse(err)
+	}
+	return c.respond(data)
+}
+
+// uint8ArrayArg は v が Uint8Array ならその内容をコピーして返す
+func uint8ArrayArg(v js.Value) ([]byte, bool) {
+	if v.Type() != js.TypeObject || !v.InstanceOf(js.Global().Get("Uint8Array")) {
+		return nil, false
+	}
+	bytes := make([]byte, v.Length())
+	js.CopyBytesToGo(bytes, v)
+	return bytes, true
+}
+
+// intSliceArg は数値の配列 (JSの配列またはJSON文字列) を読み取る
+func intSliceArg(v js.Value) ([]int, error) {
+	if v.Type() == js.TypeString {
+		values, err := parseJSONIntArray(v.String())
+		if err != nil {
+			return nil, errorf("数値の配列として解釈できません: %v", err)
+		}
+		return values, nil
+	}
+	if v.Type() != js.TypeObject {
+		return nil, errorf("数値の配列を指定してください.")
+	}
+	values := make([]int, v.Length())
+	for i := range values {
+		item := v.Index(i)
+		if item.Type() != js.TypeNumber {
+			return nil, errorf("配列の%d番目が数値ではありません.", i)
+		}
+		values[i] = item.Int()
+	}
+	return values, nil
+}
+
+// polynomialArg は多項式の引数を読み取る. 係数の配列 (JSの配列またはJSON文字列) のほか,
+// "a^5 x^3 + a^2 x + 1" のような α表記の文字列も受け付ける.
+func (c *call) polynomialArg(v js.Value) ([]int, error) {
+	if v.Type() == js.TypeString && !strings.HasPrefix(strings.TrimSpace(v.String()), "[") {
+		return c.parsePolynomial(v.String())
+	}
+	return intSliceArg(v)
+}
+
+// optionalIntArg は args[index] が数値ならその値を, 省略されていれば既定値を返す
+func optionalIntArg(args []js.Value, index, defaultValue int) int {
+	if len(args) > index && args[index].Type() == js.TypeNumber {
+		return args[index].Int()
+	}
+	return defaultValue
+}
+
+// optionalStringArg は args[index] が文字列ならその値を, 省略されていれば既定値を返す
+func optionalStringArg(args []js.Value, index int, defaultValue string) string {
+	if len(args) > index && args[index].Type() == js.TypeString {
+		return args[index].String()
+	}
+	return defaultValue
+}
+
+// renderOptionsArg は args[index] のオブジェクトから描画オプションを読み取る.
+// { moduleSize, margin, foreground, background, dataURI, halfBlock, invert, colorBy } のうち, 省略された項目は既定値とする.
+func renderOptionsArg(args []js.Value, index int) RenderOptions {
+	opts := defaultRenderOptions()
+	if len(args) <= index || args[index].Type() != js.TypeObject {
+		return opts
+	}
+	o := args[index]
+	if v := o.Get("moduleSize"); v.Type() == js.TypeNumber {
+		opts.ModuleSize = v.Int()
+	}
+	if v := o.Get("margin"); v.Type() == js.TypeNumber {
+		opts.Margin = v.Int()
+	}
+	if v := o.Get("foreground"); v.Type() == js.TypeString {
+		opts.Foreground = v.String()
+	}
+	if v := o.Get("background"); v.Type() == js.TypeString {
+		opts.Background = v.String()
+	}
+	if v := o.Get("dataURI"); v.Type() == js.TypeBoolean {
+		opts.DataURI = v.Bool()
+	}
+	if v := o.Get("halfBlock"); v.Type() == js.TypeBoolean {
+		opts.HalfBlock = v.Bool()
+	}
+	if v := o.Get("invert"); v.Type() == js.TypeBoolean {
+		opts.Invert = v.Bool()
+	}
+	if v := o.Get("colorBy"); v.Type() == js.TypeString {
+		opts.ColorBy = v.String()
+	}
+	return opts
+}
+
+// rsOptionsArg は RS 符号の設定を読み取る. 文字列の場合は符号化の方法として扱う.
+func rsOptionsArg(args []js.Value, index int) RSOptions {
+	opts := defaultRSOptions()
+	if len(args) <= index {
+		return opts
+	}
+	o := args[index]
+	switch o.Type() {
+	case js.TypeString:
+		opts.Mode = o.String()
+	case js.TypeObject:
+		if v := o.Get("mode"); v.Type() == js.TypeString {
+			opts.Mode = v.String()
+		}
+		if v := o.Get("firstRoot"); v.Type() == js.TypeNumber {
+			opts.FirstRoot = v.Int()
+		}
+	}
+	return opts
+}